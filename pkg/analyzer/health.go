@@ -2,20 +2,44 @@ package analyzer
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/nareshku/kubectl-container-status/pkg/types"
 )
 
+// notReadyGracePeriod is how long a running container may stay not ready before it is flagged
+const notReadyGracePeriod = 2 * time.Minute
+
+// containerStartupGrace is how long a new pod's containers may wait in ContainerCreating or PodInitializing,
+// e.g. while a large image is pulled, before they count as stuck
+const containerStartupGrace = 5 * time.Minute
+
 // Analyzer handles health analysis and scoring
-type Analyzer struct{}
+type Analyzer struct {
+	referenceTime time.Time // Time the analyzed state was observed, see UseReferenceTime; zero for now
+}
 
 // New creates a new analyzer instance
 func New() *Analyzer {
 	return &Analyzer{}
 }
 
+// UseReferenceTime makes grace periods and restart recency count up to the given time rather than now,
+// for state observed in the past such as a pod dump
+func (a *Analyzer) UseReferenceTime(t time.Time) {
+	a.referenceTime = t
+}
+
+// since returns the time elapsed from t until the reference time
+func (a *Analyzer) since(t time.Time) time.Duration {
+	if a.referenceTime.IsZero() {
+		return time.Since(t)
+	}
+	return a.referenceTime.Sub(t)
+}
+
 // AnalyzeWorkloadHealth analyzes the overall health of a workload
 func (a *Analyzer) AnalyzeWorkloadHealth(workload types.WorkloadInfo) types.HealthStatus {
 	if len(workload.Pods) == 0 {
@@ -29,6 +53,7 @@ func (a *Analyzer) AnalyzeWorkloadHealth(workload types.WorkloadInfo) types.Heal
 	totalScore := 0
 	criticalIssues := 0
 	degradedIssues := 0
+	startingPods := 0
 
 	for _, pod := range workload.Pods {
 		podHealth := a.AnalyzePodHealth(pod)
@@ -38,6 +63,8 @@ func (a *Analyzer) AnalyzeWorkloadHealth(workload types.WorkloadInfo) types.Heal
 			criticalIssues++
 		} else if podHealth.Level == string(types.HealthLevelDegraded) {
 			degradedIssues++
+		} else if podHealth.Reason == ReasonStarting {
+			startingPods++
 		}
 	}
 
@@ -61,6 +88,13 @@ func (a *Analyzer) AnalyzeWorkloadHealth(workload types.WorkloadInfo) types.Heal
 		} else {
 			reason = fmt.Sprintf("%d pods have issues", degradedIssues)
 		}
+	} else if startingPods > 0 {
+		level = types.HealthLevelHealthy
+		if startingPods == 1 {
+			reason = "1 pod starting"
+		} else {
+			reason = fmt.Sprintf("%d pods starting", startingPods)
+		}
 	} else {
 		level = types.HealthLevelHealthy
 		reason = "all pods running normally"
@@ -87,15 +121,39 @@ func (a *Analyzer) AnalyzePodHealth(pod types.PodInfo) types.HealthStatus {
 		}
 	}
 
+	// Evicted pods never come back, whatever their containers last reported
+	if a.IsPodEvicted(pod) {
+		return types.HealthStatus{
+			Level:  string(types.HealthLevelCritical),
+			Reason: evictionReason(pod.StatusMessage),
+			Score:  0,
+		}
+	}
+
+	// Check for pods that outlived their termination grace period
+	if a.isPodStuckTerminating(pod) {
+		reason := fmt.Sprintf("stuck terminating for %s (grace period %s)",
+			pod.TerminatingFor.Round(time.Second), pod.TerminationGracePeriod)
+		if len(pod.Finalizers) > 0 {
+			reason += fmt.Sprintf(", finalizers: %s", strings.Join(pod.Finalizers, ", "))
+		}
+		return types.HealthStatus{
+			Level:  string(types.HealthLevelCritical),
+			Reason: reason,
+			Score:  0,
+		}
+	}
+
 	// Check container statuses
 	allContainers := append(pod.InitContainers, pod.Containers...)
 
 	criticalContainers := 0
 	degradedContainers := 0
+	startingContainers := 0
 	totalRestarts := int32(0)
 
 	for _, container := range allContainers {
-		containerHealth := a.analyzeContainerHealth(container)
+		containerHealth := a.analyzePodContainer(pod, container)
 		totalRestarts += container.RestartCount
 
 		if containerHealth.Level == string(types.HealthLevelCritical) {
@@ -104,6 +162,9 @@ func (a *Analyzer) AnalyzePodHealth(pod types.PodInfo) types.HealthStatus {
 		} else if containerHealth.Level == string(types.HealthLevelDegraded) {
 			degradedContainers++
 			score -= 15
+		} else if isContainerStarting(container) {
+			startingContainers++
+			continue
 		}
 
 		if containerHealth.Reason != "" {
@@ -122,6 +183,14 @@ func (a *Analyzer) AnalyzePodHealth(pod types.PodInfo) types.HealthStatus {
 		} else {
 			reason = "containers in critical state"
 		}
+	} else if a.IsRunningWithNoContainersReady(pod) {
+		// Each container may only look degraded, but together the pod can't serve anything
+		level = types.HealthLevelCritical
+		reason = "running but no containers ready"
+		if len(issues) > 0 {
+			reason += " (" + issues[0] + ")"
+		}
+		score -= 30
 	} else if degradedContainers > 0 {
 		level = types.HealthLevelDegraded
 		if len(issues) > 0 {
@@ -129,6 +198,14 @@ func (a *Analyzer) AnalyzePodHealth(pod types.PodInfo) types.HealthStatus {
 		} else {
 			reason = "containers have issues"
 		}
+	} else if flapping := a.readinessFlapping(pod); flapping != "" {
+		// Every container looks fine right now, but readiness has been coming and going
+		level = types.HealthLevelDegraded
+		reason = flapping
+		score -= 10
+	} else if startingContainers > 0 {
+		level = types.HealthLevelHealthy
+		reason = ReasonStarting
 	} else {
 		level = types.HealthLevelHealthy
 		reason = "all containers running normally"
@@ -146,6 +223,51 @@ func (a *Analyzer) AnalyzePodHealth(pod types.PodInfo) types.HealthStatus {
 	}
 }
 
+// AnalyzeContainers records the health of every container in the pod on the container itself
+func (a *Analyzer) AnalyzeContainers(pod *types.PodInfo) {
+	for _, containers := range [][]types.ContainerInfo{pod.InitContainers, pod.Containers, pod.EphemeralContainers} {
+		for i := range containers {
+			containers[i].Health = a.analyzePodContainer(*pod, containers[i])
+		}
+	}
+}
+
+// analyzePodContainer analyzes a container knowing its pod's age: a container still being created or
+// waiting for init containers is starting while the pod is younger than containerStartupGrace, and stuck
+// after that
+func (a *Analyzer) analyzePodContainer(pod types.PodInfo, container types.ContainerInfo) types.HealthStatus {
+	health := a.analyzeContainerHealth(container)
+	if isContainerStarting(container) && pod.Age >= containerStartupGrace {
+		return types.HealthStatus{
+			Level:  string(types.HealthLevelDegraded),
+			Reason: fmt.Sprintf("stuck in %s for %s", container.Status, pod.Age.Round(time.Second)),
+			Score:  50,
+		}
+	}
+	return health
+}
+
+// ReasonStarting is the reason given to young pods and containers that are Healthy only because they are
+// still being created, before anything has run that could fail
+const ReasonStarting = "starting"
+
+// IsStarting reports whether a Healthy workload still has pods that are starting, so it has not proven
+// itself healthy yet
+func IsStarting(workload types.WorkloadInfo) bool {
+	for _, pod := range workload.Pods {
+		if pod.Health.Reason == ReasonStarting {
+			return true
+		}
+	}
+	return false
+}
+
+// isContainerStarting checks whether a container is waiting for the ordinary steps of a new pod:
+// its sandbox, volumes and image (ContainerCreating) or the init containers (PodInitializing)
+func isContainerStarting(container types.ContainerInfo) bool {
+	return container.Status == "ContainerCreating" || container.Status == "PodInitializing"
+}
+
 // analyzeContainerHealth analyzes the health of a single container
 func (a *Analyzer) analyzeContainerHealth(container types.ContainerInfo) types.HealthStatus {
 	score := 100
@@ -175,6 +297,13 @@ func (a *Analyzer) analyzeContainerHealth(container types.ContainerInfo) types.H
 		level = types.HealthLevelCritical
 		reason = "cannot pull container image"
 		score = 0
+	case "ContainerCreating", "PodInitializing":
+		// Probes and usage mean nothing before the container starts; analyzePodContainer judges how long is too long
+		return types.HealthStatus{
+			Level:  string(types.HealthLevelHealthy),
+			Reason: ReasonStarting,
+			Score:  100,
+		}
 	case string(types.ContainerStatusWaiting):
 		level = types.HealthLevelDegraded
 		reason = "container waiting to start"
@@ -218,6 +347,19 @@ func (a *Analyzer) analyzeContainerHealth(container types.ContainerInfo) types.H
 		score = 0
 	}
 
+	// A startup probe that hasn't passed means the app is still starting; past its deadline the kubelet kills it
+	if !container.Probes.Startup.Passing && container.Probes.Startup.Configured && container.Status == string(types.ContainerStatusRunning) {
+		if a.startupDeadlineExceeded(container) {
+			level = types.HealthLevelCritical
+			reason = "startup probe failing past its deadline"
+			score = 0
+		} else if level == types.HealthLevelHealthy {
+			level = types.HealthLevelDegraded
+			reason = "startup probe has not passed yet"
+			score -= 15
+		}
+	}
+
 	if !container.Probes.Readiness.Passing && container.Probes.Readiness.Configured {
 		if level == types.HealthLevelHealthy {
 			level = types.HealthLevelDegraded
@@ -226,6 +368,13 @@ func (a *Analyzer) analyzeContainerHealth(container types.ContainerInfo) types.H
 		score -= 15
 	}
 
+	// Running but never becoming ready, even without a readiness probe to blame
+	if a.isRunningButNotReady(container) && level == types.HealthLevelHealthy {
+		level = types.HealthLevelDegraded
+		reason = "running but not ready"
+		score -= 15
+	}
+
 	// Check resource usage - focus on actual constraints that affect performance
 	if container.Resources.MemPercentage > 85 {
 		if level == types.HealthLevelHealthy {
@@ -262,6 +411,36 @@ func (a *Analyzer) analyzeContainerHealth(container types.ContainerInfo) types.H
 	}
 }
 
+// isRunningButNotReady checks whether a regular container has been running longer than the grace period without becoming ready
+func (a *Analyzer) isRunningButNotReady(container types.ContainerInfo) bool {
+	if container.Type == string(types.ContainerTypeInit) || container.Status != string(types.ContainerStatusRunning) || container.Ready {
+		return false
+	}
+	return container.StartedAt != nil && a.since(*container.StartedAt) > notReadyGracePeriod
+}
+
+// IsRunningWithNoContainersReady checks whether a pod past its startup grace period is Running while none
+// of its regular containers is ready, e.g. because every readiness probe is failing
+func (a *Analyzer) IsRunningWithNoContainersReady(pod types.PodInfo) bool {
+	if pod.Status != "Running" || len(pod.Containers) == 0 || pod.Age <= notReadyGracePeriod {
+		return false
+	}
+	for _, container := range pod.Containers {
+		if container.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// startupDeadlineExceeded checks whether a container has been starting for longer than its startup probe allows
+func (a *Analyzer) startupDeadlineExceeded(container types.ContainerInfo) bool {
+	if container.StartedAt == nil || container.Probes.Startup.Deadline <= 0 {
+		return false
+	}
+	return a.since(*container.StartedAt) > container.Probes.Startup.Deadline
+}
+
 // hasRecentRestarts checks if container has had restarts in the last hour
 func (a *Analyzer) hasRecentRestarts(container types.ContainerInfo) bool {
 	// Check if there are restarts and the container was recently started
@@ -277,7 +456,7 @@ func (a *Analyzer) hasRecentRestarts(container types.ContainerInfo) bool {
 
 	// Only consider restarts "recent" if the container started very recently
 	// Focus on truly current instability, not historical issues
-	return time.Since(*container.StartedAt) < 5*time.Minute
+	return a.since(*container.StartedAt) < 5*time.Minute
 }
 
 // isPodStuckInInitialization checks if a pod is stuck in initialization phase for more than 10 minutes
@@ -307,6 +486,131 @@ func (a *Analyzer) isPodStuckInInitialization(pod types.PodInfo) bool {
 	return pod.Age > 10*time.Minute
 }
 
+// isPodStuckTerminating checks if a terminating pod has exceeded its termination grace period
+func (a *Analyzer) isPodStuckTerminating(pod types.PodInfo) bool {
+	if pod.Status != "Terminating" || pod.TerminatingFor == 0 {
+		return false
+	}
+
+	return pod.TerminatingFor > pod.TerminationGracePeriod
+}
+
+// readinessFlapWindow is how recently a pod must have become ready again after a restart for the restart
+// to count as readiness coming and going
+const readinessFlapWindow = 10 * time.Minute
+
+// readinessFlapping guesses from a single observation whether a ready pod's readiness is flapping, and
+// describes why. Only direct evidence counts: readiness probe failures reported since the pod last became
+// ready (failing, but not yet failureThreshold times in a row), and becoming ready again recently after a
+// probed container restarted, which took readiness away while the pod kept running. Becoming ready long
+// after the containers started is not enough on its own, since that is also how a slow app starts.
+func (a *Analyzer) readinessFlapping(pod types.PodInfo) string {
+	var ready *types.PodCondition
+	for i := range pod.Conditions {
+		if pod.Conditions[i].Type == "Ready" {
+			ready = &pod.Conditions[i]
+		}
+	}
+	if ready == nil || ready.Status != "True" || ready.LastTransitionTime.IsZero() {
+		return ""
+	}
+
+	probed := false
+	for _, container := range pod.Containers {
+		probed = probed || container.Probes.Readiness.Configured
+	}
+	if !probed {
+		return ""
+	}
+
+	readyFor := a.since(ready.LastTransitionTime).Round(time.Second)
+	for _, event := range pod.Events {
+		if event.Reason == "Unhealthy" && strings.HasPrefix(event.Message, "Readiness probe failed") &&
+			event.Time.After(ready.LastTransitionTime) {
+			return fmt.Sprintf("possible readiness flapping: readiness probe failing since the pod became ready %s ago", readyFor)
+		}
+	}
+
+	if readyFor >= readinessFlapWindow {
+		return ""
+	}
+	for _, container := range pod.Containers {
+		// The previous instance ended while the pod was up, and readiness came back after the restart
+		if container.Probes.Readiness.Configured && container.RestartCount > 0 && container.LastFinishedAt != nil &&
+			ready.LastTransitionTime.After(*container.LastFinishedAt) && a.since(*container.LastFinishedAt) < readinessFlapWindow {
+			return fmt.Sprintf("possible readiness flapping: ready again %s ago after container %s restarted", readyFor, container.Name)
+		}
+	}
+	return ""
+}
+
+// RestartReasons groups the restarts of every container in the pods by reason. Kubernetes only keeps
+// the last termination, so each container's restarts are counted under the reason it last terminated
+// with (or its current termination reason), and "Unknown" when neither is known.
+func (a *Analyzer) RestartReasons(pods []types.PodInfo) []types.RestartReasonCount {
+	counts := make(map[string]int32)
+	for _, pod := range pods {
+		for _, container := range append(append([]types.ContainerInfo{}, pod.InitContainers...), pod.Containers...) {
+			if container.RestartCount == 0 {
+				continue
+			}
+			reason := container.LastStateReason
+			if reason == "" {
+				reason = container.TerminationReason
+			}
+			if reason == "" {
+				reason = "Unknown"
+			}
+			counts[reason] += container.RestartCount
+		}
+	}
+
+	reasons := make([]types.RestartReasonCount, 0, len(counts))
+	for reason, count := range counts {
+		reasons = append(reasons, types.RestartReasonCount{Reason: reason, Count: count})
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if reasons[i].Count != reasons[j].Count {
+			return reasons[i].Count > reasons[j].Count
+		}
+		return reasons[i].Reason < reasons[j].Reason
+	})
+	return reasons
+}
+
+// IsPodEvicted checks if the kubelet evicted the pod, e.g. because its node ran low on memory or disk
+func (a *Analyzer) IsPodEvicted(pod types.PodInfo) bool {
+	return pod.StatusReason == "Evicted"
+}
+
+// EvictionCause names what made the kubelet evict the pod, from its eviction message: node pressure, or
+// the pod outgrowing its own ephemeral-storage or emptyDir limits, which happens on a healthy node too
+func (a *Analyzer) EvictionCause(pod types.PodInfo) string {
+	message := strings.ToLower(pod.StatusMessage)
+	switch {
+	case strings.HasPrefix(message, "the node was low on resource"):
+		return "node pressure"
+	case strings.Contains(message, "emptydir"):
+		return "an emptyDir size limit"
+	case strings.Contains(message, "ephemeral local storage"), strings.Contains(message, "local ephemeral storage"):
+		return "an ephemeral-storage limit"
+	default:
+		return "the kubelet"
+	}
+}
+
+// evictionReason summarizes the kubelet's eviction message by its first sentence, e.g.
+// "Evicted: The node was low on resource: memory" without the thresholds and per-container usage
+func evictionReason(message string) string {
+	if message == "" {
+		return "Evicted by the node"
+	}
+	if end := strings.Index(message, ". "); end >= 0 {
+		message = message[:end]
+	}
+	return "Evicted: " + strings.TrimSuffix(message, ".")
+}
+
 // GetHealthIcon returns the appropriate icon for health status
 func (a *Analyzer) GetHealthIcon(level string) string {
 	switch level {
@@ -1,6 +1,9 @@
 package analyzer
 
 import (
+	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -218,6 +221,124 @@ func TestAnalyzePodHealth(t *testing.T) {
 	}
 }
 
+func TestStuckTerminatingPod(t *testing.T) {
+	analyzer := New()
+
+	tests := []struct {
+		name          string
+		pod           types.PodInfo
+		expectedLevel types.HealthLevel
+		reasonPrefix  string
+	}{
+		{
+			name: "terminating within grace period",
+			pod: types.PodInfo{
+				Name:                   "shutting-down",
+				Status:                 "Terminating",
+				TerminatingFor:         10 * time.Second,
+				TerminationGracePeriod: 30 * time.Second,
+				Containers: []types.ContainerInfo{
+					{Name: "app", Status: string(types.ContainerStatusRunning), Ready: true},
+				},
+			},
+			expectedLevel: types.HealthLevelHealthy,
+		},
+		{
+			name: "terminating beyond grace period with finalizer",
+			pod: types.PodInfo{
+				Name:                   "stuck",
+				Status:                 "Terminating",
+				TerminatingFor:         10 * time.Minute,
+				TerminationGracePeriod: 30 * time.Second,
+				Finalizers:             []string{"example.com/cleanup"},
+				Containers: []types.ContainerInfo{
+					{Name: "app", Status: string(types.ContainerStatusRunning), Ready: true},
+				},
+			},
+			expectedLevel: types.HealthLevelCritical,
+			reasonPrefix:  "stuck terminating",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzer.AnalyzePodHealth(tt.pod)
+			if result.Level != string(tt.expectedLevel) {
+				t.Errorf("expected level %s, got %s (%s)", tt.expectedLevel, result.Level, result.Reason)
+			}
+			if tt.reasonPrefix != "" && !strings.HasPrefix(result.Reason, tt.reasonPrefix) {
+				t.Errorf("expected reason to start with %q, got %q", tt.reasonPrefix, result.Reason)
+			}
+			if len(tt.pod.Finalizers) > 0 && !strings.Contains(result.Reason, tt.pod.Finalizers[0]) {
+				t.Errorf("expected reason to mention finalizer %q, got %q", tt.pod.Finalizers[0], result.Reason)
+			}
+		})
+	}
+}
+
+func TestEvictedPod(t *testing.T) {
+	analyzer := New()
+
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+		cause    string
+	}{
+		{
+			name:     "memory pressure",
+			message:  "The node was low on resource: memory. Threshold quantity: 100Mi, available: 52Mi. Container app was using 1Gi, request is 256Mi, has larger consumption of memory.",
+			expected: "Evicted: The node was low on resource: memory",
+			cause:    "node pressure",
+		},
+		{
+			name:     "single sentence",
+			message:  "Pod ephemeral local storage usage exceeds the total limit of containers 1Gi.",
+			expected: "Evicted: Pod ephemeral local storage usage exceeds the total limit of containers 1Gi",
+			cause:    "an ephemeral-storage limit",
+		},
+		{
+			name:     "container ephemeral-storage limit",
+			message:  "Container app exceeded its local ephemeral storage limit \"500Mi\". ",
+			expected: "Evicted: Container app exceeded its local ephemeral storage limit \"500Mi\"",
+			cause:    "an ephemeral-storage limit",
+		},
+		{
+			name:     "emptyDir limit",
+			message:  "Usage of EmptyDir volume \"cache\" exceeds the limit \"1Gi\". ",
+			expected: "Evicted: Usage of EmptyDir volume \"cache\" exceeds the limit \"1Gi\"",
+			cause:    "an emptyDir size limit",
+		},
+		{
+			name:     "no message",
+			expected: "Evicted by the node",
+			cause:    "the kubelet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := types.PodInfo{
+				Name:          "api-1",
+				Status:        "Failed",
+				StatusReason:  "Evicted",
+				StatusMessage: tt.message,
+				Containers:    []types.ContainerInfo{{Name: "app", Status: string(types.ContainerStatusCompleted)}},
+			}
+			result := analyzer.AnalyzePodHealth(pod)
+			if result.Level != string(types.HealthLevelCritical) {
+				t.Errorf("expected an evicted pod to be Critical, got %s", result.Level)
+			}
+			if result.Reason != tt.expected {
+				t.Errorf("expected reason %q, got %q", tt.expected, result.Reason)
+			}
+			if cause := analyzer.EvictionCause(pod); cause != tt.cause {
+				t.Errorf("expected cause %q, got %q", tt.cause, cause)
+			}
+		})
+	}
+}
+
 func TestAnalyzeWorkloadHealth(t *testing.T) {
 	analyzer := New()
 
@@ -420,3 +541,382 @@ func TestIsContainerProblematic(t *testing.T) {
 		})
 	}
 }
+
+func TestStartupProbeHealth(t *testing.T) {
+	analyzer := New()
+
+	startingContainer := func(startedAgo time.Duration, passing bool) types.ContainerInfo {
+		startedAt := time.Now().Add(-startedAgo)
+		return types.ContainerInfo{
+			Name:      "slow-starter",
+			Type:      string(types.ContainerTypeStandard),
+			Status:    string(types.ContainerStatusRunning),
+			Ready:     passing,
+			StartedAt: &startedAt,
+			Probes: types.ProbeInfo{
+				Startup: types.ProbeDetails{Configured: true, Passing: passing, Deadline: 5 * time.Minute},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		container      types.ContainerInfo
+		expectedLevel  string
+		expectedReason string
+	}{
+		{
+			name:           "startup probe not passed within deadline",
+			container:      startingContainer(1*time.Minute, false),
+			expectedLevel:  string(types.HealthLevelDegraded),
+			expectedReason: "startup probe has not passed yet",
+		},
+		{
+			name:           "startup probe not passed past deadline",
+			container:      startingContainer(10*time.Minute, false),
+			expectedLevel:  string(types.HealthLevelCritical),
+			expectedReason: "startup probe failing past its deadline",
+		},
+		{
+			name:           "startup probe passed",
+			container:      startingContainer(10*time.Minute, true),
+			expectedLevel:  string(types.HealthLevelHealthy),
+			expectedReason: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzer.analyzeContainerHealth(tt.container)
+			if result.Level != tt.expectedLevel {
+				t.Errorf("expected level %s, got %s", tt.expectedLevel, result.Level)
+			}
+			if result.Reason != tt.expectedReason {
+				t.Errorf("expected reason %q, got %q", tt.expectedReason, result.Reason)
+			}
+		})
+	}
+}
+
+func TestRunningButNotReady(t *testing.T) {
+	analyzer := New()
+
+	runningContainer := func(startedAgo time.Duration, ready bool) types.ContainerInfo {
+		startedAt := time.Now().Add(-startedAgo)
+		return types.ContainerInfo{
+			Name:      "app",
+			Type:      string(types.ContainerTypeStandard),
+			Status:    string(types.ContainerStatusRunning),
+			Ready:     ready,
+			StartedAt: &startedAt,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		container      types.ContainerInfo
+		expectedLevel  string
+		expectedReason string
+	}{
+		{"not ready within grace period", runningContainer(30*time.Second, false), string(types.HealthLevelHealthy), ""},
+		{"not ready past grace period", runningContainer(10*time.Minute, false), string(types.HealthLevelDegraded), "running but not ready"},
+		{"ready", runningContainer(10*time.Minute, true), string(types.HealthLevelHealthy), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzer.analyzeContainerHealth(tt.container)
+			if result.Level != tt.expectedLevel {
+				t.Errorf("expected level %s, got %s", tt.expectedLevel, result.Level)
+			}
+			if result.Reason != tt.expectedReason {
+				t.Errorf("expected reason %q, got %q", tt.expectedReason, result.Reason)
+			}
+		})
+	}
+}
+
+func TestAnalyzeContainersSerializesHealth(t *testing.T) {
+	analyzer := New()
+
+	pod := types.PodInfo{
+		Name: "web-1",
+		Containers: []types.ContainerInfo{
+			{Name: "app", Type: string(types.ContainerTypeStandard), Status: "CrashLoopBackOff", RestartCount: 4},
+		},
+	}
+	analyzer.AnalyzeContainers(&pod)
+
+	data, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("failed to marshal pod: %v", err)
+	}
+
+	var decoded struct {
+		Containers []struct {
+			Health map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal pod: %v", err)
+	}
+	if len(decoded.Containers) != 1 {
+		t.Fatalf("expected 1 container, got %d", len(decoded.Containers))
+	}
+
+	health := decoded.Containers[0].Health
+	if health["Level"] != string(types.HealthLevelCritical) {
+		t.Errorf("expected level %q, got %v", types.HealthLevelCritical, health["Level"])
+	}
+	if score, ok := health["Score"].(float64); !ok || score != 0 {
+		t.Errorf("expected score 0, got %v", health["Score"])
+	}
+}
+
+func TestRestartReasons(t *testing.T) {
+	analyzer := New()
+
+	pods := []types.PodInfo{
+		{
+			Name: "api-1",
+			Containers: []types.ContainerInfo{
+				{Name: "app", RestartCount: 7, LastState: "Terminated", LastStateReason: "OOMKilled"},
+				{Name: "proxy", RestartCount: 2, LastState: "Terminated", LastStateReason: "Error"},
+			},
+		},
+		{
+			Name:           "api-2",
+			InitContainers: []types.ContainerInfo{{Name: "migrate", RestartCount: 1, TerminationReason: "Error"}},
+			Containers: []types.ContainerInfo{
+				{Name: "app", RestartCount: 5, LastState: "Terminated", LastStateReason: "OOMKilled"},
+				{Name: "proxy", RestartCount: 0, LastStateReason: "Completed"},
+				{Name: "sidecar", RestartCount: 1},
+			},
+		},
+	}
+
+	expected := []types.RestartReasonCount{
+		{Reason: "OOMKilled", Count: 12},
+		{Reason: "Error", Count: 3},
+		{Reason: "Unknown", Count: 1},
+	}
+	if got := analyzer.RestartReasons(pods); !reflect.DeepEqual(got, expected) {
+		t.Errorf("RestartReasons() = %+v, want %+v", got, expected)
+	}
+	if got := analyzer.RestartReasons([]types.PodInfo{{Containers: []types.ContainerInfo{{Name: "app"}}}}); len(got) != 0 {
+		t.Errorf("expected no reasons without restarts, got %+v", got)
+	}
+}
+
+func TestReadinessFlapping(t *testing.T) {
+	analyzer := New()
+
+	pod := func(startedAgo, readyAgo time.Duration, probed bool, events ...types.EventInfo) types.PodInfo {
+		startedAt := time.Now().Add(-startedAgo)
+		return types.PodInfo{
+			Name:   "api-1",
+			Status: "Running",
+			Containers: []types.ContainerInfo{{
+				Name:      "app",
+				Type:      string(types.ContainerTypeStandard),
+				Status:    string(types.ContainerStatusRunning),
+				Ready:     true,
+				StartedAt: &startedAt,
+				Probes:    types.ProbeInfo{Readiness: types.ProbeDetails{Configured: probed, Passing: probed}},
+			}},
+			Conditions: []types.PodCondition{{Type: "Ready", Status: "True", LastTransitionTime: time.Now().Add(-readyAgo)}},
+			Events:     events,
+		}
+	}
+	probeFailed := func(ago time.Duration) types.EventInfo {
+		return types.EventInfo{Time: time.Now().Add(-ago), Type: "Warning", Reason: "Unhealthy", Message: "Readiness probe failed: HTTP probe failed with statuscode: 503"}
+	}
+	// restarted gives the pod's container a previous instance that ended just before the current one started
+	restarted := func(pod types.PodInfo) types.PodInfo {
+		finishedAt := pod.Containers[0].StartedAt.Add(-time.Second)
+		pod.Containers[0].RestartCount = 1
+		pod.Containers[0].LastFinishedAt = &finishedAt
+		return pod
+	}
+
+	tests := []struct {
+		name     string
+		pod      types.PodInfo
+		flapping string // Expected reason prefix, empty when the pod should stay Healthy
+	}{
+		{"steady", pod(2*time.Hour, 2*time.Hour, true), ""},
+		{"startup probe failures", pod(2*time.Hour, 2*time.Hour, true, probeFailed(2*time.Hour+time.Minute)), ""},
+		{"failures since ready", pod(2*time.Hour, 2*time.Hour, true, probeFailed(time.Minute)), "possible readiness flapping: readiness probe failing"},
+		// Without failures or a restart, becoming ready long after starting is just a slow start
+		{"ready again while running", pod(2*time.Hour, 3*time.Minute, true), ""},
+		{"slow start", pod(5*time.Minute, 2*time.Minute, true, probeFailed(3*time.Minute)), ""},
+		{"ready again after a restart", restarted(pod(7*time.Minute, 3*time.Minute, true)), "possible readiness flapping: ready again"},
+		{"restarted long ago", restarted(pod(2*time.Hour, 3*time.Minute, true)), ""},
+		{"ready again long ago", pod(2*time.Hour, time.Hour, true), ""},
+		{"recently started", pod(time.Minute, 30*time.Second, true), ""},
+		{"no readiness probe", pod(2*time.Hour, 3*time.Minute, false), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzer.AnalyzePodHealth(tt.pod)
+			if tt.flapping == "" {
+				if result.Level != string(types.HealthLevelHealthy) {
+					t.Errorf("expected Healthy, got %s (%s)", result.Level, result.Reason)
+				}
+				return
+			}
+			if result.Level != string(types.HealthLevelDegraded) || !strings.HasPrefix(result.Reason, tt.flapping) {
+				t.Errorf("expected Degraded with reason %q..., got %s (%s)", tt.flapping, result.Level, result.Reason)
+			}
+		})
+	}
+}
+
+func TestRunningWithNoContainersReady(t *testing.T) {
+	analyzer := New()
+
+	container := func(name string, ready bool) types.ContainerInfo {
+		startedAt := time.Now().Add(-10 * time.Minute)
+		return types.ContainerInfo{
+			Name:      name,
+			Type:      string(types.ContainerTypeStandard),
+			Status:    string(types.ContainerStatusRunning),
+			Ready:     ready,
+			StartedAt: &startedAt,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		pod            types.PodInfo
+		expectedLevel  string
+		expectedReason string
+	}{
+		{
+			name:           "none ready",
+			pod:            types.PodInfo{Status: "Running", Age: 10 * time.Minute, Containers: []types.ContainerInfo{container("app", false), container("proxy", false)}},
+			expectedLevel:  string(types.HealthLevelCritical),
+			expectedReason: "running but no containers ready (running but not ready)",
+		},
+		{
+			name:           "one ready",
+			pod:            types.PodInfo{Status: "Running", Age: 10 * time.Minute, Containers: []types.ContainerInfo{container("app", true), container("proxy", false)}},
+			expectedLevel:  string(types.HealthLevelDegraded),
+			expectedReason: "running but not ready",
+		},
+		{
+			name:          "just started",
+			pod:           types.PodInfo{Status: "Running", Age: 30 * time.Second, Containers: []types.ContainerInfo{container("app", false)}},
+			expectedLevel: string(types.HealthLevelDegraded),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzer.AnalyzePodHealth(tt.pod)
+			if result.Level != tt.expectedLevel {
+				t.Errorf("expected level %s, got %s (%s)", tt.expectedLevel, result.Level, result.Reason)
+			}
+			if tt.expectedReason != "" && result.Reason != tt.expectedReason {
+				t.Errorf("expected reason %q, got %q", tt.expectedReason, result.Reason)
+			}
+		})
+	}
+}
+
+func TestStartingContainers(t *testing.T) {
+	analyzer := New()
+
+	readiness := types.ProbeInfo{Readiness: types.ProbeDetails{Configured: true}}
+	pod := func(age time.Duration, status string, containers ...types.ContainerInfo) types.PodInfo {
+		return types.PodInfo{Status: status, Age: age, Containers: containers}
+	}
+	creating := types.ContainerInfo{Name: "app", Type: string(types.ContainerTypeStandard), Status: "ContainerCreating", Probes: readiness}
+	initializing := types.ContainerInfo{Name: "app", Type: string(types.ContainerTypeStandard), Status: "PodInitializing"}
+	running := types.ContainerInfo{Name: "proxy", Type: string(types.ContainerTypeStandard), Status: string(types.ContainerStatusRunning), Ready: true}
+
+	tests := []struct {
+		name           string
+		pod            types.PodInfo
+		expectedLevel  string
+		expectedReason string
+	}{
+		{
+			name:           "young pod creating",
+			pod:            pod(20*time.Second, "Pending", creating),
+			expectedLevel:  string(types.HealthLevelHealthy),
+			expectedReason: "starting",
+		},
+		{
+			name:           "young pod initializing next to a running sidecar",
+			pod:            pod(time.Minute, "Pending", initializing, running),
+			expectedLevel:  string(types.HealthLevelHealthy),
+			expectedReason: "starting",
+		},
+		{
+			name:           "old pod stuck creating",
+			pod:            pod(7*time.Minute, "Pending", creating),
+			expectedLevel:  string(types.HealthLevelDegraded),
+			expectedReason: "stuck in ContainerCreating for 7m0s",
+		},
+		{
+			name:           "old pod stuck initializing",
+			pod:            pod(6*time.Minute, "Pending", initializing),
+			expectedLevel:  string(types.HealthLevelDegraded),
+			expectedReason: "stuck in PodInitializing for 6m0s",
+		},
+		{
+			name:           "initializing past the stuck initialization limit",
+			pod:            pod(15*time.Minute, "Pending", initializing),
+			expectedLevel:  string(types.HealthLevelCritical),
+			expectedReason: "pod stuck in initialization phase for more than 10 minutes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzer.AnalyzePodHealth(tt.pod)
+			if result.Level != tt.expectedLevel || result.Reason != tt.expectedReason {
+				t.Errorf("expected %s (%s), got %s (%s)", tt.expectedLevel, tt.expectedReason, result.Level, result.Reason)
+			}
+
+			// The containers carry the same verdict
+			analyzer.AnalyzeContainers(&tt.pod)
+			if health := tt.pod.Containers[0].Health; tt.expectedLevel != string(types.HealthLevelCritical) && health.Level != tt.expectedLevel {
+				t.Errorf("expected container level %s, got %s (%s)", tt.expectedLevel, health.Level, health.Reason)
+			}
+		})
+	}
+}
+
+func TestReferenceTime(t *testing.T) {
+	taken := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	startedAt := taken.Add(-2 * time.Minute)
+	restarted := types.ContainerInfo{
+		Name:         "worker",
+		Type:         string(types.ContainerTypeStandard),
+		Status:       string(types.ContainerStatusRunning),
+		Ready:        true,
+		RestartCount: 3,
+		StartedAt:    &startedAt,
+	}
+
+	// Against the current time the restart is years old; as of when it was observed it is recent
+	if New().hasRecentRestarts(restarted) {
+		t.Error("expected a restart years ago not to be recent")
+	}
+	analyzer := New()
+	analyzer.UseReferenceTime(taken)
+	if !analyzer.hasRecentRestarts(restarted) {
+		t.Error("expected a restart 2m before the reference time to be recent")
+	}
+
+	starting := restarted
+	starting.Ready = false
+	starting.RestartCount = 0
+	starting.Probes.Startup = types.ProbeDetails{Configured: true, Deadline: 5 * time.Minute}
+	if result := analyzer.analyzeContainerHealth(starting); result.Reason != "startup probe has not passed yet" {
+		t.Errorf("expected the startup probe to be within its deadline at the reference time, got %q", result.Reason)
+	}
+}
@@ -0,0 +1,155 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// failedSchedulingIssue is reported for pending pods the scheduler could not place
+const failedSchedulingIssue = "pod cannot be scheduled"
+
+// Remediation groups the pods affected by one issue with suggested next steps
+type Remediation struct {
+	Issue string
+	Pods  []string
+	Hints []string
+}
+
+// remediationHints maps health reasons (matched by substring) to an issue title and suggested next steps.
+// Order matters: the first matching entry wins, so more specific reasons come first.
+var remediationHints = []struct {
+	match string
+	issue string // Stable title reasons are grouped under, without per-pod durations or counts
+	hints []string
+}{
+	{"CrashLoopBackOff", "container in CrashLoopBackOff", []string{
+		"Check the logs of the crashed container: kubectl logs <pod> -c <container> --previous",
+		"Look at the last exit code and termination reason to tell app errors from config errors",
+	}},
+	{"cannot pull container image", "cannot pull container image", []string{
+		"Verify the image name and tag exist in the registry",
+		"For private registries, check imagePullSecrets on the pod or its service account",
+	}},
+	{failedSchedulingIssue, failedSchedulingIssue, []string{
+		"Check free node capacity against the pod's requests: kubectl describe nodes",
+		"Check node taints against the pod's tolerations, and its nodeSelector/affinity rules",
+	}},
+	{"out of memory", "container killed due to out of memory", []string{
+		"Raise the container's memory limit, or reduce the application's memory use",
+		"Compare recent memory usage with the limit shown above to size the new limit",
+	}},
+	{"stuck terminating", "pod stuck terminating", []string{
+		"Check that the controllers owning the listed finalizers are running",
+		"As a last resort, remove the finalizers: kubectl patch pod <pod> -p '{\"metadata\":{\"finalizers\":null}}'",
+	}},
+	{"initialization phase", "pod stuck in initialization phase", []string{
+		"Check the init container logs: kubectl logs <pod> -c <init-container>",
+	}},
+	{"liveness probe failing", "liveness probe failing", []string{
+		"Check that the liveness endpoint answers within timeoutSeconds under load",
+		"Raise initialDelaySeconds or add a startup probe if the app starts slowly",
+	}},
+	{"startup probe", "startup probe not passing", []string{
+		"If the app legitimately starts slowly, raise failureThreshold or periodSeconds on the startup probe",
+		"Check the application logs for what it is waiting on during startup",
+	}},
+	{"readiness probe failing", "readiness probe failing", []string{
+		"Check the readiness endpoint and the app's dependencies; the pod gets no Service traffic until ready",
+	}},
+	{"running but not ready", "running but not ready", []string{
+		"Check the application logs and any readiness gates; the pod gets no Service traffic until ready",
+	}},
+	{"recent restarts", "recent restarts detected", []string{
+		"Check why the last run ended: kubectl logs <pod> -c <container> --previous",
+	}},
+	{"non-zero exit code", "terminated with non-zero exit code", []string{
+		"Check the container logs for the error that caused the non-zero exit",
+	}},
+	{"terminated unexpectedly", "terminated unexpectedly", []string{
+		"Check the container logs and the termination reason for why the process exited",
+	}},
+	{"waiting to start", "container waiting to start", []string{
+		"Run kubectl describe pod <pod> to see what the container is waiting on (volumes, config maps, secrets)",
+	}},
+	{"high memory usage", "high memory usage", []string{
+		"Raise the memory limit before the container gets OOMKilled, or investigate memory growth",
+	}},
+	{"high CPU usage", "high CPU usage", []string{
+		"Raise the CPU limit to avoid throttling, or scale out the workload",
+	}},
+}
+
+// RemediationFor returns the suggested next steps for a health reason, or nil if there are none
+func (a *Analyzer) RemediationFor(reason string) []string {
+	if i := remediationIndex(reason); i >= 0 {
+		return remediationHints[i].hints
+	}
+	return nil
+}
+
+// remediationIndex returns the index of the remediationHints entry matching a health reason, or -1
+func remediationIndex(reason string) int {
+	for i, entry := range remediationHints {
+		if strings.Contains(reason, entry.match) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Explain collects the distinct issues across all pods, in the order they are first seen,
+// together with the pods they affect and the suggested next steps. Reasons are grouped by the
+// hint they match, so "stuck terminating for 5m" and "for 9m" count as one issue.
+func (a *Analyzer) Explain(workloads []types.WorkloadInfo) []Remediation {
+	var remediations []Remediation
+	index := make(map[int]int)
+
+	add := func(reason, pod string) {
+		entry := remediationIndex(reason)
+		if entry < 0 {
+			return
+		}
+		i, exists := index[entry]
+		if !exists {
+			index[entry] = len(remediations)
+			remediations = append(remediations, Remediation{
+				Issue: remediationHints[entry].issue,
+				Pods:  []string{pod},
+				Hints: remediationHints[entry].hints,
+			})
+			return
+		}
+		if pods := remediations[i].Pods; pods[len(pods)-1] != pod {
+			remediations[i].Pods = append(pods, pod)
+		}
+	}
+
+	for _, workload := range workloads {
+		for _, pod := range workload.Pods {
+			if pod.Status == "Pending" && hasFailedScheduling(pod) {
+				add(failedSchedulingIssue, pod.Name)
+			}
+			if pod.Health.Level != string(types.HealthLevelHealthy) {
+				add(pod.Health.Reason, pod.Name)
+			}
+			// The pod reason only names its first issue, so look at every container's health too
+			for _, container := range append(pod.InitContainers, pod.Containers...) {
+				if container.Health.Reason != "" && container.Health.Level != string(types.HealthLevelHealthy) {
+					add(container.Health.Reason, pod.Name)
+				}
+			}
+		}
+	}
+	return remediations
+}
+
+// hasFailedScheduling checks whether the pod has a FailedScheduling event
+func hasFailedScheduling(pod types.PodInfo) bool {
+	for _, event := range pod.Events {
+		if event.Reason == "FailedScheduling" {
+			return true
+		}
+	}
+	return false
+}
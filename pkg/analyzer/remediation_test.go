@@ -0,0 +1,117 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestRemediationFor(t *testing.T) {
+	analyzer := New()
+
+	tests := []struct {
+		reason   string
+		expected bool
+	}{
+		{"container in CrashLoopBackOff", true},
+		{"cannot pull container image", true},
+		{"container killed due to out of memory", true},
+		{"stuck terminating for 5m (grace period 30s), finalizers: example.com/cleanup", true},
+		{"all containers running normally", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			hints := analyzer.RemediationFor(tt.reason)
+			if (len(hints) > 0) != tt.expected {
+				t.Errorf("expected hints %v for %q, got %v", tt.expected, tt.reason, hints)
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	analyzer := New()
+
+	crashing := func(name string) types.PodInfo {
+		pod := types.PodInfo{
+			Name:   name,
+			Status: "Running",
+			Containers: []types.ContainerInfo{
+				{Name: "app", Type: string(types.ContainerTypeStandard), Status: "CrashLoopBackOff", RestartCount: 5},
+				{Name: "sidecar", Type: string(types.ContainerTypeStandard), Status: "ImagePullBackOff"},
+			},
+		}
+		analyzer.AnalyzeContainers(&pod)
+		pod.Health = analyzer.AnalyzePodHealth(pod)
+		return pod
+	}
+
+	pending := types.PodInfo{
+		Name:   "pending-pod",
+		Status: "Pending",
+		Events: []types.EventInfo{{Type: "Warning", Reason: "FailedScheduling"}},
+	}
+	healthy := types.PodInfo{
+		Name:   "healthy-pod",
+		Status: "Running",
+		Containers: []types.ContainerInfo{
+			{Name: "app", Type: string(types.ContainerTypeStandard), Status: string(types.ContainerStatusRunning), Ready: true},
+		},
+	}
+	analyzer.AnalyzeContainers(&healthy)
+	healthy.Health = analyzer.AnalyzePodHealth(healthy)
+
+	// Reasons carrying per-pod durations still make one issue
+	terminating := func(name string, terminatingFor time.Duration) types.PodInfo {
+		pod := types.PodInfo{
+			Name:                   name,
+			Status:                 "Terminating",
+			TerminatingFor:         terminatingFor,
+			TerminationGracePeriod: 30 * time.Second,
+		}
+		pod.Health = analyzer.AnalyzePodHealth(pod)
+		return pod
+	}
+
+	workloads := []types.WorkloadInfo{{Pods: []types.PodInfo{
+		crashing("pod-a"), crashing("pod-b"), pending, healthy,
+		terminating("old-a", 5*time.Minute), terminating("old-b", 9*time.Minute),
+	}}}
+
+	remediations := analyzer.Explain(workloads)
+	if len(remediations) != 4 {
+		t.Fatalf("expected 4 distinct issues, got %d: %+v", len(remediations), remediations)
+	}
+
+	expected := []struct {
+		issue string
+		pods  []string
+	}{
+		{"container in CrashLoopBackOff", []string{"pod-a", "pod-b"}},
+		{"cannot pull container image", []string{"pod-a", "pod-b"}},
+		{failedSchedulingIssue, []string{"pending-pod"}},
+		{"pod stuck terminating", []string{"old-a", "old-b"}},
+	}
+	for i, want := range expected {
+		got := remediations[i]
+		if got.Issue != want.issue {
+			t.Errorf("issue %d: expected %q, got %q", i, want.issue, got.Issue)
+		}
+		if len(got.Pods) != len(want.pods) {
+			t.Errorf("issue %q: expected pods %v, got %v", want.issue, want.pods, got.Pods)
+			continue
+		}
+		for j := range want.pods {
+			if got.Pods[j] != want.pods[j] {
+				t.Errorf("issue %q: expected pods %v, got %v", want.issue, want.pods, got.Pods)
+				break
+			}
+		}
+		if len(got.Hints) == 0 {
+			t.Errorf("issue %q: expected hints", want.issue)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/nareshku/kubectl-container-status/pkg/analyzer"
+	"github.com/nareshku/kubectl-container-status/pkg/collector"
+	"github.com/nareshku/kubectl-container-status/pkg/output"
+	"github.com/nareshku/kubectl-container-status/pkg/resolver"
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestJSONOutputWithMetricsFailure(t *testing.T) {
+	var pods []runtime.Object
+	for _, name := range []string{"web-1", "web-2"} {
+		pods = append(pods, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}, UID: k8stypes.UID("uid-" + name)},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:1"}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		})
+	}
+	clientset := fake.NewSimpleClientset(pods...)
+
+	// Force every metrics call to fail the way a cluster with a broken metrics-server does
+	metricsClient := metricsfake.NewSimpleClientset()
+	metricsClient.PrependReactor("*", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+
+	options := &types.Options{
+		Namespace:    "default",
+		Selector:     "app=web",
+		OutputFormat: "json",
+		ShowEvents:   true,
+	}
+
+	var warnings bytes.Buffer
+	c := collector.New(clientset, metricsClient)
+	c.SetWarningOutput(&warnings)
+
+	workloads, err := collectWorkloads(context.Background(), resolver.New(clientset), c, analyzer.New(), nil, options)
+	if err != nil {
+		t.Fatalf("collection failed: %v", err)
+	}
+	var stdout bytes.Buffer
+	if err := output.NewWithWriter(options, &stdout).Output(workloads); err != nil {
+		t.Fatalf("output failed: %v", err)
+	}
+
+	if !bytes.Contains(warnings.Bytes(), []byte("Warning: Failed to collect metrics")) {
+		t.Errorf("expected the metrics failure to be reported as a warning, got %q", warnings.String())
+	}
+
+	var decoded []types.WorkloadInfo
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &decoded); err != nil {
+		t.Fatalf("stdout is not valid JSON: %v\n%s", err, stdout.String())
+	}
+	podCount := 0
+	for _, workload := range decoded {
+		podCount += len(workload.Pods)
+		for _, pod := range workload.Pods {
+			if pod.UID != "uid-"+pod.Name {
+				t.Errorf("expected pod %s to carry its UID in the JSON output, got %q", pod.Name, pod.UID)
+			}
+		}
+	}
+	if podCount != 2 {
+		t.Errorf("expected 2 pods in the JSON output, got %d", podCount)
+	}
+}
+
+func TestJSONOutputCarriesDeferredWarnings(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:1"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	})
+	metricsClient := metricsfake.NewSimpleClientset()
+	metricsClient.PrependReactor("*", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+
+	options := &types.Options{Namespace: "default", Selector: "app=web", OutputFormat: "json"}
+
+	var warnings bytes.Buffer
+	c := collector.New(clientset, metricsClient)
+	c.SetWarningOutput(&warnings)
+	c.DeferWarnings()
+
+	workloads, err := collectWorkloads(context.Background(), resolver.New(clientset), c, analyzer.New(), nil, options)
+	if err != nil {
+		t.Fatalf("collection failed: %v", err)
+	}
+	var stdout bytes.Buffer
+	if err := output.NewWithWriter(options, &stdout).Output(workloads); err != nil {
+		t.Fatalf("output failed: %v", err)
+	}
+	if warnings.Len() != 0 {
+		t.Errorf("expected deferred warnings to stay out of stderr, got %q", warnings.String())
+	}
+
+	var decoded []types.WorkloadInfo
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &decoded); err != nil {
+		t.Fatalf("stdout is not valid JSON: %v\n%s", err, stdout.String())
+	}
+	if len(decoded) != 1 || len(decoded[0].Warnings) != 1 || !strings.HasPrefix(decoded[0].Warnings[0], "Failed to collect") {
+		t.Errorf("expected the metrics failure in the workload's Warnings, got %+v", decoded)
+	}
+}
+
+func TestCollectWorkloadsFieldSelector(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{NodeName: "node-a", Containers: []corev1.Container{{Name: "app", Image: "web:1"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	tests := []struct {
+		name          string
+		options       types.Options
+		badRequest    bool
+		expectedError string
+	}{
+		{
+			name:    "passed through to pod listing",
+			options: types.Options{Selector: "app=web", FieldSelector: "spec.nodeName=node-a"},
+		},
+		{
+			name:          "rejected by the API server",
+			options:       types.Options{Selector: "app=web", FieldSelector: "spec.unknown=x"},
+			badRequest:    true,
+			expectedError: `invalid field selector "spec.unknown=x"`,
+		},
+		{
+			name:          "single pod",
+			options:       types.Options{ResourceName: "web-1", ResourceType: "pod", FieldSelector: "spec.nodeName=node-a"},
+			expectedError: "--field-selector cannot be used with a single pod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(pod)
+			var listed []string
+			clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				listed = append(listed, action.(k8stesting.ListAction).GetListRestrictions().Fields.String())
+				if tt.badRequest {
+					return true, nil, apierrors.NewBadRequest("field label not supported: spec.unknown")
+				}
+				return false, nil, nil
+			})
+			c := collector.New(clientset, nil)
+			c.SetWarningOutput(io.Discard)
+
+			options := tt.options
+			options.Namespace = "default"
+			workloads, err := collectWorkloads(context.Background(), resolver.New(clientset), c, analyzer.New(), nil, &options)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("collection failed: %v", err)
+			}
+			if len(workloads) != 1 || len(workloads[0].Pods) != 1 {
+				t.Errorf("expected web-1, got %+v", workloads)
+			}
+			// Both the resolver's and the collector's pod listings carry the field selector
+			if len(listed) != 2 || listed[0] != tt.options.FieldSelector || listed[1] != tt.options.FieldSelector {
+				t.Errorf("expected every pod listing to use %q, got %q", tt.options.FieldSelector, listed)
+			}
+		})
+	}
+}
+
+func TestWorkloadSummaryCountsLivenessFailuresFromEvents(t *testing.T) {
+	probe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz"}}}
+	owner := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "web"}
+	var objects []runtime.Object
+	for _, name := range []string{"web-1", "web-2"} {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}, OwnerReferences: []metav1.OwnerReference{owner}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:1", LivenessProbe: probe}}},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}},
+			},
+		})
+	}
+	// Only web-1's container failed its liveness probe; web-2 only has a pod-level event
+	event := func(name, pod, fieldPath, reason, message string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: pod, Namespace: "default", FieldPath: fieldPath},
+			Type:           corev1.EventTypeWarning,
+			Reason:         reason,
+			Message:        message,
+			Count:          3,
+			LastTimestamp:  metav1.NewTime(time.Now().Add(-time.Minute)),
+		}
+	}
+	objects = append(objects,
+		event("web-1.liveness", "web-1", "spec.containers{app}", "Unhealthy", "Liveness probe failed: HTTP probe failed with statuscode: 500"),
+		event("web-2.scheduled", "web-2", "", "FailedMount", "MountVolume.SetUp failed"),
+	)
+	clientset := fake.NewSimpleClientset(objects...)
+
+	options := &types.Options{Namespace: "default", Selector: "app=web", OutputFormat: "table", ShowEvents: true, NoColor: true}
+	c := collector.New(clientset, nil)
+	c.SetWarningOutput(io.Discard)
+
+	workloads, err := collectWorkloads(context.Background(), resolver.New(clientset), c, analyzer.New(), nil, options)
+	if err != nil {
+		t.Fatalf("collection failed: %v", err)
+	}
+	var liveness []int32
+	for _, pod := range workloads[0].Pods {
+		liveness = append(liveness, pod.Containers[0].Probes.Liveness.FailureCount)
+	}
+	if len(liveness) != 2 || liveness[0]+liveness[1] != 3 {
+		t.Errorf("expected 3 liveness failures on one pod, got %v", liveness)
+	}
+
+	var stdout bytes.Buffer
+	if err := output.NewWithWriter(options, &stdout).Output(workloads); err != nil {
+		t.Fatalf("output failed: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "1 pod with liveness failures") {
+		t.Errorf("expected the workload summary to count one pod with liveness failures, got:\n%s", stdout.String())
+	}
+}
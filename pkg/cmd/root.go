@@ -2,18 +2,32 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned"
 
 	"github.com/nareshku/kubectl-container-status/pkg/analyzer"
 	"github.com/nareshku/kubectl-container-status/pkg/collector"
 	"github.com/nareshku/kubectl-container-status/pkg/output"
+	"github.com/nareshku/kubectl-container-status/pkg/profile"
 	"github.com/nareshku/kubectl-container-status/pkg/resolver"
 	"github.com/nareshku/kubectl-container-status/pkg/types"
 )
@@ -23,7 +37,11 @@ func NewContainerStatusCommand() *cobra.Command {
 	options := &types.Options{
 		Namespace:    "",
 		OutputFormat: "table",
+		Color:        "auto",
 		SortBy:       "name",
+		ShowEvents:   true,
+		MaxEvents:    10,
+		ShowEnv:      true,
 	}
 
 	cmd := &cobra.Command{
@@ -48,7 +66,16 @@ Examples:
   kubectl container-status --selector app=web,tier=backend
 
   # Show only problematic containers and pods (restarts, failures, terminating, etc.)
-  kubectl container-status --problematic`,
+  kubectl container-status --problematic
+
+  # One-line-per-workload health roll-up across all namespaces
+  kubectl container-status --all-namespaces --summary
+
+  # Log pod health changes during a risky deploy, ringing the bell on each change
+  kubectl container-status deployment/web-backend --watch-problematic --bell
+
+  # Compare a deployment against canary pods selected by label
+  kubectl container-status deployment/web-backend --compare track=canary`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
@@ -63,6 +90,11 @@ Examples:
 			}
 
 			err := runContainerStatus(options)
+			var unhealthy *unhealthyError
+			if errors.As(err, &unhealthy) {
+				// --quiet or --wait-healthy already printed the final state, the exit code carries the verdict
+				os.Exit(unhealthy.exitCode)
+			}
 			if err != nil {
 				// Print error message and exit without showing usage
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -78,24 +110,108 @@ Examples:
 	cmd.Flags().StringVar(&options.Job, "job", "", "Show container status for all pods in the given Job")
 	cmd.Flags().StringVar(&options.DaemonSet, "daemonset", "", "Show container status for all pods in the given DaemonSet")
 	cmd.Flags().StringVarP(&options.Selector, "selector", "l", "", "Label selector to fetch and group matching pods")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "With --selector, only print how many pods match and the owners they resolve to, without collecting pod details")
+	cmd.Flags().StringVar(&options.OwnerKind, "owner-kind", "", "Resolve the resource name as the owner of this kind, including custom resources (e.g. Rollout, HelmRelease); with --selector, keep only workloads of this kind (e.g. Deployment, StatefulSet, Pod)")
+	cmd.Flags().StringVar(&options.FieldSelector, "field-selector", "", "Field selector to filter pods in workload and selector views (e.g. status.phase=Running,spec.nodeName=node-1); Failed and Succeeded pods are shown unless filtered out")
+	cmd.Flags().Int64Var(&options.ChunkSize, "chunk-size", 500, "Fetch pod lists in pages of this size to keep large namespaces from timing out (0 disables paging)")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "Target namespace (defaults to current context)")
+	cmd.Flags().StringVar(&options.Kubeconfig, "kubeconfig", "", "Path to the kubeconfig file to use (defaults to KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVar(&options.As, "as", "", "Username to impersonate for the operation (e.g. system:serviceaccount:ns:name)")
+	cmd.Flags().StringArrayVar(&options.AsGroups, "as-group", nil, "Group to impersonate for the operation, can be repeated")
+	cmd.Flags().StringVar(&options.AsUID, "as-uid", "", "UID to impersonate for the operation")
 	cmd.Flags().StringVar(&options.Context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVar(&options.Cluster, "cluster", "", "The name of the kubeconfig cluster to use, overriding the context's cluster")
+	cmd.Flags().StringVar(&options.User, "user", "", "The name of the kubeconfig user to use, overriding the context's user")
 	cmd.Flags().BoolVar(&options.AllNamespaces, "all-namespaces", false, "Show containers across all namespaces")
-	cmd.Flags().StringVar(&options.OutputFormat, "output", "table", "Output format: table, json, yaml")
+	cmd.Flags().StringVar(&options.OutputFormat, "output", "table", "Output format: table, json, yaml, html, junit (one test case per pod, for CI), name (one pod/<name> per line)")
+	cmd.Flags().StringVar(&options.OutputFile, "output-file", "", "Write the formatted output to this file instead of stdout (uncolored unless --color=always)")
+	cmd.Flags().BoolVar(&options.Compact, "compact", false, "Use a narrow workload table that fits 80 columns (automatic on terminals narrower than 100 columns)")
 	cmd.Flags().BoolVar(&options.NoColor, "no-color", false, "Disable colored output")
+	cmd.Flags().StringVar(&options.Color, "color", "auto", "When to color output: auto (only on a terminal without NO_COLOR set), always, never")
+	cmd.Flags().BoolVar(&options.Hyperlinks, "hyperlinks", false, "Make pod and node names clickable links (OSC 8) on terminals, using --pod-url and --node-url")
+	cmd.Flags().StringVar(&options.PodURL, "pod-url", "", "URL template for --hyperlinks pod names, with {namespace}, {pod} and {node} placeholders")
+	cmd.Flags().StringVar(&options.NodeURL, "node-url", "", "URL template for --hyperlinks node names, with a {node} placeholder")
+	cmd.Flags().StringSliceVar(&options.FromFile, "from-file", nil, "Analyze pods and events from kubectl get -o yaml/json dumps instead of a cluster (repeatable); combine with --metrics-from for usage")
+	cmd.Flags().StringVar(&options.DumpTime, "dump-time", "", "When the --from-file dumps were taken, as RFC3339 (e.g. 2024-05-01T10:00:00Z); defaults to when the files were last modified")
+	cmd.Flags().StringVar(&options.MetricsFrom, "metrics-from", "", "Read CPU/memory usage from a snapshot file instead of metrics-server: a PodMetricsList JSON or namespace,pod,container,cpu,memory CSV")
+	cmd.Flags().StringSliceVarP(&options.LabelColumns, "label-columns", "L", nil, "Comma-separated pod label keys to show as extra workload table columns (e.g. version,tier)")
+	cmd.Flags().BoolVar(&options.ShowSecurity, "security", false, "Show each container's security context (runAsUser, runAsNonRoot, privileged, readOnlyRootFilesystem, capabilities) in the single-pod view")
+	cmd.Flags().BoolVar(&options.WideProbes, "wide-probes", false, "Show HTTP probes' scheme (HTTP/HTTPS), host and custom headers, to spot probes that can't reach the app")
+	cmd.Flags().IntVar(&options.BarWidth, "bar-width", 10, "Number of segments in resource usage bars (workload summary mini bars use four fifths of it)")
+	cmd.Flags().Float64Var(&options.Thresholds.Warning, "warning-threshold", 70, "Usage percentage at which resource bars and values turn yellow")
+	cmd.Flags().Float64Var(&options.Thresholds.Critical, "critical-threshold", 90, "Usage percentage at which resource bars and values turn red")
+	cmd.Flags().BoolVar(&options.NodeSummary, "node-summary", false, "In workload views, add a table of pods per node with their health, to spot node-correlated failures")
+	cmd.Flags().BoolVar(&options.EvictionRisk, "eviction-risk", false, "In workload views, rank pods by how likely the kubelet is to evict them under node memory pressure (QoS class, priority, memory usage over requests)")
+	cmd.Flags().BoolVar(&options.NodeHealth, "node-health", false, "Fetch the nodes hosting the pods and flag the pods on nodes that are NotReady or under Memory/Disk/PID pressure (one lookup per node)")
+	cmd.Flags().BoolVar(&options.NodeContext, "node-context", false, "In the single-pod view, show the pod's usage as a share of its node's allocatable CPU and memory (one extra node lookup)")
+	cmd.Flags().BoolVar(&options.RawMetrics, "raw-metrics", false, "Show exact CPU millicores and memory bytes instead of rounded cores and Mi/Gi")
+	cmd.Flags().BoolVar(&options.Timestamps, "timestamps", false, "Show absolute RFC3339 timestamps instead of relative ages")
+	cmd.Flags().BoolVar(&options.UTC, "utc", false, "Show absolute timestamps in UTC (implies --timestamps)")
+	cmd.Flags().StringVar(&options.Timezone, "timezone", "", "Show absolute timestamps in the given time zone, e.g. America/New_York (implies --timestamps)")
 	cmd.Flags().BoolVar(&options.Problematic, "problematic", false, "Show only problematic containers and pods (restarts, failures, terminating, etc.)")
+	cmd.Flags().StringSliceVar(&options.Statuses, "status", nil, "Show only containers in one of these states, and the pods running them (e.g. CrashLoopBackOff,ImagePullBackOff,OOMKilled)")
 	cmd.Flags().StringVar(&options.SortBy, "sort", "name", "Sort by: name, restarts, cpu, memory, age")
+	cmd.Flags().StringVar(&options.SortWorkloads, "sort-workloads", "", "Order workloads by: name, health (most critical first), restarts")
 	cmd.Flags().BoolVar(&options.ShowLogs, "logs", false, "Show last 10 lines of container logs (Pod resources only)")
+	cmd.Flags().StringVar(&options.LogGrep, "log-grep", "", "Show the last 10 log lines matching this regular expression, searching the last 1000 lines (implies --logs)")
 	cmd.Flags().StringVarP(&options.ContainerName, "container", "c", "", "Show only the specified container")
+	cmd.Flags().StringVar(&options.ContainerType, "container-type", "all", "Show only containers of this type: init, standard, ephemeral, all")
+	cmd.Flags().BoolVar(&options.ShowEvents, "events", true, "Show recent pod events (use --events=false to skip the events lookup)")
+	cmd.Flags().BoolVar(&options.NoEvents, "no-events", false, "Skip the events lookup and the events section, which is faster in busy namespaces (same as --events=false)")
+	cmd.Flags().BoolVar(&options.EventsWarningsOnly, "events-warnings-only", false, "Only show Warning events, skipping Normal lifecycle events like Pulled, Created and Started")
+	cmd.Flags().IntVar(&options.MaxEvents, "max-events", 10, "Maximum number of events to show per pod or workload (0 for unlimited)")
+	cmd.Flags().StringVar(&options.EventsSort, "events-sort", string(types.EventSortBySeverity), "Order events by: severity (FailedScheduling, then warnings, then newest) or time (newest first)")
+	cmd.Flags().BoolVar(&options.ShowEnv, "env", true, "Show container environment variables in the single-pod view")
+	cmd.Flags().BoolVar(&options.ContainersSummaryOnly, "containers-summary-only", false, "In the single-pod view, show details only for containers that aren't healthy (automatic for pods with 8 or more containers)")
+	cmd.Flags().BoolVar(&options.AllContainers, "all", false, "In the single-pod view, show details of every container, even in pods with many sidecars")
+	cmd.Flags().BoolVar(&options.ResourcesOnly, "resources-only", false, "Only collect resource usage, skipping events, environment variables and logs (events are shown by default)")
+	cmd.Flags().BoolVar(&options.ShowTolerations, "show-tolerations", false, "Show pod tolerations in the single-pod view (always shown for pending pods)")
+	cmd.Flags().BoolVar(&options.ShowNodeSelector, "show-node-selector", false, "Show the node selector and node affinity in the single-pod view (always shown for pending pods)")
+	cmd.Flags().BoolVar(&options.ShowIDs, "show-ids", false, "Show the pod UID and resourceVersion in the single-pod view (always included in JSON/YAML)")
+	cmd.Flags().BoolVar(&options.ShowPDB, "pdb", false, "Show PodDisruptionBudgets covering each workload and how many disruptions they allow")
+	cmd.Flags().BoolVar(&options.ShowHPA, "hpa", false, "Show HorizontalPodAutoscalers scaling each workload: current and desired replicas and the metrics driving them")
+	cmd.Flags().BoolVar(&options.AllAnnotations, "all-annotations", false, "Show all pod annotations, including noisy ones like last-applied-configuration")
+	cmd.Flags().BoolVar(&options.Summary, "summary", false, "Show a one-line roll-up per workload (health, ready replicas, restarts, CPU/memory) without per-pod tables")
+	cmd.Flags().IntVar(&options.Samples, "samples", 1, "Read CPU/memory usage this many times per workload and show min, max and whether it is increasing or decreasing")
+	cmd.Flags().DurationVar(&options.SampleInterval, "sample-interval", 15*time.Second, "Interval between --samples readings; metrics-server refreshes usage about every 15s, so shorter intervals repeat readings")
+	cmd.Flags().BoolVar(&options.Top, "top", false, "Show only a per-container CPU/memory usage table sorted by --sort, with totals, like kubectl top (implies --resources-only)")
+	cmd.Flags().BoolVar(&options.Explain, "explain", false, "After the output, print suggested next steps for each distinct issue found (table output only)")
+	cmd.Flags().BoolVarP(&options.Quiet, "quiet", "q", false, "Print one status line per workload; exit 2 if any workload is degraded, 3 if any is critical")
+	cmd.Flags().BoolVar(&options.WatchProblematic, "watch-problematic", false, "Keep watching and print a timestamped line only when a pod changes health level")
+	cmd.Flags().DurationVar(&options.WatchInterval, "watch-interval", 5*time.Second, "Interval between checks in --watch-problematic and --wait-healthy mode")
+	cmd.Flags().BoolVar(&options.WaitHealthy, "wait-healthy", false, "Poll until every workload is Healthy with no pods still starting, then print it and exit 0; exit 1 if --timeout passes first")
+	cmd.Flags().DurationVar(&options.WaitTimeout, "timeout", 5*time.Minute, "How long --wait-healthy waits before giving up")
+	cmd.Flags().BoolVar(&options.Profile, "profile", false, "Print how long resolving, pod listing, metrics, events, per-pod collection, analysis and formatting took, to stderr")
+	cmd.Flags().BoolVar(&options.Bell, "bell", false, "Ring the terminal bell on health transitions in --watch-problematic mode")
+	cmd.Flags().StringVar(&options.Compare, "compare", "", "Label selector of pods to compare side by side against the target (e.g. track=canary)")
+	cmd.Flags().StringVar(&options.Snapshot, "snapshot", "", "Also save the collected workloads to this file as JSON, for a later --diff")
+	cmd.Flags().StringVar(&options.Diff, "diff", "", "Print what changed since a --snapshot (or --output json) file: pods added or removed, health transitions, restarts and images")
 
 	// Mark some flags as mutually exclusive
 	cmd.MarkFlagsMutuallyExclusive("deployment", "statefulset", "job", "daemonset", "selector")
 	cmd.MarkFlagsMutuallyExclusive("namespace", "all-namespaces")
+	cmd.MarkFlagsMutuallyExclusive("utc", "timezone")
+	cmd.MarkFlagsMutuallyExclusive("no-color", "color")
+	cmd.MarkFlagsMutuallyExclusive("no-events", "events")
+	cmd.MarkFlagsMutuallyExclusive("containers-summary-only", "all")
+	cmd.MarkFlagsMutuallyExclusive("watch-problematic", "compare")
+	cmd.MarkFlagsMutuallyExclusive("wait-healthy", "watch-problematic", "compare")
+	cmd.MarkFlagsMutuallyExclusive("quiet", "summary", "explain", "compare", "watch-problematic")
+	cmd.MarkFlagsMutuallyExclusive("top", "quiet", "summary", "explain", "compare", "diff", "watch-problematic", "wait-healthy", "dry-run")
+	cmd.MarkFlagsMutuallyExclusive("diff", "compare", "watch-problematic", "wait-healthy", "quiet")
+	cmd.MarkFlagsMutuallyExclusive("snapshot", "watch-problematic", "wait-healthy")
+	cmd.MarkFlagsMutuallyExclusive("profile", "watch-problematic", "wait-healthy")
+	// Neither collects logs, so there would be nothing to grep
+	cmd.MarkFlagsMutuallyExclusive("log-grep", "resources-only")
+	cmd.MarkFlagsMutuallyExclusive("log-grep", "top")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "logs", "log-grep", "node-context", "node-health", "pdb", "hpa", "compare", "dry-run", "watch-problematic", "wait-healthy")
+	cmd.MarkFlagsMutuallyExclusive("samples", "watch-problematic", "wait-healthy", "dry-run", "metrics-from", "from-file")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "watch-problematic", "wait-healthy", "compare", "diff", "snapshot", "quiet", "summary")
 
 	return cmd
 }
 
-func runContainerStatus(options *types.Options) error {
+func runContainerStatus(options *types.Options) (err error) {
 	// Determine which resource flag was set
 	if options.Deployment != "" {
 		options.ResourceType = "deployment"
@@ -111,62 +227,488 @@ func runContainerStatus(options *types.Options) error {
 		options.ResourceName = options.DaemonSet
 	}
 
-	// Initialize Kubernetes clients
+	switch types.WorkloadSortType(options.SortWorkloads) {
+	case "", types.WorkloadSortByName, types.WorkloadSortByHealth, types.WorkloadSortByRestarts:
+	default:
+		return fmt.Errorf("invalid --sort-workloads %q: must be one of name, health, restarts", options.SortWorkloads)
+	}
+
+	switch types.EventSortType(options.EventsSort) {
+	case "", types.EventSortBySeverity, types.EventSortByTime:
+	default:
+		return fmt.Errorf("invalid --events-sort %q: must be one of severity, time", options.EventsSort)
+	}
+
+	for _, key := range options.LabelColumns {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("invalid --label-columns %q: label keys must not be empty", strings.Join(options.LabelColumns, ","))
+		}
+	}
+
+	if options.MaxEvents < 0 {
+		return fmt.Errorf("--max-events must be 0 (unlimited) or greater, got %d", options.MaxEvents)
+	}
+
+	if options.LogGrep != "" {
+		pattern, err := regexp.Compile(options.LogGrep)
+		if err != nil {
+			return fmt.Errorf("invalid --log-grep %q: %w", options.LogGrep, err)
+		}
+		options.LogPattern = pattern
+		options.ShowLogs = true
+	}
+
+	// Catch malformed selectors before connecting to the cluster
+	if _, err := resolver.ParseSelector(options.Selector); err != nil {
+		return err
+	}
+	if options.Compare != "" {
+		if _, err := resolver.ParseSelector(options.Compare); err != nil {
+			return fmt.Errorf("--compare: %w", err)
+		}
+	}
+	if options.DryRun && options.Selector == "" {
+		return fmt.Errorf("--dry-run needs --selector")
+	}
+	if options.DumpTime != "" && len(options.FromFile) == 0 {
+		return fmt.Errorf("--dump-time needs --from-file")
+	}
+
+	// Output written to a file is treated like output piped off the terminal
+	toTerminal := options.OutputFile == "" && term.IsTerminal(int(os.Stdout.Fd()))
+	noColor, err := resolveNoColor(options, toTerminal, os.Getenv("NO_COLOR") != "")
+	if err != nil {
+		return err
+	}
+	options.NoColor = noColor
+	color.NoColor = noColor
+
+	if options.Hyperlinks && options.PodURL == "" && options.NodeURL == "" {
+		return fmt.Errorf("--hyperlinks needs a link target: set --pod-url and/or --node-url")
+	}
+	// Escape sequences would end up in files and pipes, so links are only written to a terminal
+	options.Hyperlinks = options.Hyperlinks && toTerminal
+
+	switch types.ContainerType(options.ContainerType) {
+	case "", "all", types.ContainerTypeInit, types.ContainerTypeStandard, types.ContainerTypeEphemeral:
+	default:
+		return fmt.Errorf("invalid --container-type %q: must be one of init, standard, ephemeral, all", options.ContainerType)
+	}
+
+	for _, status := range options.Statuses {
+		if strings.TrimSpace(status) == "" {
+			return fmt.Errorf("--status values must not be empty")
+		}
+	}
+
+	if options.BarWidth < 5 || options.BarWidth > 100 {
+		return fmt.Errorf("--bar-width must be between 5 and 100, got %d", options.BarWidth)
+	}
+	if options.Thresholds.Warning <= 0 || options.Thresholds.Warning >= options.Thresholds.Critical {
+		return fmt.Errorf("--warning-threshold must be greater than 0 and below --critical-threshold, got %g and %g",
+			options.Thresholds.Warning, options.Thresholds.Critical)
+	}
+
+	if options.ChunkSize < 0 {
+		return fmt.Errorf("--chunk-size must be 0 (no paging) or greater, got %d", options.ChunkSize)
+	}
+
+	if (options.WatchProblematic || options.WaitHealthy) && options.WatchInterval <= 0 {
+		return fmt.Errorf("--watch-interval must be greater than 0, got %s", options.WatchInterval)
+	}
+
+	if options.Samples < 1 {
+		return fmt.Errorf("--samples must be 1 or greater, got %d", options.Samples)
+	}
+	if options.Samples > 1 && options.SampleInterval <= 0 {
+		return fmt.Errorf("--sample-interval must be greater than 0, got %s", options.SampleInterval)
+	}
+
+	if options.WaitHealthy && options.WaitTimeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than 0, got %s", options.WaitTimeout)
+	}
+
+	if options.FieldSelector != "" {
+		if _, err := fields.ParseSelector(options.FieldSelector); err != nil {
+			return fmt.Errorf("invalid field selector %q: %w", options.FieldSelector, err)
+		}
+	}
+
+	// A time zone only matters for absolute timestamps, so selecting one turns them on
+	if options.UTC || options.Timezone != "" {
+		if options.Timezone != "" {
+			if _, err := time.LoadLocation(options.Timezone); err != nil {
+				return fmt.Errorf("invalid timezone %q: %w", options.Timezone, err)
+			}
+		}
+		options.Timestamps = true
+	}
+
+	// Phase timings go to stderr so they never mix with structured output
+	var timings *profile.Profile
+	if options.Profile {
+		timings = profile.New()
+		defer timings.Print(os.Stderr)
+	}
+
+	// Load offline metrics before talking to the cluster so a bad file fails fast
+	var metricsSnapshot *collector.MetricsSnapshot
+	if options.MetricsFrom != "" {
+		snapshot, err := collector.LoadMetricsSnapshot(options.MetricsFrom)
+		if err != nil {
+			return err
+		}
+		metricsSnapshot = snapshot
+	}
+
+	// Offline pods replace the cluster altogether
+	var podDump *collector.PodDump
+	if len(options.FromFile) > 0 {
+		dump, err := collector.LoadPodDump(options.FromFile)
+		if err != nil {
+			return err
+		}
+		if options.DumpTime != "" {
+			taken, err := time.Parse(time.RFC3339, options.DumpTime)
+			if err != nil {
+				return fmt.Errorf("invalid --dump-time %q: use RFC3339, e.g. 2024-05-01T10:00:00Z", options.DumpTime)
+			}
+			dump.SetReferenceTime(taken)
+		}
+		podDump = dump
+	}
+
+	// Likewise read the snapshot to diff against before it can be overwritten by --snapshot
+	var previousWorkloads []types.WorkloadInfo
+	var previousSavedAt time.Time
+	if options.Diff != "" {
+		previous, savedAt, err := output.ReadSnapshot(options.Diff)
+		if err != nil {
+			return err
+		}
+		previousWorkloads, previousSavedAt = previous, savedAt
+	}
+
+	// Initialize components; a dump stands in for the cluster, so there is nothing to connect to
+	var workloadResolver *resolver.Resolver
+	var podCollector *collector.Collector
+	var metricsClientErr error
+	if podDump != nil {
+		podCollector = newDumpCollector(podDump)
+	} else {
+		workloadResolver, podCollector, metricsClientErr, err = connectCluster(options)
+		if err != nil {
+			return err
+		}
+	}
+	if metricsSnapshot != nil {
+		podCollector.UseMetricsSnapshot(metricsSnapshot)
+	}
+	if timings != nil {
+		podCollector.UsePhaseTimer(timings.Track)
+	}
+	// A single run reports warnings together at the end; watch modes print them as they happen
+	if !options.WatchProblematic && !options.WaitHealthy {
+		podCollector.DeferWarnings()
+	}
+	if metricsClientErr != nil {
+		podCollector.Warnf("Could not create metrics client: %v", metricsClientErr)
+	}
+	healthAnalyzer := analyzer.New()
+	if podDump != nil {
+		// Judge the dump as of when it was taken, and say when that was since it is only a guess
+		options.ReferenceTime = podDump.ReferenceTime()
+		healthAnalyzer.UseReferenceTime(options.ReferenceTime)
+		if options.DumpTime == "" {
+			fmt.Fprintf(os.Stderr, "Reading the dump as of %s; set --dump-time if it was taken earlier\n", options.ReferenceTime.Format(time.RFC3339))
+		}
+	}
+	var out io.Writer = os.Stdout
+	if options.OutputFile != "" {
+		file := &outputFile{path: options.OutputFile}
+		defer func() {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		out = file
+	}
+	formatter := output.NewWithWriter(options, out)
+
+	ctx := context.Background()
+
+	if options.NoEvents {
+		options.ShowEvents = false
+	}
+
+	// --resources-only skips everything that isn't needed for resource usage, and --top needs nothing else
+	if options.ResourcesOnly || options.Top {
+		options.ShowEvents = false
+		options.ShowEnv = false
+		options.ShowLogs = false
+	}
+
+	// Dry run: report what the selector matches without collecting anything else
+	if options.DryRun {
+		owners, matched, err := workloadResolver.MatchSelector(ctx, options)
+		if err != nil {
+			return accessError(fmt.Errorf("failed to resolve resources: %w", err), options)
+		}
+		return formatter.OutputSelectorMatches(owners, matched)
+	}
+
+	// Watch mode: only report pod health transitions
+	if options.WatchProblematic {
+		return watchHealthTransitions(ctx, workloadResolver, podCollector, healthAnalyzer, timings, out, options)
+	}
+
+	// Wait mode: poll until healthy or the timeout passes
+	if options.WaitHealthy {
+		return waitHealthy(ctx, workloadResolver, podCollector, healthAnalyzer, timings, formatter, options)
+	}
+
+	// Single execution mode
+	workloads, err := collectWorkloads(ctx, workloadResolver, podCollector, healthAnalyzer, timings, options)
+	if err != nil {
+		return err
+	}
+
+	// A snapshot keeps every pod, so a later --diff sees healthy pods too rather than reporting them gone
+	if options.Snapshot != "" {
+		if err := output.WriteSnapshot(options.Snapshot, workloads); err != nil {
+			return err
+		}
+	}
+
+	// Diff mode: report what changed since the earlier snapshot, which holds every pod as well
+	if options.Diff != "" {
+		return formatter.OutputDiff(previousWorkloads, workloads, options.Diff, previousSavedAt)
+	}
+
+	// Filter problems if requested and order the workloads
+	workloads = presentWorkloads(workloads, options)
+
+	// Compare mode: collect the comparison set and print both side by side
+	if options.Compare != "" {
+		compareOptions := *options
+		compareOptions.Selector = options.Compare
+		compareOptions.ResourceName = ""
+		compareOptions.ResourceType = ""
+
+		compareWorkloads, err := collectWorkloads(ctx, workloadResolver, podCollector, healthAnalyzer, timings, &compareOptions)
+		if err != nil {
+			return fmt.Errorf("failed to collect comparison set: %w", err)
+		}
+		if options.Problematic {
+			compareWorkloads = filterProblematicWorkloads(compareWorkloads)
+		}
+
+		return formatter.OutputComparison(workloads, compareWorkloads)
+	}
+
+	// Output results
+	stopFormatting := timings.Track("formatting")
+	err = formatter.Output(workloads)
+	stopFormatting()
+	if err != nil {
+		return err
+	}
+	if options.Quiet {
+		return healthExitError(workloads)
+	}
+	return nil
+}
+
+// outputFile is the --output-file, created on the first write so that a run failing before it has
+// anything to print doesn't leave an empty file behind
+type outputFile struct {
+	path string
+	file *os.File
+	err  error // First create or write error, reported again by Close
+}
+
+func (o *outputFile) Write(p []byte) (int, error) {
+	if o.err != nil {
+		return 0, o.err
+	}
+	if o.file == nil {
+		file, err := os.Create(o.path)
+		if err != nil {
+			o.err = fmt.Errorf("failed to create output file: %w", err)
+			return 0, o.err
+		}
+		o.file = file
+	}
+	n, err := o.file.Write(p)
+	if err != nil {
+		o.err = fmt.Errorf("failed to write output file: %w", err)
+	}
+	return n, o.err
+}
+
+// Close closes the file if anything was written to it. The formatter doesn't check every write, so
+// this is where a file that couldn't be created or fully written is reported.
+func (o *outputFile) Close() error {
+	if o.file == nil {
+		return o.err
+	}
+	if err := o.file.Close(); err != nil && o.err == nil {
+		o.err = fmt.Errorf("failed to write output file: %w", err)
+	}
+	return o.err
+}
+
+// kubeconfigOverrides applies the --context, --cluster and --user flags on top of the kubeconfig,
+// the same way kubectl's connection flags do
+func kubeconfigOverrides(options *types.Options) *clientcmd.ConfigOverrides {
 	configOverrides := &clientcmd.ConfigOverrides{}
 	if options.Context != "" {
 		configOverrides.CurrentContext = options.Context
 	}
+	if options.Cluster != "" {
+		configOverrides.Context.Cluster = options.Cluster
+	}
+	if options.User != "" {
+		configOverrides.Context.AuthInfo = options.User
+	}
+	return configOverrides
+}
+
+// connectCluster creates the resolver and collector for the cluster in the kubeconfig, and fills in its
+// current namespace when none was given. Metrics are optional, so a metrics client that can't be created
+// is returned as an error for a warning rather than failing.
+func connectCluster(options *types.Options) (podResolver *resolver.Resolver, podCollector *collector.Collector, metricsClientErr error, err error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if options.Kubeconfig != "" {
+		loadingRules.ExplicitPath = options.Kubeconfig
+	}
+
+	configOverrides := kubeconfigOverrides(options)
 
 	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		clientcmd.NewDefaultClientConfigLoadingRules(),
+		loadingRules,
 		configOverrides,
 	).ClientConfig()
 	if err != nil {
-		return fmt.Errorf("failed to create kubernetes config: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to create kubernetes config: %w", err)
+	}
+
+	// Impersonate another identity, e.g. to check what a service account can see
+	if options.As != "" || len(options.AsGroups) > 0 || options.AsUID != "" {
+		config.Impersonate = rest.ImpersonationConfig{
+			UserName: options.As,
+			UID:      options.AsUID,
+			Groups:   options.AsGroups,
+		}
 	}
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		return fmt.Errorf("failed to create kubernetes client: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
-	metricsClient, err := metricsv1beta1.NewForConfig(config)
-	if err != nil {
-		// Metrics client is optional, continue without it
-		fmt.Fprintf(os.Stderr, "Warning: Could not create metrics client: %v\n", err)
+	// Metrics client is optional, continue without it
+	metricsClient, metricsClientErr := metricsv1beta1.NewForConfig(config)
+	if metricsClientErr != nil {
 		metricsClient = nil
 	}
 
 	// Set default namespace if not specified
 	if options.Namespace == "" && !options.AllNamespaces {
 		namespace, _, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-			clientcmd.NewDefaultClientConfigLoadingRules(),
+			loadingRules,
 			configOverrides,
 		).Namespace()
 		if err != nil {
-			return fmt.Errorf("failed to get current namespace: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed to get current namespace: %w", err)
 		}
 		options.Namespace = namespace
 	}
 
 	// Initialize components
-	resolver := resolver.New(clientset)
-	collector := collector.New(clientset, metricsClient)
-	analyzer := analyzer.New()
-	formatter := output.New(options)
+	podResolver = resolver.New(clientset)
+	if dynamicClient, err := dynamic.NewForConfig(config); err == nil {
+		// Lets resource/name arguments name any type the API server serves, e.g. rs/web or rollouts/api
+		discoveryClient := memory.NewMemCacheClient(clientset.Discovery())
+		mapper := restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient), discoveryClient, nil)
+		podResolver.UseDynamic(dynamicClient, mapper)
+	}
+	return podResolver, collector.New(clientset, metricsClient), metricsClientErr, nil
+}
 
-	ctx := context.Background()
+// newDumpCollector creates a collector that reads pods and events from a dump instead of a cluster
+func newDumpCollector(dump *collector.PodDump) *collector.Collector {
+	dumpCollector := collector.New(nil, nil)
+	dumpCollector.UsePodDump(dump)
+	return dumpCollector
+}
 
-	// Single execution mode
-	workloads, err := resolver.Resolve(ctx, options)
+// unhealthyError reports unhealthy workloads in --quiet mode through the process exit code
+type unhealthyError struct {
+	exitCode int
+}
+
+func (e *unhealthyError) Error() string {
+	return fmt.Sprintf("workloads unhealthy (exit code %d)", e.exitCode)
+}
+
+// healthExitError returns an unhealthyError for the worst workload health level, or nil when all are healthy
+func healthExitError(workloads []types.WorkloadInfo) error {
+	exitCode := 0
+	for _, workload := range workloads {
+		switch workload.Health.Level {
+		case string(types.HealthLevelCritical):
+			exitCode = 3
+		case string(types.HealthLevelDegraded):
+			if exitCode < 2 {
+				exitCode = 2
+			}
+		}
+	}
+	if exitCode == 0 {
+		return nil
+	}
+	return &unhealthyError{exitCode: exitCode}
+}
+
+// collectWorkloads resolves the target resources, collects their pods and analyzes their health
+func collectWorkloads(ctx context.Context, workloadResolver *resolver.Resolver, podCollector *collector.Collector, healthAnalyzer *analyzer.Analyzer, timings *profile.Profile, options *types.Options) ([]types.WorkloadInfo, error) {
+	stopResolve := timings.Track("resolve")
+	var workloads []types.WorkloadInfo
+	var err error
+	if len(options.FromFile) > 0 {
+		// Offline, the pods' own owner references stand in for the resolver
+		workloads, err = podCollector.DumpWorkloads(options)
+	} else {
+		workloads, err = workloadResolver.Resolve(ctx, options)
+	}
+	stopResolve()
 	if err != nil {
-		return fmt.Errorf("failed to resolve resources: %w", err)
+		return nil, accessError(fmt.Errorf("failed to resolve resources: %w", err), options)
 	}
 
 	if len(workloads) == 0 {
-		return fmt.Errorf("no resources found")
+		return nil, fmt.Errorf("no resources found")
+	}
+
+	// A named pod is fetched directly, so there is nothing for a field selector to filter
+	if options.FieldSelector != "" && options.ResourceName != "" && workloads[0].Kind == "Pod" {
+		return nil, fmt.Errorf("--field-selector cannot be used with a single pod, use it with a workload or --selector")
 	}
 
+	// Always collect resource usage now that we have efficient bulk collection
+	options.ShowResourceUsage = true
+
+	// Usage is sampled for all workloads at once, so the wait doesn't grow with the number of workloads
+	if options.Samples > 1 {
+		if err := podCollector.SampleWorkloads(ctx, workloads, options); err != nil {
+			return nil, accessError(fmt.Errorf("failed to collect pod data: %w", err), options)
+		}
+	}
+
+	// Warnings raised before collection apply to every workload
+	sharedWarnings := podCollector.TakeWarnings()
+
 	// Collect data for all workloads
 	for i, workload := range workloads {
 		// Set optimization flags based on workload type
@@ -176,36 +718,143 @@ func runContainerStatus(options *types.Options) error {
 
 		// Restrict --logs to only work with Pod resources
 		if options.ShowLogs && !isSinglePod {
-			fmt.Fprintf(os.Stderr, "Warning: --logs flag is only supported for individual Pods, ignoring for %s '%s'\n",
+			podCollector.Warnf("--logs flag is only supported for individual Pods, ignoring for %s '%s'",
 				workload.Kind, workload.Name)
 			options.ShowLogs = false
 		}
 
-		// Always collect resource usage now that we have efficient bulk collection
-		options.ShowResourceUsage = true
-
-		pods, err := collector.CollectPods(ctx, workload, options)
+		pods, err := podCollector.CollectPods(ctx, workload, options)
 		if err != nil {
-			return fmt.Errorf("failed to collect pod data: %w", err)
+			return nil, accessError(fmt.Errorf("failed to collect pod data: %w", err), options)
 		}
 		workloads[i].Pods = pods
+		workloads[i].MetricsUnavailable = podCollector.MetricsUnavailable()
+		workloads[i].MetricsForbidden = podCollector.MetricsForbidden()
+		workloads[i].EventsForbidden = podCollector.EventsForbidden()
 
 		// Analyze health for each pod
-		for j, pod := range workloads[i].Pods {
-			workloads[i].Pods[j].Health = analyzer.AnalyzePodHealth(pod)
+		stopAnalysis := timings.Track("analysis")
+		for j := range workloads[i].Pods {
+			pod := &workloads[i].Pods[j]
+			healthAnalyzer.AnalyzeContainers(pod)
+			pod.Health = healthAnalyzer.AnalyzePodHealth(*pod)
 		}
 
 		// Analyze overall workload health
-		workloads[i].Health = analyzer.AnalyzeWorkloadHealth(workloads[i])
+		workloads[i].Health = healthAnalyzer.AnalyzeWorkloadHealth(workloads[i])
+		workloads[i].RestartReasons = healthAnalyzer.RestartReasons(workloads[i].Pods)
+		stopAnalysis()
+
+		if options.ShowPDB {
+			pdbs, err := podCollector.CollectPDBs(ctx, workloads[i])
+			if err != nil {
+				// Disruption budgets are extra context, so don't fail the whole run over them
+				podCollector.Warnf("%v", accessError(err, options))
+			}
+			workloads[i].PDBs = pdbs
+		}
+
+		if options.ShowHPA {
+			hpas, err := podCollector.CollectHPAs(ctx, workloads[i])
+			if err != nil {
+				// Like disruption budgets, autoscalers only explain the replica count
+				podCollector.Warnf("%v", accessError(err, options))
+			}
+			workloads[i].HPAs = hpas
+		}
+
+		// Notes from resolving, e.g. a Service's manually managed endpoints, come first
+		workloads[i].Warnings = append(workloads[i].Warnings, sharedWarnings...)
+		workloads[i].Warnings = append(workloads[i].Warnings, podCollector.TakeWarnings()...)
 	}
 
-	// Filter problems if requested
-	if options.Problematic {
-		workloads = filterProblematicWorkloads(workloads)
+	return workloads, nil
+}
+
+// resolveNoColor decides whether to disable color from --no-color, --color and the environment
+func resolveNoColor(options *types.Options, isTerminal, noColorEnv bool) (bool, error) {
+	if options.NoColor {
+		return true, nil
 	}
+	switch options.Color {
+	case "always":
+		return false, nil
+	case "never":
+		return true, nil
+	case "", "auto":
+		return !isTerminal || noColorEnv, nil
+	default:
+		return false, fmt.Errorf("invalid --color %q: must be one of auto, always, never", options.Color)
+	}
+}
 
-	// Output results
-	return formatter.Output(workloads)
+// healthRank orders health levels from most to least severe
+var healthRank = map[string]int{
+	string(types.HealthLevelCritical): 0,
+	string(types.HealthLevelDegraded): 1,
+	string(types.HealthLevelHealthy):  2,
+}
+
+// sortWorkloads orders the workloads themselves, independently of the pod order within each
+func sortWorkloads(workloads []types.WorkloadInfo, sortBy string) {
+	switch types.WorkloadSortType(sortBy) {
+	case types.WorkloadSortByName:
+		sort.SliceStable(workloads, func(i, j int) bool {
+			if workloads[i].Name != workloads[j].Name {
+				return workloads[i].Name < workloads[j].Name
+			}
+			return workloads[i].Namespace < workloads[j].Namespace
+		})
+	case types.WorkloadSortByHealth:
+		sort.SliceStable(workloads, func(i, j int) bool {
+			rankI, rankJ := healthRank[workloads[i].Health.Level], healthRank[workloads[j].Health.Level]
+			if rankI != rankJ {
+				return rankI < rankJ
+			}
+			return workloads[i].Health.Score < workloads[j].Health.Score
+		})
+	case types.WorkloadSortByRestarts:
+		sort.SliceStable(workloads, func(i, j int) bool {
+			return workloadRestarts(workloads[i]) > workloadRestarts(workloads[j])
+		})
+	}
+}
+
+// workloadRestarts sums the restart counts of every container in the workload
+func workloadRestarts(workload types.WorkloadInfo) int32 {
+	var restarts int32
+	for _, pod := range workload.Pods {
+		for _, container := range append(pod.InitContainers, pod.Containers...) {
+			restarts += container.RestartCount
+		}
+	}
+	return restarts
+}
+
+// accessError makes RBAC denials stand out from other failures, naming the impersonated identity if any
+func accessError(err error, options *types.Options) error {
+	if !apierrors.IsForbidden(err) {
+		return err
+	}
+	if options.As != "" || len(options.AsGroups) > 0 || options.AsUID != "" {
+		return fmt.Errorf("access denied while impersonating %s: %w", impersonatedIdentity(options), err)
+	}
+	return fmt.Errorf("access denied: %w", err)
+}
+
+// impersonatedIdentity describes the identity set with --as, --as-group and --as-uid
+func impersonatedIdentity(options *types.Options) string {
+	identity := options.As
+	if identity == "" {
+		identity = "(no user)"
+	}
+	if options.AsUID != "" {
+		identity += fmt.Sprintf(" (uid %s)", options.AsUID)
+	}
+	if len(options.AsGroups) > 0 {
+		identity += fmt.Sprintf(" with groups %s", strings.Join(options.AsGroups, ","))
+	}
+	return identity
 }
 
 // filterProblematicWorkloads filters workloads to only include those with problems
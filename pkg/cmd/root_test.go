@@ -1,8 +1,17 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/clientcmd"
+
 	"github.com/nareshku/kubectl-container-status/pkg/types"
 )
 
@@ -62,3 +71,294 @@ func TestLogsRestriction(t *testing.T) {
 		})
 	}
 }
+
+func TestEventsAndEnvFlags(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		expectEvents bool
+		expectEnv    bool
+	}{
+		{
+			name:         "defaults enable events and env",
+			args:         []string{},
+			expectEvents: true,
+			expectEnv:    true,
+		},
+		{
+			name:         "events disabled explicitly",
+			args:         []string{"--events=false"},
+			expectEvents: false,
+			expectEnv:    true,
+		},
+		{
+			name:         "env disabled explicitly",
+			args:         []string{"--env=false"},
+			expectEvents: true,
+			expectEnv:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewContainerStatusCommand()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+
+			events, _ := cmd.Flags().GetBool("events")
+			env, _ := cmd.Flags().GetBool("env")
+			if events != tt.expectEvents {
+				t.Errorf("expected events=%v, got %v", tt.expectEvents, events)
+			}
+			if env != tt.expectEnv {
+				t.Errorf("expected env=%v, got %v", tt.expectEnv, env)
+			}
+		})
+	}
+}
+
+func TestAccessError(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("cannot list resource"))
+
+	err := accessError(fmt.Errorf("failed to list pods: %w", forbidden), &types.Options{
+		As:       "system:serviceaccount:shop:checkout",
+		AsGroups: []string{"system:serviceaccounts"},
+	})
+	if !strings.HasPrefix(err.Error(), "access denied while impersonating system:serviceaccount:shop:checkout with groups system:serviceaccounts:") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+	if !apierrors.IsForbidden(err) {
+		t.Error("expected the forbidden error to remain inspectable")
+	}
+
+	if err := accessError(forbidden, &types.Options{}); !strings.HasPrefix(err.Error(), "access denied: ") {
+		t.Errorf("unexpected error message without impersonation: %v", err)
+	}
+
+	other := errors.New("connection refused")
+	if err := accessError(other, &types.Options{As: "jane"}); err != other {
+		t.Errorf("expected non-forbidden errors to pass through unchanged, got %v", err)
+	}
+}
+
+func TestSortWorkloadsByHealth(t *testing.T) {
+	workload := func(name, level string, score int) types.WorkloadInfo {
+		return types.WorkloadInfo{Name: name, Health: types.HealthStatus{Level: level, Score: score}}
+	}
+
+	workloads := []types.WorkloadInfo{
+		workload("web", string(types.HealthLevelHealthy), 100),
+		workload("worker", string(types.HealthLevelDegraded), 70),
+		workload("api", string(types.HealthLevelCritical), 40),
+		workload("cache", string(types.HealthLevelCritical), 10),
+		workload("cron", string(types.HealthLevelHealthy), 100),
+	}
+
+	sortWorkloads(workloads, string(types.WorkloadSortByHealth))
+
+	expected := []string{"cache", "api", "worker", "web", "cron"}
+	for i, name := range expected {
+		if workloads[i].Name != name {
+			t.Errorf("position %d: expected %s, got %s", i, name, workloads[i].Name)
+		}
+	}
+}
+
+func TestSortWorkloadsByRestarts(t *testing.T) {
+	workload := func(name string, restarts int32) types.WorkloadInfo {
+		return types.WorkloadInfo{Name: name, Pods: []types.PodInfo{{
+			Containers: []types.ContainerInfo{{Name: "app", RestartCount: restarts}},
+		}}}
+	}
+
+	workloads := []types.WorkloadInfo{workload("a", 1), workload("b", 7), workload("c", 3)}
+	sortWorkloads(workloads, string(types.WorkloadSortByRestarts))
+
+	expected := []string{"b", "c", "a"}
+	for i, name := range expected {
+		if workloads[i].Name != name {
+			t.Errorf("position %d: expected %s, got %s", i, name, workloads[i].Name)
+		}
+	}
+}
+
+func TestHealthExitError(t *testing.T) {
+	workload := func(level string) types.WorkloadInfo {
+		return types.WorkloadInfo{Health: types.HealthStatus{Level: level}}
+	}
+	healthy := workload(string(types.HealthLevelHealthy))
+	degraded := workload(string(types.HealthLevelDegraded))
+	critical := workload(string(types.HealthLevelCritical))
+
+	tests := []struct {
+		name      string
+		workloads []types.WorkloadInfo
+		exitCode  int
+	}{
+		{"all healthy", []types.WorkloadInfo{healthy, healthy}, 0},
+		{"one degraded", []types.WorkloadInfo{healthy, degraded}, 2},
+		{"critical wins over degraded", []types.WorkloadInfo{critical, degraded, healthy}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := healthExitError(tt.workloads)
+			if tt.exitCode == 0 {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			var unhealthy *unhealthyError
+			if !errors.As(err, &unhealthy) || unhealthy.exitCode != tt.exitCode {
+				t.Errorf("expected exit code %d, got %v", tt.exitCode, err)
+			}
+		})
+	}
+}
+
+func TestResolveNoColor(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    types.Options
+		isTerminal bool
+		noColorEnv bool
+		expected   bool
+	}{
+		{"auto on a terminal", types.Options{Color: "auto"}, true, false, false},
+		{"auto when piped", types.Options{Color: "auto"}, false, false, true},
+		{"auto with NO_COLOR", types.Options{Color: "auto"}, true, true, true},
+		{"always when piped", types.Options{Color: "always"}, false, true, false},
+		{"never on a terminal", types.Options{Color: "never"}, true, false, true},
+		{"--no-color wins", types.Options{Color: "always", NoColor: true}, true, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveNoColor(&tt.options, tt.isTerminal, tt.noColorEnv)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected noColor=%v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := resolveNoColor(&types.Options{Color: "sometimes"}, true, false); err == nil {
+		t.Error("expected an error for an invalid --color value")
+	}
+}
+
+func TestKubeconfigOverrides(t *testing.T) {
+	kubeconfig := `apiVersion: v1
+kind: Config
+current-context: prod
+clusters:
+- name: prod-east
+  cluster:
+    server: https://east.example.com
+- name: prod-west
+  cluster:
+    server: https://west.example.com
+users:
+- name: admin
+  user:
+    token: admin-token
+- name: viewer
+  user:
+    token: viewer-token
+contexts:
+- name: prod
+  context:
+    cluster: prod-east
+    user: admin
+`
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(kubeconfig), 0o600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		options       types.Options
+		expectedHost  string
+		expectedToken string
+	}{
+		{"current context", types.Options{}, "https://east.example.com", "admin-token"},
+		{"--cluster", types.Options{Cluster: "prod-west"}, "https://west.example.com", "admin-token"},
+		{"--user", types.Options{User: "viewer"}, "https://east.example.com", "viewer-token"},
+		{"--cluster and --user", types.Options{Cluster: "prod-west", User: "viewer"}, "https://west.example.com", "viewer-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadingRules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: path}
+			config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, kubeconfigOverrides(&tt.options)).ClientConfig()
+			if err != nil {
+				t.Fatalf("failed to build config: %v", err)
+			}
+			if config.Host != tt.expectedHost {
+				t.Errorf("expected host %q, got %q", tt.expectedHost, config.Host)
+			}
+			if config.BearerToken != tt.expectedToken {
+				t.Errorf("expected token %q, got %q", tt.expectedToken, config.BearerToken)
+			}
+		})
+	}
+}
+
+func TestLogGrepFlagConflicts(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"log-grep alone", []string{"--log-grep", "timeout"}, false},
+		{"log-grep with resources-only", []string{"--log-grep", "timeout", "--resources-only"}, true},
+		{"log-grep with top", []string{"--log-grep", "timeout", "--top"}, true},
+		{"top with resources-only", []string{"--top", "--resources-only"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewContainerStatusCommand()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+			if err := cmd.ValidateFlagGroups(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestOutputFile(t *testing.T) {
+	dir := t.TempDir()
+
+	// Nothing written, e.g. because resolving failed: no file is left behind
+	unused := &outputFile{path: filepath.Join(dir, "unused.html")}
+	if err := unused.Close(); err != nil {
+		t.Fatalf("expected closing an unwritten file to succeed, got %v", err)
+	}
+	if _, err := os.Stat(unused.path); !os.IsNotExist(err) {
+		t.Errorf("expected no file without output, got %v", err)
+	}
+
+	report := &outputFile{path: filepath.Join(dir, "report.txt")}
+	fmt.Fprint(report, "first ")
+	fmt.Fprint(report, "second")
+	if err := report.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+	if data, err := os.ReadFile(report.path); err != nil || string(data) != "first second" {
+		t.Errorf("expected the written output, got %q (%v)", data, err)
+	}
+
+	// The formatter ignores write errors, so Close reports them
+	missing := &outputFile{path: filepath.Join(dir, "no-such-dir", "report.txt")}
+	fmt.Fprint(missing, "output")
+	if err := missing.Close(); err == nil || !strings.Contains(err.Error(), "failed to create output file") {
+		t.Errorf("expected the create error from Close, got %v", err)
+	}
+}
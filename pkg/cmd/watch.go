@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/nareshku/kubectl-container-status/pkg/analyzer"
+	"github.com/nareshku/kubectl-container-status/pkg/collector"
+	"github.com/nareshku/kubectl-container-status/pkg/output"
+	"github.com/nareshku/kubectl-container-status/pkg/profile"
+	"github.com/nareshku/kubectl-container-status/pkg/resolver"
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// healthTransition records a pod moving from one health level to another between two collections
+type healthTransition struct {
+	Pod    string // namespace/name
+	From   string // Previous health level, empty for a newly seen pod
+	To     string // Current health level, empty for a pod that disappeared
+	Reason string
+}
+
+// watchHealthTransitions re-collects the workloads every interval and writes a timestamped line to out
+// for each pod whose health level changed, instead of redrawing the full output
+func watchHealthTransitions(ctx context.Context, workloadResolver *resolver.Resolver, podCollector *collector.Collector, healthAnalyzer *analyzer.Analyzer, timings *profile.Profile, out io.Writer, options *types.Options) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	location := time.Local
+	if options.UTC {
+		location = time.UTC
+	} else if options.Timezone != "" {
+		if loc, err := time.LoadLocation(options.Timezone); err == nil {
+			location = loc
+		}
+	}
+
+	var previous map[string]string
+	ticker := time.NewTicker(options.WatchInterval)
+	defer ticker.Stop()
+
+	for {
+		// Nodes fetched last round may have changed conditions since
+		podCollector.Reset()
+		workloads, err := collectWorkloads(ctx, workloadResolver, podCollector, healthAnalyzer, timings, options)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		} else {
+			current := podHealthLevels(workloads)
+			if previous == nil {
+				fmt.Fprintf(out, "Watching %d pods for health changes every %s (Ctrl+C to stop)\n", len(current), options.WatchInterval)
+				// Report pods that are already unhealthy when the watch starts
+				previous = make(map[string]string)
+				for key, level := range current {
+					if level == string(types.HealthLevelHealthy) {
+						previous[key] = level
+					}
+				}
+			}
+
+			transitions := diffPodHealth(previous, current, podHealthReasons(workloads))
+			timestamp := time.Now().In(location).Format(time.RFC3339)
+			for _, transition := range transitions {
+				fmt.Fprintf(out, "%s  %s\n", timestamp, formatTransition(transition))
+			}
+			if len(transitions) > 0 && options.Bell {
+				fmt.Fprint(out, "\a")
+			}
+			previous = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitHealthy re-collects the workloads every interval until all of them are healthy, then prints
+// them and returns nil. If the timeout passes (or the user interrupts) first, it prints the last
+// state collected and returns an unhealthyError with exit code 1. A missing workload or denied
+// access won't fix itself by waiting, so those errors are returned right away.
+func waitHealthy(ctx context.Context, workloadResolver *resolver.Resolver, podCollector *collector.Collector, healthAnalyzer *analyzer.Analyzer, timings *profile.Profile, formatter *output.Formatter, options *types.Options) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, options.WaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(options.WatchInterval)
+	defer ticker.Stop()
+
+	var last []types.WorkloadInfo
+	var lastStatus, lastWarning string
+	for {
+		// Nodes fetched last round may have changed conditions since
+		podCollector.Reset()
+		workloads, err := collectWorkloads(ctx, workloadResolver, podCollector, healthAnalyzer, timings, options)
+		if err != nil {
+			if permanentError(err) {
+				return err
+			}
+			// Like progress, a failure is only reported again once it changes
+			if warning := err.Error(); ctx.Err() == nil && warning != lastWarning {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+				lastWarning = warning
+			}
+		} else {
+			lastWarning = ""
+			last = workloads
+
+			waiting := unhealthyWorkloads(workloads)
+			if len(workloads) > 0 && len(waiting) == 0 {
+				return formatter.Output(presentWorkloads(workloads, options))
+			}
+			// Only report progress when it changes, so long waits don't flood CI logs
+			if status := describeWaiting(workloads, waiting); status != lastStatus {
+				fmt.Fprintf(os.Stderr, "Waiting for %s\n", status)
+				lastStatus = status
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Fprintf(os.Stderr, "Gave up after %s waiting for workloads to become healthy\n", options.WaitTimeout)
+			if last != nil {
+				if err := formatter.Output(presentWorkloads(last, options)); err != nil {
+					return err
+				}
+			}
+			return &unhealthyError{exitCode: 1}
+		case <-ticker.C:
+		}
+	}
+}
+
+// permanentError reports whether a collection error will still be there on the next try
+func permanentError(err error) bool {
+	return apierrors.IsNotFound(err) || apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err)
+}
+
+// presentWorkloads filters and orders the workloads for printing, the way a single run does
+func presentWorkloads(workloads []types.WorkloadInfo, options *types.Options) []types.WorkloadInfo {
+	if options.Problematic {
+		workloads = filterProblematicWorkloads(workloads)
+	}
+	sortWorkloads(workloads, options.SortWorkloads)
+	return workloads
+}
+
+// unhealthyWorkloads returns the workloads whose health level is not Healthy, or that are Healthy only
+// because their pods are still starting and nothing has run yet
+func unhealthyWorkloads(workloads []types.WorkloadInfo) []types.WorkloadInfo {
+	var unhealthy []types.WorkloadInfo
+	for _, workload := range workloads {
+		if workload.Health.Level != string(types.HealthLevelHealthy) || analyzer.IsStarting(workload) {
+			unhealthy = append(unhealthy, workload)
+		}
+	}
+	return unhealthy
+}
+
+// describeWaiting summarizes what --wait-healthy is still waiting on
+func describeWaiting(workloads, waiting []types.WorkloadInfo) string {
+	if len(workloads) == 0 {
+		return "pods to appear"
+	}
+	var parts []string
+	for _, workload := range waiting {
+		parts = append(parts, fmt.Sprintf("%s (%s: %s)", workload.Name, workload.Health.Level, workload.Health.Reason))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// podHealthLevels maps each pod (namespace/name) to its health level
+func podHealthLevels(workloads []types.WorkloadInfo) map[string]string {
+	levels := make(map[string]string)
+	for _, workload := range workloads {
+		for _, pod := range workload.Pods {
+			levels[pod.Namespace+"/"+pod.Name] = pod.Health.Level
+		}
+	}
+	return levels
+}
+
+// podHealthReasons maps each pod (namespace/name) to the reason for its health level
+func podHealthReasons(workloads []types.WorkloadInfo) map[string]string {
+	reasons := make(map[string]string)
+	for _, workload := range workloads {
+		for _, pod := range workload.Pods {
+			reasons[pod.Namespace+"/"+pod.Name] = pod.Health.Reason
+		}
+	}
+	return reasons
+}
+
+// diffPodHealth returns the pods whose health level differs between two collections, sorted by pod
+func diffPodHealth(previous, current, reasons map[string]string) []healthTransition {
+	var transitions []healthTransition
+	for pod, level := range current {
+		if previous[pod] != level {
+			transitions = append(transitions, healthTransition{Pod: pod, From: previous[pod], To: level, Reason: reasons[pod]})
+		}
+	}
+	for pod, level := range previous {
+		if _, exists := current[pod]; !exists {
+			transitions = append(transitions, healthTransition{Pod: pod, From: level})
+		}
+	}
+
+	sort.Slice(transitions, func(i, j int) bool {
+		return transitions[i].Pod < transitions[j].Pod
+	})
+	return transitions
+}
+
+// formatTransition formats a health transition as a single log line
+func formatTransition(transition healthTransition) string {
+	switch {
+	case transition.From == "":
+		return fmt.Sprintf("%s  new → %s (%s)", transition.Pod, transition.To, transition.Reason)
+	case transition.To == "":
+		return fmt.Sprintf("%s  %s → gone", transition.Pod, transition.From)
+	default:
+		return fmt.Sprintf("%s  %s → %s (%s)", transition.Pod, transition.From, transition.To, transition.Reason)
+	}
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/nareshku/kubectl-container-status/pkg/analyzer"
+	"github.com/nareshku/kubectl-container-status/pkg/collector"
+	"github.com/nareshku/kubectl-container-status/pkg/output"
+	"github.com/nareshku/kubectl-container-status/pkg/resolver"
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestDiffPodHealth(t *testing.T) {
+	previous := map[string]string{
+		"default/web-1": "Healthy",
+		"default/web-2": "Healthy",
+		"default/web-3": "Degraded",
+	}
+	current := map[string]string{
+		"default/web-1": "Healthy",
+		"default/web-2": "Critical",
+		"default/web-4": "Healthy",
+	}
+	reasons := map[string]string{
+		"default/web-2": "container app in CrashLoopBackOff",
+		"default/web-4": "all containers running",
+	}
+
+	transitions := diffPodHealth(previous, current, reasons)
+	expected := []healthTransition{
+		{Pod: "default/web-2", From: "Healthy", To: "Critical", Reason: "container app in CrashLoopBackOff"},
+		{Pod: "default/web-3", From: "Degraded"},
+		{Pod: "default/web-4", To: "Healthy", Reason: "all containers running"},
+	}
+
+	if len(transitions) != len(expected) {
+		t.Fatalf("expected %d transitions, got %d: %+v", len(expected), len(transitions), transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("transition %d: expected %+v, got %+v", i, expected[i], transitions[i])
+		}
+	}
+
+	if len(diffPodHealth(current, current, reasons)) != 0 {
+		t.Error("expected no transitions when health is unchanged")
+	}
+}
+
+func TestFormatTransition(t *testing.T) {
+	tests := []struct {
+		transition healthTransition
+		expected   string
+	}{
+		{healthTransition{Pod: "default/web-1", From: "Healthy", To: "Degraded", Reason: "high restarts"}, "default/web-1  Healthy → Degraded (high restarts)"},
+		{healthTransition{Pod: "default/web-2", To: "Critical", Reason: "image pull failed"}, "default/web-2  new → Critical (image pull failed)"},
+		{healthTransition{Pod: "default/web-3", From: "Healthy"}, "default/web-3  Healthy → gone"},
+	}
+
+	for _, tt := range tests {
+		if got := formatTransition(tt.transition); got != tt.expected {
+			t.Errorf("formatTransition() = %q, want %q", got, tt.expected)
+		}
+	}
+}
+
+func TestDescribeWaiting(t *testing.T) {
+	workloads := []types.WorkloadInfo{
+		{Name: "api", Health: types.HealthStatus{Level: "Healthy"}},
+		{Name: "worker", Health: types.HealthStatus{Level: "Critical", Reason: "1 pod has critical issues"}},
+	}
+
+	waiting := unhealthyWorkloads(workloads)
+	if len(waiting) != 1 || waiting[0].Name != "worker" {
+		t.Fatalf("expected only worker to be unhealthy, got %+v", waiting)
+	}
+	if got := describeWaiting(workloads, waiting); got != "worker (Critical: 1 pod has critical issues)" {
+		t.Errorf("unexpected waiting description: %q", got)
+	}
+	if got := describeWaiting(nil, nil); got != "pods to appear" {
+		t.Errorf("unexpected waiting description without workloads: %q", got)
+	}
+}
+
+func TestWatchHealthTransitionsWritesToOut(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}, CreationTimestamp: metav1.Now()},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:1"}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "app", RestartCount: 4, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+		}},
+	})
+	c := collector.New(clientset, metricsfake.NewSimpleClientset())
+	c.SetWarningOutput(io.Discard)
+	options := &types.Options{Namespace: "default", Selector: "app=web", UTC: true, WatchInterval: time.Hour}
+
+	// The first round reports the pod that is already unhealthy, then the watch stops
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	var out bytes.Buffer
+	if err := watchHealthTransitions(ctx, resolver.New(clientset), c, analyzer.New(), nil, &out, options); err != nil {
+		t.Fatalf("watchHealthTransitions() failed: %v", err)
+	}
+	for _, expected := range []string{"Watching 1 pods for health changes", "default/web-1  new → Critical"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q in the output, got:\n%s", expected, out.String())
+		}
+	}
+}
+
+func TestWaitHealthy(t *testing.T) {
+	pod := func(waitingReason string) *corev1.Pod {
+		status := corev1.ContainerStatus{Name: "app", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}
+		phase := corev1.PodRunning
+		if waitingReason != "" {
+			status = corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason}}}
+			if waitingReason == "ContainerCreating" {
+				phase = corev1.PodPending
+			}
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}, CreationTimestamp: metav1.Now()},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:1"}}},
+			Status:     corev1.PodStatus{Phase: phase, ContainerStatuses: []corev1.ContainerStatus{status}},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		waitingReason string
+		exitCode      int // 0 when waitHealthy should return nil
+	}{
+		{"healthy", "", 0},
+		{"times out", "CrashLoopBackOff", 1},
+		// A fresh pod is Healthy while its containers are created, but nothing has run yet
+		{"still starting", "ContainerCreating", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(pod(tt.waitingReason))
+			c := collector.New(clientset, metricsfake.NewSimpleClientset())
+			c.SetWarningOutput(io.Discard)
+
+			options := &types.Options{
+				Namespace:     "default",
+				Selector:      "app=web",
+				OutputFormat:  "json",
+				WaitHealthy:   true,
+				WatchInterval: 10 * time.Millisecond,
+				WaitTimeout:   100 * time.Millisecond,
+			}
+
+			err := waitHealthy(context.Background(), resolver.New(clientset), c, analyzer.New(), nil, output.NewWithWriter(options, io.Discard), options)
+
+			var unhealthy *unhealthyError
+			switch {
+			case tt.exitCode == 0 && err != nil:
+				t.Errorf("expected a healthy workload to return nil, got %v", err)
+			case tt.exitCode != 0 && !errors.As(err, &unhealthy):
+				t.Errorf("expected an unhealthyError, got %v", err)
+			case tt.exitCode != 0 && unhealthy.exitCode != tt.exitCode:
+				t.Errorf("expected exit code %d, got %d", tt.exitCode, unhealthy.exitCode)
+			}
+		})
+	}
+}
+
+func TestWaitHealthyFailsFastOnMissingWorkload(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	c := collector.New(clientset, metricsfake.NewSimpleClientset())
+	c.SetWarningOutput(io.Discard)
+	options := &types.Options{
+		Namespace:     "default",
+		ResourceType:  "deployment",
+		ResourceName:  "missing",
+		OutputFormat:  "json",
+		WaitHealthy:   true,
+		WatchInterval: 10 * time.Millisecond,
+		WaitTimeout:   time.Minute,
+	}
+
+	start := time.Now()
+	err := waitHealthy(context.Background(), resolver.New(clientset), c, analyzer.New(), nil, output.NewWithWriter(options, io.Discard), options)
+	var unhealthy *unhealthyError
+	if err == nil || errors.As(err, &unhealthy) {
+		t.Errorf("expected the lookup error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected to fail without waiting for the timeout, took %s", elapsed)
+	}
+}
+
+func TestWaitHealthyPrintsProblematicPods(t *testing.T) {
+	pod := func(name string, status corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}, CreationTimestamp: metav1.Now()},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:1"}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{status}},
+		}
+	}
+	clientset := fake.NewSimpleClientset(
+		pod("web-ok", corev1.ContainerStatus{Name: "app", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}),
+		pod("web-crashing", corev1.ContainerStatus{Name: "app", RestartCount: 4, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}}),
+	)
+	c := collector.New(clientset, metricsfake.NewSimpleClientset())
+	c.SetWarningOutput(io.Discard)
+	options := &types.Options{
+		Namespace:     "default",
+		Selector:      "app=web",
+		OutputFormat:  "json",
+		Problematic:   true,
+		WaitHealthy:   true,
+		WatchInterval: 10 * time.Millisecond,
+		WaitTimeout:   50 * time.Millisecond,
+	}
+
+	var out bytes.Buffer
+	err := waitHealthy(context.Background(), resolver.New(clientset), c, analyzer.New(), nil, output.NewWithWriter(options, &out), options)
+	var unhealthy *unhealthyError
+	if !errors.As(err, &unhealthy) {
+		t.Fatalf("expected an unhealthyError, got %v", err)
+	}
+	if !strings.Contains(out.String(), "web-crashing") || strings.Contains(out.String(), "web-ok") {
+		t.Errorf("expected only the problematic pod in the output, got %s", out.String())
+	}
+}
@@ -3,11 +3,22 @@ package collector
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -21,6 +32,147 @@ import (
 type Collector struct {
 	clientset     kubernetes.Interface
 	metricsClient metricsv1beta1.Interface
+
+	metricsSnapshot *MetricsSnapshot            // Offline usage data that replaces metrics-server when set
+	podDump         *PodDump                    // Offline pods and events that replace the API when set
+	sampled         map[string]*sampledWorkload // Pods and metrics samples read by SampleWorkloads, by workloadKey
+
+	metricsUnavailable atomic.Bool // Set once the metrics API turned out to be missing
+	metricsForbidden   atomic.Bool // Set once reading metrics was denied by RBAC
+	eventsForbidden    atomic.Bool // Set once listing events was denied by RBAC
+
+	warningOutput io.Writer // Destination for non-fatal warnings, stderr by default
+
+	// Warnings held back for the end of the output after DeferWarnings; pods are collected
+	// concurrently, so every access goes through warningsMu
+	warningsMu    sync.Mutex
+	deferWarnings bool
+	warnings      []string
+
+	nodeCacheMu sync.Mutex
+	nodeCache   map[string]*nodeLookup // Nodes fetched for --node-context and --node-health, by name
+
+	phaseTimer func(phase string) func() // Times collection phases for --profile, nil when not profiling
+}
+
+// warnf reports a non-fatal collection problem. Warnings go to stderr so that structured output
+// (JSON/YAML) on stdout stays parseable, or are held for TakeWarnings after DeferWarnings.
+func (c *Collector) warnf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+
+	c.warningsMu.Lock()
+	defer c.warningsMu.Unlock()
+	if !c.deferWarnings {
+		fmt.Fprintf(c.warningOutput, "Warning: %s\n", message)
+		return
+	}
+	for _, existing := range c.warnings {
+		if existing == message {
+			return
+		}
+	}
+	c.warnings = append(c.warnings, message)
+}
+
+// Warnf reports a non-fatal problem outside of collection itself, so it is printed or held back
+// together with the collector's own warnings
+func (c *Collector) Warnf(format string, args ...interface{}) {
+	c.warnf(format, args...)
+}
+
+// DeferWarnings holds warnings back instead of printing them as they happen, so they can be
+// reported together at the end of the output
+func (c *Collector) DeferWarnings() {
+	c.warningsMu.Lock()
+	defer c.warningsMu.Unlock()
+	c.deferWarnings = true
+}
+
+// TakeWarnings returns the warnings held back since the last call and forgets them
+func (c *Collector) TakeWarnings() []string {
+	c.warningsMu.Lock()
+	defer c.warningsMu.Unlock()
+	warnings := c.warnings
+	c.warnings = nil
+	return warnings
+}
+
+// UsePhaseTimer makes the collector time its pod listing, metrics, events and per-pod phases: start is
+// called when a phase begins, and the function it returns when the phase ends
+func (c *Collector) UsePhaseTimer(start func(phase string) func()) {
+	c.phaseTimer = start
+}
+
+// track starts timing a phase with the phase timer, if there is one, and returns the function that stops it
+func (c *Collector) track(phase string) func() {
+	if c.phaseTimer == nil {
+		return func() {}
+	}
+	return c.phaseTimer(phase)
+}
+
+// SetWarningOutput redirects non-fatal warnings, which go to stderr by default
+func (c *Collector) SetWarningOutput(w io.Writer) {
+	c.warningOutput = w
+}
+
+// errMetricsAPIUnavailable is returned when there is no metrics API to query
+var errMetricsAPIUnavailable = errors.New("metrics API not available")
+
+// MetricsUnavailable reports whether resource usage could not be collected because the cluster has
+// no metrics API (metrics-server is not installed or not ready), the user may not read it, or the
+// pods come from a dump and no metrics snapshot was given
+func (c *Collector) MetricsUnavailable() bool {
+	if c.podDump != nil && c.metricsSnapshot == nil {
+		return true
+	}
+	return c.metricsUnavailable.Load() || c.metricsForbidden.Load()
+}
+
+// MetricsForbidden reports whether reading pod metrics was denied by RBAC
+func (c *Collector) MetricsForbidden() bool {
+	return c.metricsForbidden.Load()
+}
+
+// EventsForbidden reports whether listing events was denied by RBAC
+func (c *Collector) EventsForbidden() bool {
+	return c.eventsForbidden.Load()
+}
+
+// recordMetricsError remembers that the metrics API is missing or forbidden and returns true if err
+// means either, so callers report it once instead of warning for every pod
+func (c *Collector) recordMetricsError(err error) bool {
+	if apierrors.IsForbidden(err) {
+		c.metricsForbidden.Store(true)
+		return true
+	}
+	if !isMetricsAPIUnavailable(err) {
+		return false
+	}
+	c.metricsUnavailable.Store(true)
+	return true
+}
+
+// recordEventsError remembers that listing events is forbidden and returns true if err means that,
+// so the events section can say so instead of printing a warning for every pod
+func (c *Collector) recordEventsError(err error) bool {
+	if !apierrors.IsForbidden(err) {
+		return false
+	}
+	c.eventsForbidden.Store(true)
+	return true
+}
+
+// isMetricsAPIUnavailable checks whether err means the metrics.k8s.io API itself is missing or down,
+// as opposed to metrics for a single pod not being available yet
+func isMetricsAPIUnavailable(err error) bool {
+	if errors.Is(err, errMetricsAPIUnavailable) || apierrors.IsServiceUnavailable(err) {
+		return true
+	}
+	// A missing API group answers with a generic NotFound, unlike a missing PodMetrics object
+	message := err.Error()
+	return strings.Contains(message, "the server could not find the requested resource") ||
+		strings.Contains(message, "ServerResourcesForGroupVersion")
 }
 
 // New creates a new collector instance
@@ -28,69 +180,52 @@ func New(clientset kubernetes.Interface, metricsClient metricsv1beta1.Interface)
 	return &Collector{
 		clientset:     clientset,
 		metricsClient: metricsClient,
+		warningOutput: os.Stderr,
 	}
 }
 
 // CollectPods collects pod information for a workload
 func (c *Collector) CollectPods(ctx context.Context, workload types.WorkloadInfo, options *types.Options) ([]types.PodInfo, error) {
-	var pods []corev1.Pod
+	// Pods and metrics read by SampleWorkloads are reused, so they line up with the samples
+	sampled := c.sampled[workloadKey(workload)]
 
-	if workload.Kind == "Pod" {
-		// Single pod
-		pod, err := c.clientset.CoreV1().Pods(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
-		if err != nil {
-			return nil, fmt.Errorf("failed to get pod: %w", err)
-		}
-		pods = append(pods, *pod)
+	var pods []corev1.Pod
+	stopListing := c.track("pod listing")
+	if sampled != nil {
+		pods = sampled.pods
 	} else {
-		// Workload with selector
-		selector := labels.SelectorFromSet(workload.Selector)
-		podList, err := c.clientset.CoreV1().Pods(workload.Namespace).List(ctx, metav1.ListOptions{
-			LabelSelector: selector.String(),
-		})
+		listed, err := c.listPods(ctx, workload, options)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list pods: %w", err)
+			return nil, err
 		}
-		pods = podList.Items
+		pods = listed
 	}
+	stopListing()
 
 	// Collect bulk metrics and events for better performance
 	var bulkMetrics map[string]*types.PodMetrics
 	var bulkEvents map[string][]types.EventInfo
 	var err error
 
-	// Decide how to collect metrics:
-	// - If we're dealing with a single pod, fetch only that pod's metrics.
-	// - If multiple pods and resource usage is requested, fetch bulk metrics using the workload's selector.
-	if len(pods) == 1 {
-		if c.metricsClient != nil {
-			metrics, mErr := c.collectPodMetrics(ctx, &pods[0])
-			if mErr != nil {
-				fmt.Printf("Warning: Failed to collect metrics for pod %s: %v\n", pods[0].Name, mErr)
-			} else if metrics != nil {
-				bulkMetrics = make(map[string]*types.PodMetrics)
-				bulkMetrics[pods[0].Name] = metrics
-			}
-		}
-	} else if options.ShowResourceUsage {
-		var labelSelector string
-		if len(workload.Selector) > 0 {
-			labelSelector = labels.SelectorFromSet(workload.Selector).String()
-		}
-		bulkMetrics, err = c.collectBulkMetrics(ctx, workload.Namespace, pods, labelSelector)
-		if err != nil {
-			fmt.Printf("Warning: Failed to collect bulk metrics: %v\n", err)
-			bulkMetrics = make(map[string]*types.PodMetrics)
-		}
+	if sampled != nil {
+		bulkMetrics = sampled.metrics
+	} else {
+		stopMetrics := c.track("metrics")
+		bulkMetrics = c.readMetrics(ctx, workload, pods, options)
+		stopMetrics()
 	}
 
 	// Collect bulk events when needed
-	if len(pods) > 0 {
-		bulkEvents, err = c.collectBulkEvents(ctx, workload.Namespace, pods)
+	if len(pods) > 0 && options.ShowEvents {
+		stopEvents := c.track("events")
+		bulkEvents, err = c.collectBulkEvents(ctx, workload.Namespace, pods, options.EventsWarningsOnly)
 		if err != nil {
-			fmt.Printf("Warning: Failed to collect bulk events: %v\n", err)
+			if !c.recordEventsError(err) {
+				c.warnf("Failed to collect bulk events: %v", err)
+			}
 			bulkEvents = make(map[string][]types.EventInfo)
 		}
+		stopEvents()
 	}
 
 	// Process pods in parallel for better performance
@@ -101,6 +236,7 @@ func (c *Collector) CollectPods(ctx context.Context, workload types.WorkloadInfo
 	}
 
 	results := make(chan result, len(pods))
+	defer c.track("pod details")()
 
 	// Process each pod in a separate goroutine
 	for i, pod := range pods {
@@ -123,92 +259,47 @@ func (c *Collector) CollectPods(ctx context.Context, workload types.WorkloadInfo
 
 	// Collect results in order
 	podInfos := make([]*types.PodInfo, len(pods))
+	podErrs := make([]error, len(pods))
 	for i := 0; i < len(pods); i++ {
 		res := <-results
-		if res.err != nil {
-			return nil, fmt.Errorf("failed to collect pod info for pod %d: %w", res.index, res.err)
-		}
-		podInfos[res.index] = res.pod
+		podInfos[res.index], podErrs[res.index] = res.pod, res.err
 	}
 
-	// Convert to slice of values
-	var finalPods []types.PodInfo
-	for _, podInfo := range podInfos {
-		if podInfo != nil {
-			finalPods = append(finalPods, *podInfo)
-		}
-	}
-
-	return finalPods, nil
+	return c.keepCollectedPods(pods, podInfos, podErrs)
 }
 
-// collectPodInfo collects detailed information for a single pod
-func (c *Collector) collectPodInfo(ctx context.Context, pod *corev1.Pod, options *types.Options) (*types.PodInfo, error) {
-	// Determine pod status - check for terminating state first
-	status := string(pod.Status.Phase)
-	if pod.DeletionTimestamp != nil {
-		status = "Terminating"
-	}
-
-	podInfo := &types.PodInfo{
-		Name:           pod.Name,
-		Namespace:      pod.Namespace,
-		NodeName:       pod.Spec.NodeName,
-		ServiceAccount: pod.Spec.ServiceAccountName,
-		Age:            time.Since(pod.CreationTimestamp.Time),
-		Status:         status,
-		Labels:         pod.Labels,
-		Annotations:    pod.Annotations,
-		Conditions:     c.collectPodConditions(pod),
-		Network:        c.collectNetworkInfo(pod),
-	}
-
-	// Determine if this is a workload view (multiple pods) vs single pod view
-	isWorkloadView := !options.SinglePodView
-
-	// For workload view, only collect detailed data if specifically requested
-	needsMetrics := !isWorkloadView || options.ShowResourceUsage
-	needsDetailedInfo := !isWorkloadView
-
-	// Collect metrics only when needed
-	var podMetrics *types.PodMetrics
-	if needsMetrics && c.metricsClient != nil {
-		metrics, err := c.collectPodMetrics(ctx, pod)
-		if err != nil {
-			// Metrics are optional, continue without them
-			if !isWorkloadView {
-				fmt.Printf("Warning: Failed to collect metrics for pod %s: %v\n", pod.Name, err)
+// keepCollectedPods returns the pods whose details were collected, in listing order. A pod collected only in
+// part, e.g. without its logs, is kept with a warning; a pod that couldn't be collected at all is skipped with
+// one, so one broken pod doesn't hide the rest. Only when every pod failed is it an error.
+func (c *Collector) keepCollectedPods(pods []corev1.Pod, podInfos []*types.PodInfo, podErrs []error) ([]types.PodInfo, error) {
+	var collected []types.PodInfo
+	var firstErr error
+	for i, err := range podErrs {
+		if podInfos[i] == nil {
+			if err == nil {
+				continue
+			}
+			c.warnf("Skipping pod %s: %v", pods[i].Name, err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to collect pod %s: %w", pods[i].Name, err)
 			}
+			continue
 		}
-		podMetrics = metrics
-		podInfo.Metrics = metrics
-	}
-
-	// Collect container information - pass pod metrics for resource calculation
-	for _, container := range pod.Spec.InitContainers {
-		containerInfo := c.collectContainerInfo(ctx, container, pod, types.ContainerTypeInit, options, podMetrics, needsDetailedInfo)
-		podInfo.InitContainers = append(podInfo.InitContainers, containerInfo)
-	}
-
-	for _, container := range pod.Spec.Containers {
-		containerInfo := c.collectContainerInfo(ctx, container, pod, types.ContainerTypeStandard, options, podMetrics, needsDetailedInfo)
-		podInfo.Containers = append(podInfo.Containers, containerInfo)
-	}
-
-	events, err := c.collectPodEvents(ctx, pod)
-	if err != nil {
-		// Events are optional, log warning but continue
-		if !isWorkloadView {
-			fmt.Printf("Warning: Failed to collect events for pod %s: %v\n", pod.Name, err)
+		if err != nil {
+			c.warnf("Pod %s: %v", pods[i].Name, err)
 		}
+		collected = append(collected, *podInfos[i])
 	}
-	podInfo.Events = events
 
-	return podInfo, nil
+	if len(collected) == 0 && firstErr != nil {
+		return nil, fmt.Errorf("none of the %d pods could be collected: %w", len(pods), firstErr)
+	}
+	return collected, nil
 }
 
-// collectContainerInfo collects information for a single container
-func (c *Collector) collectContainerInfo(ctx context.Context, container corev1.Container, pod *corev1.Pod, containerType types.ContainerType, options *types.Options, podMetrics *types.PodMetrics, needsDetailedInfo bool) types.ContainerInfo {
+// collectContainerInfo collects information for a single container. The error reports what couldn't be
+// read, such as its logs; the container information is complete otherwise.
+func (c *Collector) collectContainerInfo(ctx context.Context, container corev1.Container, pod *corev1.Pod, containerType types.ContainerType, options *types.Options, podMetrics *types.PodMetrics, needsDetailedInfo bool) (types.ContainerInfo, error) {
 	containerInfo := types.ContainerInfo{
 		Name:    container.Name,
 		Type:    string(containerType),
@@ -226,6 +317,13 @@ func (c *Collector) collectContainerInfo(ctx context.Context, container corev1.C
 				break
 			}
 		}
+	} else if containerType == types.ContainerTypeEphemeral {
+		for i, status := range pod.Status.EphemeralContainerStatuses {
+			if status.Name == container.Name {
+				containerStatus = &pod.Status.EphemeralContainerStatuses[i]
+				break
+			}
+		}
 	} else {
 		for i, status := range pod.Status.ContainerStatuses {
 			if status.Name == container.Name {
@@ -253,6 +351,7 @@ func (c *Collector) collectContainerInfo(ctx context.Context, container corev1.C
 			if containerInfo.Status == "" {
 				containerInfo.Status = string(types.ContainerStatusWaiting)
 			}
+			containerInfo.StatusMessage = containerStatus.State.Waiting.Message
 		} else if containerStatus.State.Terminated != nil {
 			if containerType == types.ContainerTypeInit && containerStatus.State.Terminated.ExitCode == 0 {
 				containerInfo.Status = string(types.ContainerStatusCompleted)
@@ -260,6 +359,7 @@ func (c *Collector) collectContainerInfo(ctx context.Context, container corev1.C
 				containerInfo.Status = string(types.ContainerStatusTerminated)
 			}
 			containerInfo.ExitCode = &containerStatus.State.Terminated.ExitCode
+			containerInfo.Signal = containerStatus.State.Terminated.Signal
 			containerInfo.StartedAt = &containerStatus.State.Terminated.StartedAt.Time
 			containerInfo.FinishedAt = &containerStatus.State.Terminated.FinishedAt.Time
 			containerInfo.TerminationReason = containerStatus.State.Terminated.Reason
@@ -272,11 +372,21 @@ func (c *Collector) collectContainerInfo(ctx context.Context, container corev1.C
 
 		// Last state information and exit code from previous termination
 		if containerStatus.LastTerminationState.Terminated != nil {
+			lastTerminated := containerStatus.LastTerminationState.Terminated
 			containerInfo.LastState = "Terminated"
-			containerInfo.LastStateReason = containerStatus.LastTerminationState.Terminated.Reason
+			containerInfo.LastStateReason = lastTerminated.Reason
+			containerInfo.LastExitCode = &lastTerminated.ExitCode
+			containerInfo.LastSignal = lastTerminated.Signal
+			if !lastTerminated.StartedAt.IsZero() {
+				containerInfo.LastStartedAt = &lastTerminated.StartedAt.Time
+			}
+			if !lastTerminated.FinishedAt.IsZero() {
+				containerInfo.LastFinishedAt = &lastTerminated.FinishedAt.Time
+			}
 			// Get exit code from last termination if current state doesn't have one
 			if containerInfo.ExitCode == nil {
 				containerInfo.ExitCode = &containerStatus.LastTerminationState.Terminated.ExitCode
+				containerInfo.Signal = containerStatus.LastTerminationState.Terminated.Signal
 			}
 		} else if containerStatus.LastTerminationState.Waiting != nil {
 			containerInfo.LastState = "Waiting"
@@ -301,10 +411,13 @@ func (c *Collector) collectContainerInfo(ctx context.Context, container corev1.C
 	}
 
 	// Collect environment variables
-	if needsDetailedInfo {
+	if needsDetailedInfo && options.ShowEnv {
 		containerInfo.Environment = c.collectEnvironmentInfo(container, pod)
 	}
 
+	containerInfo.Security = collectSecurityInfo(container, pod)
+	containerInfo.ImageRef = parseImageRef(container.Image)
+
 	// Collect exposed ports from container spec
 	if len(container.Ports) > 0 {
 		for _, p := range container.Ports {
@@ -319,19 +432,14 @@ func (c *Collector) collectContainerInfo(ctx context.Context, container corev1.C
 
 	// Collect logs if requested (only for running containers to avoid errors)
 	if options.ShowLogs && containerInfo.Status == string(types.ContainerStatusRunning) {
-		logs, err := c.collectContainerLogs(ctx, pod, container.Name)
+		logs, err := c.collectContainerLogs(ctx, pod, container.Name, options.LogPattern)
 		if err != nil {
-			// Logs are optional, continue without them but don't spam warnings
-			// Only log error for single pod view
-			if options.SinglePodView {
-				fmt.Printf("Warning: Failed to collect logs for container %s: %v\n", container.Name, err)
-			}
-		} else {
-			containerInfo.Logs = logs
+			return containerInfo, fmt.Errorf("failed to collect logs for container %s: %w", container.Name, err)
 		}
+		containerInfo.Logs = logs
 	}
 
-	return containerInfo
+	return containerInfo, nil
 }
 
 // collectResourceInfo collects resource requests, limits, and usage
@@ -368,19 +476,37 @@ func (c *Collector) collectResourceInfo(container corev1.Container, containerNam
 		if containerMetrics != nil {
 			// Set CPU usage and calculate percentage
 			if containerMetrics.CPUUsage != "" {
+				resourceInfo.HasCPUUsage = true
 				resourceInfo.CPUUsage = c.formatCPUUsage(containerMetrics.CPUUsage)
+				if quantity, err := resource.ParseQuantity(containerMetrics.CPUUsage); err == nil {
+					resourceInfo.CPUUsageMilli = quantity.MilliValue()
+				}
 				if resourceInfo.CPULimit != "" {
 					resourceInfo.CPUPercentage = c.calculateCPUPercentage(containerMetrics.CPUUsage, resourceInfo.CPULimit)
 				}
+				if resourceInfo.CPURequest != "" {
+					resourceInfo.CPURequestPercentage = c.calculateCPUPercentage(containerMetrics.CPUUsage, resourceInfo.CPURequest)
+				}
 			}
 
 			// Set memory usage and calculate percentage
 			if containerMetrics.MemoryUsage != "" {
+				resourceInfo.HasMemUsage = true
 				resourceInfo.MemUsage = c.formatMemoryUsage(containerMetrics.MemoryUsage)
+				if quantity, err := resource.ParseQuantity(containerMetrics.MemoryUsage); err == nil {
+					resourceInfo.MemUsageBytes = quantity.Value()
+				}
 				if resourceInfo.MemLimit != "" {
 					resourceInfo.MemPercentage = c.calculateMemoryPercentage(containerMetrics.MemoryUsage, resourceInfo.MemLimit)
 				}
+				if resourceInfo.MemRequest != "" {
+					resourceInfo.MemRequestPercentage = c.calculateMemoryPercentage(containerMetrics.MemoryUsage, resourceInfo.MemRequest)
+				}
+			}
+			if containerMetrics.MemoryRSS != "" {
+				resourceInfo.MemRSS = c.formatMemoryUsage(containerMetrics.MemoryRSS)
 			}
+			resourceInfo.Samples = containerMetrics.Samples
 		}
 	}
 
@@ -395,8 +521,8 @@ func (c *Collector) collectProbeInfo(container corev1.Container, status *corev1.
 	if container.LivenessProbe != nil {
 		probeInfo.Liveness = c.parseProbeDetails(container.LivenessProbe)
 		probeInfo.Liveness.Configured = true
-		// In a real implementation, we'd check the actual probe status
-		probeInfo.Liveness.Passing = true // Default assumption
+		// The API doesn't report liveness results; failures are filled in from events by recordLivenessFailures
+		probeInfo.Liveness.Passing = true
 	}
 
 	// Readiness probe
@@ -412,12 +538,54 @@ func (c *Collector) collectProbeInfo(container corev1.Container, status *corev1.
 	if container.StartupProbe != nil {
 		probeInfo.Startup = c.parseProbeDetails(container.StartupProbe)
 		probeInfo.Startup.Configured = true
-		probeInfo.Startup.Passing = true // Default assumption
+		probeInfo.Startup.Deadline = startupProbeDeadline(container.StartupProbe)
+		// The kubelet marks the container as started once its startup probe succeeds
+		if status != nil {
+			probeInfo.Startup.Passing = status.Started != nil && *status.Started
+		}
 	}
 
 	return probeInfo
 }
 
+// recordLivenessFailures fills in each container's liveness probe failures from the pod's Unhealthy events.
+// The API doesn't report liveness results, and a container whose probe keeps failing is restarted rather
+// than shown as failing, so the events are the only trace of them.
+func recordLivenessFailures(podInfo *types.PodInfo) {
+	for i := range podInfo.Containers {
+		liveness := &podInfo.Containers[i].Probes.Liveness
+		if !liveness.Configured {
+			continue
+		}
+		var lastSeen time.Time
+		for _, event := range podInfo.Events {
+			if event.Reason != "Unhealthy" || event.Container != podInfo.Containers[i].Name ||
+				!strings.HasPrefix(event.Message, "Liveness probe failed") {
+				continue
+			}
+			liveness.FailureCount += event.Count
+			if event.Time.After(lastSeen) {
+				lastSeen = event.Time
+				liveness.LastError = event.Message
+			}
+		}
+	}
+}
+
+// startupProbeDeadline returns how long a startup probe allows the container to start before the
+// kubelet kills it: the initial delay plus one period for each allowed failure
+func startupProbeDeadline(probe *corev1.Probe) time.Duration {
+	period := probe.PeriodSeconds
+	if period <= 0 {
+		period = 10
+	}
+	failureThreshold := probe.FailureThreshold
+	if failureThreshold <= 0 {
+		failureThreshold = 3
+	}
+	return time.Duration(probe.InitialDelaySeconds+period*failureThreshold) * time.Second
+}
+
 // parseProbeDetails parses probe configuration details
 func (c *Collector) parseProbeDetails(probe *corev1.Probe) types.ProbeDetails {
 	details := types.ProbeDetails{}
@@ -426,16 +594,43 @@ func (c *Collector) parseProbeDetails(probe *corev1.Probe) types.ProbeDetails {
 		details.Type = "HTTP"
 		details.Path = probe.HTTPGet.Path
 		details.Port = probe.HTTPGet.Port.String()
+		details.Scheme = string(probe.HTTPGet.Scheme)
+		if details.Scheme == "" {
+			details.Scheme = string(corev1.URISchemeHTTP)
+		}
+		details.Host = probe.HTTPGet.Host
+		for _, header := range probe.HTTPGet.HTTPHeaders {
+			value := header.Value
+			if c.isSensitiveHeader(header.Name) {
+				value = "***"
+			}
+			details.Headers = append(details.Headers, fmt.Sprintf("%s: %s", header.Name, value))
+		}
 	} else if probe.TCPSocket != nil {
 		details.Type = "TCP"
 		details.Port = probe.TCPSocket.Port.String()
 	} else if probe.Exec != nil {
 		details.Type = "Exec"
+	} else if probe.GRPC != nil {
+		details.Type = "gRPC"
+		details.Port = fmt.Sprintf("%d", probe.GRPC.Port)
+		if probe.GRPC.Service != nil {
+			details.Service = *probe.GRPC.Service
+		}
 	}
 
 	return details
 }
 
+// isSensitiveHeader checks if a probe header carries credentials, like Authorization or a token header
+func (c *Collector) isSensitiveHeader(name string) bool {
+	switch strings.ToLower(name) {
+	case "authorization", "proxy-authorization", "cookie":
+		return true
+	}
+	return c.isSensitiveEnvVar(name)
+}
+
 // collectVolumeInfo collects volume mount information
 func (c *Collector) collectVolumeInfo(container corev1.Container, pod *corev1.Pod) []types.VolumeInfo {
 	var volumes []types.VolumeInfo
@@ -444,6 +639,11 @@ func (c *Collector) collectVolumeInfo(container corev1.Container, pod *corev1.Po
 		volumeInfo := types.VolumeInfo{
 			Name:      mount.Name,
 			MountPath: mount.MountPath,
+			ReadOnly:  mount.ReadOnly,
+			SubPath:   mount.SubPath,
+		}
+		if mount.SubPathExpr != "" {
+			volumeInfo.SubPath = mount.SubPathExpr
 		}
 
 		// Find the volume in pod spec to get more details
@@ -461,6 +661,24 @@ func (c *Collector) collectVolumeInfo(container corev1.Container, pod *corev1.Po
 				} else if volume.EmptyDir != nil {
 					volumeInfo.VolumeType = "EmptyDir"
 					volumeInfo.Details = "emptyDir"
+				} else if volume.CSI != nil {
+					volumeInfo.VolumeType = "CSI"
+					volumeInfo.Details = fmt.Sprintf("csi/%s", volume.CSI.Driver)
+				} else if volume.Projected != nil {
+					volumeInfo.VolumeType = "Projected"
+					volumeInfo.Details = c.describeProjectedSources(volume.Projected.Sources)
+				} else if volume.HostPath != nil {
+					volumeInfo.VolumeType = "HostPath"
+					volumeInfo.Details = fmt.Sprintf("hostPath:%s", volume.HostPath.Path)
+					if volume.HostPath.Type != nil && *volume.HostPath.Type != "" {
+						volumeInfo.Details += fmt.Sprintf(" (%s)", *volume.HostPath.Type)
+					}
+				} else if volume.DownwardAPI != nil {
+					volumeInfo.VolumeType = "DownwardAPI"
+					volumeInfo.Details = fmt.Sprintf("downwardAPI (%d items)", len(volume.DownwardAPI.Items))
+				} else if volume.NFS != nil {
+					volumeInfo.VolumeType = "NFS"
+					volumeInfo.Details = fmt.Sprintf("nfs/%s:%s", volume.NFS.Server, volume.NFS.Path)
 				} else {
 					volumeInfo.VolumeType = "Other"
 					volumeInfo.Details = "unknown"
@@ -475,6 +693,29 @@ func (c *Collector) collectVolumeInfo(container corev1.Container, pod *corev1.Po
 	return volumes
 }
 
+// describeProjectedSources summarizes the sources of a projected volume
+func (c *Collector) describeProjectedSources(sources []corev1.VolumeProjection) string {
+	var parts []string
+	for _, source := range sources {
+		if source.ServiceAccountToken != nil {
+			parts = append(parts, "serviceAccountToken")
+		} else if source.ConfigMap != nil {
+			parts = append(parts, fmt.Sprintf("configmap/%s", source.ConfigMap.Name))
+		} else if source.Secret != nil {
+			parts = append(parts, fmt.Sprintf("secret/%s", source.Secret.Name))
+		} else if source.DownwardAPI != nil {
+			parts = append(parts, "downwardAPI")
+		} else if source.ClusterTrustBundle != nil {
+			parts = append(parts, "clusterTrustBundle")
+		}
+	}
+
+	if len(parts) == 0 {
+		return "projected"
+	}
+	return fmt.Sprintf("projected (%s)", strings.Join(parts, ", "))
+}
+
 // collectEnvironmentInfo collects environment variable information
 func (c *Collector) collectEnvironmentInfo(container corev1.Container, pod *corev1.Pod) []types.EnvVar {
 	var envVars []types.EnvVar
@@ -514,7 +755,7 @@ func (c *Collector) collectEnvironmentInfo(container corev1.Container, pod *core
 			} else if env.ValueFrom.ConfigMapKeyRef != nil {
 				envVar.Value = fmt.Sprintf("[configMap:%s/%s]", env.ValueFrom.ConfigMapKeyRef.Name, env.ValueFrom.ConfigMapKeyRef.Key)
 			} else if env.ValueFrom.ResourceFieldRef != nil {
-				envVar.Value = fmt.Sprintf("[resource:%s]", env.ValueFrom.ResourceFieldRef.Resource)
+				envVar.Value = resolveResourceFieldRef(env.ValueFrom.ResourceFieldRef, container, pod)
 			} else {
 				envVar.Value = "[valueFrom:unknown]"
 			}
@@ -530,6 +771,50 @@ func (c *Collector) collectEnvironmentInfo(container corev1.Container, pod *core
 	return envVars
 }
 
+// resolveResourceFieldRef resolves a downward API resource reference the way the kubelet does: the
+// container's request or limit divided by the divisor (default 1) and rounded up, so 1Gi with a 1Mi
+// divisor is 1024 and 500m CPU with the default divisor is 1. Unset limits fall back to the node's
+// allocatable capacity, which isn't known here, so they are left as a placeholder.
+func resolveResourceFieldRef(ref *corev1.ResourceFieldSelector, container corev1.Container, pod *corev1.Pod) string {
+	if ref.ContainerName != "" && ref.ContainerName != container.Name {
+		found := false
+		for _, other := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+			if other.Name == ref.ContainerName {
+				container, found = other, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Sprintf("[resource:%s of unknown container %s]", ref.Resource, ref.ContainerName)
+		}
+	}
+
+	kind, name, ok := strings.Cut(ref.Resource, ".")
+	var list corev1.ResourceList
+	switch {
+	case ok && kind == "limits":
+		list = container.Resources.Limits
+	case ok && kind == "requests":
+		list = container.Resources.Requests
+	default:
+		return fmt.Sprintf("[resource:%s]", ref.Resource)
+	}
+
+	quantity, set := list[corev1.ResourceName(name)]
+	if !set && kind == "limits" {
+		return fmt.Sprintf("[resource:%s unset, node allocatable]", ref.Resource)
+	}
+
+	divisor := ref.Divisor
+	if divisor.IsZero() {
+		divisor = resource.MustParse("1")
+	}
+	if corev1.ResourceName(name) == corev1.ResourceCPU {
+		return strconv.FormatInt(int64(math.Ceil(float64(quantity.MilliValue())/float64(divisor.MilliValue()))), 10)
+	}
+	return strconv.FormatInt(int64(math.Ceil(float64(quantity.Value())/float64(divisor.Value()))), 10)
+}
+
 // isSensitiveEnvVar checks if an environment variable should be masked
 func (c *Collector) isSensitiveEnvVar(name string) bool {
 	sensitivePatterns := []string{"PASSWORD", "SECRET", "KEY", "TOKEN", "PASS"}
@@ -544,7 +829,7 @@ func (c *Collector) isSensitiveEnvVar(name string) bool {
 }
 
 // collectPodEvents collects recent events for a pod
-func (c *Collector) collectPodEvents(ctx context.Context, pod *corev1.Pod) ([]types.EventInfo, error) {
+func (c *Collector) collectPodEvents(ctx context.Context, pod *corev1.Pod, warningsOnly bool) ([]types.EventInfo, error) {
 	events, err := c.clientset.CoreV1().Events(pod.Namespace).List(ctx, metav1.ListOptions{
 		FieldSelector: "involvedObject.name=" + pod.Name,
 	})
@@ -556,34 +841,27 @@ func (c *Collector) collectPodEvents(ctx context.Context, pod *corev1.Pod) ([]ty
 
 	// Default: last 5 minutes for automatic event display
 	// With --events flag: last 1 hour for comprehensive view
-	cutoffTime := time.Now().Add(-1 * time.Hour) // Last 1 hour when explicitly requested
+	cutoffTime := c.now().Add(-1 * time.Hour) // Last 1 hour when explicitly requested
 
 	for _, event := range events.Items {
-		// Handle both old and new event formats
-		var eventTime time.Time
-
-		// For newer events, use EventTime or Series.LastObservedTime
-		if !event.EventTime.IsZero() {
-			eventTime = event.EventTime.Time
-			// If there's a series with more recent observation, use that
-			if event.Series != nil && !event.Series.LastObservedTime.IsZero() {
-				eventTime = event.Series.LastObservedTime.Time
-			}
-		} else {
-			// Fallback to older format: use LastTimestamp if available, otherwise FirstTimestamp
-			eventTime = event.FirstTimestamp.Time
-			if !event.LastTimestamp.IsZero() {
-				eventTime = event.LastTimestamp.Time
-			}
+		// Skip routine lifecycle events (Pulled, Created, Started) when only problems are wanted
+		if warningsOnly && event.Type == corev1.EventTypeNormal {
+			continue
 		}
 
+		eventTime := latestEventTime(&event)
+
 		if eventTime.After(cutoffTime) {
+			firstSeen, count := eventOccurrences(&event, eventTime)
 			eventInfo := types.EventInfo{
-				Time:    eventTime,
-				Type:    event.Type,
-				Reason:  event.Reason,
-				Message: event.Message,
-				PodName: pod.Name,
+				Time:      eventTime,
+				FirstSeen: firstSeen,
+				Count:     count,
+				Type:      event.Type,
+				Reason:    event.Reason,
+				Message:   event.Message,
+				PodName:   pod.Name,
+				Container: eventContainer(event.InvolvedObject.FieldPath),
 			}
 			eventInfos = append(eventInfos, eventInfo)
 		}
@@ -592,10 +870,35 @@ func (c *Collector) collectPodEvents(ctx context.Context, pod *corev1.Pod) ([]ty
 	return eventInfos, nil
 }
 
+// eventOccurrences returns when an event was first seen and how many times it occurred, reading
+// Series for events.k8s.io-style events and FirstTimestamp/Count for older ones
+func eventOccurrences(event *corev1.Event, lastSeen time.Time) (time.Time, int32) {
+	firstSeen := event.FirstTimestamp.Time
+	if firstSeen.IsZero() {
+		firstSeen = event.EventTime.Time
+	}
+	if firstSeen.IsZero() {
+		firstSeen = lastSeen
+	}
+
+	count := event.Count
+	if event.Series != nil && event.Series.Count > count {
+		count = event.Series.Count
+	}
+	if count < 1 {
+		count = 1
+	}
+	return firstSeen, count
+}
+
 // collectPodMetrics collects resource usage metrics for a pod
 func (c *Collector) collectPodMetrics(ctx context.Context, pod *corev1.Pod) (*types.PodMetrics, error) {
-	if c.metricsClient == nil {
-		return nil, fmt.Errorf("metrics client not available")
+	if c.metricsSnapshot != nil {
+		return c.snapshotPodMetrics(pod), nil
+	}
+
+	if c.metricsClient == nil || c.metricsUnavailable.Load() {
+		return nil, errMetricsAPIUnavailable
 	}
 
 	podMetrics, err := c.metricsClient.MetricsV1beta1().PodMetricses(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
@@ -626,6 +929,8 @@ func (c *Collector) collectPodMetrics(ctx context.Context, pod *corev1.Pod) (*ty
 	// Set aggregated pod-level usage
 	metrics.CPUUsage = c.formatCPUUsage(totalCPU.String())
 	metrics.MemoryUsage = c.formatMemoryUsage(totalMem.String())
+	metrics.CPUUsageMilli = totalCPU.MilliValue()
+	metrics.MemoryUsageBytes = totalMem.Value()
 
 	return metrics, nil
 }
@@ -745,10 +1050,118 @@ func (c *Collector) formatMemoryUsage(usage string) string {
 	return fmt.Sprintf("%d", bytes)
 }
 
+// readMetrics takes one metrics reading. A single pod's metrics are fetched on their own; for multiple
+// pods, when resource usage is requested, they are fetched in bulk using the workload's selector. Failures
+// are warned about and leave the result nil for a single pod and empty for bulk.
+func (c *Collector) readMetrics(ctx context.Context, workload types.WorkloadInfo, pods []corev1.Pod, options *types.Options) map[string]*types.PodMetrics {
+	if len(pods) == 1 {
+		if c.metricsClient == nil && c.metricsSnapshot == nil {
+			return nil
+		}
+		metrics, err := c.collectPodMetrics(ctx, &pods[0])
+		if err != nil {
+			if !c.recordMetricsError(err) {
+				c.warnf("Failed to collect metrics for pod %s: %v", pods[0].Name, err)
+			}
+			return nil
+		}
+		if metrics == nil {
+			return nil
+		}
+		return map[string]*types.PodMetrics{pods[0].Name: metrics}
+	}
+	if !options.ShowResourceUsage {
+		return nil
+	}
+
+	bulkMetrics, err := c.collectBulkMetrics(ctx, workload.Namespace, pods, workloadSelector(workload))
+	if err != nil {
+		if !c.recordMetricsError(err) {
+			c.warnf("Failed to collect bulk metrics: %v", err)
+		}
+		return make(map[string]*types.PodMetrics)
+	}
+	return bulkMetrics
+}
+
+// listPods lists a workload's pods: from the pod dump when there is one, the pod itself for a single pod,
+// and otherwise the pods its selector matches
+func (c *Collector) listPods(ctx context.Context, workload types.WorkloadInfo, options *types.Options) ([]corev1.Pod, error) {
+	if c.podDump != nil {
+		return c.podDump.pods(workload), nil
+	}
+	if workload.Kind == "Pod" {
+		// Single pod
+		pod, err := c.clientset.CoreV1().Pods(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsForbidden(err) {
+				return nil, &ForbiddenError{Verb: "get", Resource: "pods", Namespace: workload.Namespace, Err: err}
+			}
+			return nil, fmt.Errorf("failed to get pod: %w", err)
+		}
+		return []corev1.Pod{*pod}, nil
+	}
+
+	// Workload with selector
+	podList, err := ListPods(ctx, c.clientset.CoreV1().Pods(workload.Namespace), metav1.ListOptions{
+		LabelSelector: workloadSelector(workload),
+		FieldSelector: options.FieldSelector,
+	}, options.ChunkSize)
+	if err != nil {
+		if options.FieldSelector != "" && apierrors.IsBadRequest(err) {
+			return nil, fmt.Errorf("invalid field selector %q: %w", options.FieldSelector, err)
+		}
+		return nil, PodListError(err, workload.Namespace)
+	}
+	if len(workload.PodNames) > 0 {
+		return podsNamed(podList, workload.PodNames), nil
+	}
+	return podList, nil
+}
+
+// workloadKey identifies a workload across the collector's per-workload state
+func workloadKey(workload types.WorkloadInfo) string {
+	return fmt.Sprintf("%s/%s/%s", workload.Kind, workload.Namespace, workload.Name)
+}
+
+// workloadSelector returns the label selector that lists a workload's pods
+func workloadSelector(workload types.WorkloadInfo) string {
+	if workload.LabelSelector != "" {
+		return workload.LabelSelector
+	}
+	return labels.SelectorFromSet(workload.Selector).String()
+}
+
+// podsNamed keeps the pods with the given names, in their listed order
+func podsNamed(pods []corev1.Pod, names []string) []corev1.Pod {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	var named []corev1.Pod
+	for _, pod := range pods {
+		if wanted[pod.Name] {
+			named = append(named, pod)
+		}
+	}
+	return named
+}
+
 // collectBulkMetrics collects metrics for all pods in one API call
 func (c *Collector) collectBulkMetrics(ctx context.Context, namespace string, pods []corev1.Pod, labelSelector string) (map[string]*types.PodMetrics, error) {
-	if c.metricsClient == nil {
-		return nil, fmt.Errorf("metrics client not available")
+	// A metrics snapshot file takes precedence over the live metrics API
+	if c.metricsSnapshot != nil {
+		result := make(map[string]*types.PodMetrics)
+		for i := range pods {
+			if metrics := c.snapshotPodMetrics(&pods[i]); metrics != nil {
+				result[pods[i].Name] = metrics
+			}
+		}
+		return result, nil
+	}
+
+	if c.metricsClient == nil || c.metricsUnavailable.Load() {
+		return nil, errMetricsAPIUnavailable
 	}
 
 	// Get pod metrics in the namespace filtered by label selector (if provided)
@@ -794,6 +1207,8 @@ func (c *Collector) collectBulkMetrics(ctx context.Context, namespace string, po
 		// Set aggregated pod-level usage
 		metrics.CPUUsage = c.formatCPUUsage(totalCPU.String())
 		metrics.MemoryUsage = c.formatMemoryUsage(totalMem.String())
+		metrics.CPUUsageMilli = totalCPU.MilliValue()
+		metrics.MemoryUsageBytes = totalMem.Value()
 
 		result[podMetrics.Name] = metrics
 	}
@@ -802,11 +1217,19 @@ func (c *Collector) collectBulkMetrics(ctx context.Context, namespace string, po
 }
 
 // collectBulkEvents collects events for all pods in one API call
-func (c *Collector) collectBulkEvents(ctx context.Context, namespace string, pods []corev1.Pod) (map[string][]types.EventInfo, error) {
-	// Get all events in the namespace
-	events, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
+func (c *Collector) collectBulkEvents(ctx context.Context, namespace string, pods []corev1.Pod, warningsOnly bool) (map[string][]types.EventInfo, error) {
+	// Get all events in the namespace. A dump's events are as old as the dump, so they are
+	// judged against its reference time rather than the current time.
+	var events []corev1.Event
+	now := c.now()
+	if c.podDump != nil {
+		events = c.podDump.namespaceEvents(namespace)
+	} else {
+		eventList, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		events = eventList.Items
 	}
 
 	// Create a map of pod names for fast lookup
@@ -816,43 +1239,36 @@ func (c *Collector) collectBulkEvents(ctx context.Context, namespace string, pod
 	}
 
 	// Determine time cutoff
-	cutoffTime := time.Now().Add(-1 * time.Hour) // Last 1 hour when explicitly requested
+	cutoffTime := now.Add(-1 * time.Hour) // Last 1 hour when explicitly requested
 
 	// Group events by pod name
 	result := make(map[string][]types.EventInfo)
 
-	for _, event := range events.Items {
+	for _, event := range events {
 		// Check if this event is for one of our pods
 		if !podNames[event.InvolvedObject.Name] {
 			continue
 		}
 
-		// Handle both old and new event formats
-		var eventTime time.Time
-
-		// For newer events, use EventTime or Series.LastObservedTime
-		if !event.EventTime.IsZero() {
-			eventTime = event.EventTime.Time
-			// If there's a series with more recent observation, use that
-			if event.Series != nil && !event.Series.LastObservedTime.IsZero() {
-				eventTime = event.Series.LastObservedTime.Time
-			}
-		} else {
-			// Fallback to older format: use LastTimestamp if available, otherwise FirstTimestamp
-			eventTime = event.FirstTimestamp.Time
-			if !event.LastTimestamp.IsZero() {
-				eventTime = event.LastTimestamp.Time
-			}
+		// Skip routine lifecycle events (Pulled, Created, Started) when only problems are wanted
+		if warningsOnly && event.Type == corev1.EventTypeNormal {
+			continue
 		}
 
+		eventTime := latestEventTime(&event)
+
 		if eventTime.After(cutoffTime) {
 			podName := event.InvolvedObject.Name
+			firstSeen, count := eventOccurrences(&event, eventTime)
 			eventInfo := types.EventInfo{
-				Time:    eventTime,
-				Type:    event.Type,
-				Reason:  event.Reason,
-				Message: event.Message,
-				PodName: podName,
+				Time:      eventTime,
+				FirstSeen: firstSeen,
+				Count:     count,
+				Type:      event.Type,
+				Reason:    event.Reason,
+				Message:   event.Message,
+				PodName:   podName,
+				Container: eventContainer(event.InvolvedObject.FieldPath),
 			}
 
 			result[podName] = append(result[podName], eventInfo)
@@ -862,8 +1278,66 @@ func (c *Collector) collectBulkEvents(ctx context.Context, namespace string, pod
 	return result, nil
 }
 
-// collectPodInfoWithData collects pod information using pre-collected metrics and events
+// eventContainer returns the container an event is about, from an involvedObject field path like
+// spec.containers{app}, or an empty string for pod-level events
+func eventContainer(fieldPath string) string {
+	start := strings.Index(fieldPath, "{")
+	if start < 0 || !strings.HasSuffix(fieldPath, "}") {
+		return ""
+	}
+	return fieldPath[start+1 : len(fieldPath)-1]
+}
+
+// latestEventTime returns when an event was last seen, handling both old and new event formats
+func latestEventTime(event *corev1.Event) time.Time {
+	// For newer events, use EventTime or Series.LastObservedTime
+	if !event.EventTime.IsZero() {
+		// If there's a series with more recent observation, use that
+		if event.Series != nil && !event.Series.LastObservedTime.IsZero() {
+			return event.Series.LastObservedTime.Time
+		}
+		return event.EventTime.Time
+	}
+	// Fallback to older format: use LastTimestamp if available, otherwise FirstTimestamp
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	return event.FirstTimestamp.Time
+}
+
+// podRevision returns the template revision hash a pod was created from. Deployments label pods with
+// pod-template-hash, StatefulSets and DaemonSets with controller-revision-hash.
+func podRevision(pod *corev1.Pod) string {
+	if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" {
+		return hash
+	}
+	return pod.Labels[appsv1.ControllerRevisionHashLabelKey]
+}
+
+// schedulingLatency returns how long the pod waited between creation and its PodScheduled condition
+// turning true, or nil when it hasn't been scheduled
+func schedulingLatency(pod *corev1.Pod) *time.Duration {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.PodScheduled || condition.Status != corev1.ConditionTrue || condition.LastTransitionTime.IsZero() {
+			continue
+		}
+		latency := condition.LastTransitionTime.Sub(pod.CreationTimestamp.Time)
+		if latency < 0 {
+			latency = 0
+		}
+		return &latency
+	}
+	return nil
+}
+
+// collectPodInfoWithData collects pod information using pre-collected metrics and events. Without pod
+// information the error says why; with it, the error reports what's missing, such as container logs.
 func (c *Collector) collectPodInfoWithData(ctx context.Context, pod *corev1.Pod, options *types.Options, podMetrics *types.PodMetrics, podEvents []types.EventInfo) (*types.PodInfo, error) {
+	// Past the deadline or after an interrupt, the API calls below would only fail one by one
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Determine pod status - check for terminating state first
 	status := string(pod.Status.Phase)
 	if pod.DeletionTimestamp != nil {
@@ -875,41 +1349,92 @@ func (c *Collector) collectPodInfoWithData(ctx context.Context, pod *corev1.Pod,
 		Namespace:      pod.Namespace,
 		NodeName:       pod.Spec.NodeName,
 		ServiceAccount: pod.Spec.ServiceAccountName,
-		Age:            time.Since(pod.CreationTimestamp.Time),
+		Age:            c.now().Sub(pod.CreationTimestamp.Time),
 		Status:         status,
+		StatusReason:   pod.Status.Reason,
+		StatusMessage:  pod.Status.Message,
 		Metrics:        podMetrics,
 		Events:         podEvents,
 		Labels:         pod.Labels,
 		Annotations:    pod.Annotations,
 		Conditions:     c.collectPodConditions(pod),
 		Network:        c.collectNetworkInfo(pod),
+
+		PriorityClassName: pod.Spec.PriorityClassName,
+		Priority:          pod.Spec.Priority,
+		QOSClass:          string(pod.Status.QOSClass),
+
+		SchedulerName:     pod.Spec.SchedulerName,
+		SchedulingLatency: schedulingLatency(pod),
+
+		Revision:        podRevision(pod),
+		CompletionIndex: pod.Annotations[batchv1.JobCompletionIndexAnnotation],
+
+		UID:             string(pod.UID),
+		ResourceVersion: pod.ResourceVersion,
+
+		SchedulingConstraints: c.collectSchedulingConstraints(pod),
+
+		NodeSelector: pod.Spec.NodeSelector,
+		NodeAffinity: describeNodeAffinity(pod.Spec.Affinity),
+		Tolerations:  describeTolerations(pod.Spec.Tolerations),
 	}
+	c.collectTerminationInfo(pod, podInfo)
 
 	// Determine if detailed info is needed
 	needsDetailedInfo := options.SinglePodView
 
 	// Collect container information - pass pod metrics for resource calculation
+	var errs []error
 	for _, container := range pod.Spec.InitContainers {
-		containerInfo := c.collectContainerInfo(ctx, container, pod, types.ContainerTypeInit, options, podMetrics, needsDetailedInfo)
+		containerInfo, err := c.collectContainerInfo(ctx, container, pod, types.ContainerTypeInit, options, podMetrics, needsDetailedInfo)
 		podInfo.InitContainers = append(podInfo.InitContainers, containerInfo)
+		errs = append(errs, err)
 	}
 
 	for _, container := range pod.Spec.Containers {
-		containerInfo := c.collectContainerInfo(ctx, container, pod, types.ContainerTypeStandard, options, podMetrics, needsDetailedInfo)
+		containerInfo, err := c.collectContainerInfo(ctx, container, pod, types.ContainerTypeStandard, options, podMetrics, needsDetailedInfo)
 		podInfo.Containers = append(podInfo.Containers, containerInfo)
+		errs = append(errs, err)
+	}
+
+	for _, container := range pod.Spec.EphemeralContainers {
+		// Ephemeral containers share the regular container fields, minus resources, ports and probes
+		containerInfo, err := c.collectContainerInfo(ctx, corev1.Container(container.EphemeralContainerCommon), pod, types.ContainerTypeEphemeral, options, podMetrics, needsDetailedInfo)
+		podInfo.EphemeralContainers = append(podInfo.EphemeralContainers, containerInfo)
+		errs = append(errs, err)
+	}
+
+	if options.NodeContext && options.SinglePodView {
+		podInfo.NodeUsage = c.collectNodeContext(ctx, pod, podMetrics)
+	}
+	if options.NodeHealth {
+		podInfo.NodeProblems = c.collectNodeProblems(ctx, pod)
 	}
+	recordLivenessFailures(podInfo)
 
-	return podInfo, nil
+	return podInfo, errors.Join(errs...)
 }
 
-// collectContainerLogs collects recent logs for a container
-func (c *Collector) collectContainerLogs(ctx context.Context, pod *corev1.Pod, containerName string) ([]string, error) {
+// Log lines shown per container, and how far back --log-grep searches for them
+const (
+	logTailLines     = 10
+	logGrepScanLines = 1000
+)
+
+// collectContainerLogs collects recent logs for a container. With a pattern, it searches further back
+// and keeps the most recent matching lines instead.
+func (c *Collector) collectContainerLogs(ctx context.Context, pod *corev1.Pod, containerName string, pattern *regexp.Regexp) ([]string, error) {
 	// Just get the most recent 10 lines, like systemctl status
+	tailLines := int64(logTailLines)
+	if pattern != nil {
+		tailLines = logGrepScanLines
+	}
 	logOptions := &corev1.PodLogOptions{
 		Container:  containerName,
 		Follow:     false,
 		Timestamps: false,
-		TailLines:  int64Ptr(10), // Last 10 lines, no time filtering
+		TailLines:  int64Ptr(tailLines), // No time filtering
 	}
 
 	// Get logs
@@ -920,12 +1445,17 @@ func (c *Collector) collectContainerLogs(ctx context.Context, pod *corev1.Pod, c
 	}
 	defer logs.Close()
 
-	// Read logs
+	return readLogLines(logs, pattern)
+}
+
+// readLogLines reads non-empty log lines, keeping only those matching pattern when it is set, and
+// returns the last logTailLines of them
+func readLogLines(logs io.Reader, pattern *regexp.Regexp) ([]string, error) {
 	var logLines []string
 	scanner := bufio.NewScanner(logs)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		if line != "" {
+		if line != "" && (pattern == nil || pattern.MatchString(line)) {
 			logLines = append(logLines, line)
 		}
 	}
@@ -934,6 +1464,9 @@ func (c *Collector) collectContainerLogs(ctx context.Context, pod *corev1.Pod, c
 		return nil, fmt.Errorf("failed to read logs: %w", err)
 	}
 
+	if len(logLines) > logTailLines {
+		logLines = logLines[len(logLines)-logTailLines:]
+	}
 	return logLines, nil
 }
 
@@ -947,6 +1480,8 @@ func (c *Collector) collectPodConditions(pod *corev1.Pod) []types.PodCondition {
 			Status:  string(condition.Status),
 			Reason:  condition.Reason,
 			Message: condition.Message,
+
+			LastTransitionTime: condition.LastTransitionTime.Time,
 		}
 		conditions = append(conditions, podCondition)
 	}
@@ -954,6 +1489,172 @@ func (c *Collector) collectPodConditions(pod *corev1.Pod) []types.PodCondition {
 	return conditions
 }
 
+// collectTerminationInfo records how long a terminating pod has been shutting down and what may block it
+func (c *Collector) collectTerminationInfo(pod *corev1.Pod, podInfo *types.PodInfo) {
+	podInfo.Finalizers = pod.Finalizers
+
+	if pod.DeletionTimestamp == nil {
+		return
+	}
+
+	// The API server sets the deletion timestamp to the deletion request time plus the grace
+	// period, so subtract the grace period to find when termination started
+	gracePeriod := time.Duration(0)
+	if pod.DeletionGracePeriodSeconds != nil {
+		gracePeriod = time.Duration(*pod.DeletionGracePeriodSeconds) * time.Second
+	} else if pod.Spec.TerminationGracePeriodSeconds != nil {
+		gracePeriod = time.Duration(*pod.Spec.TerminationGracePeriodSeconds) * time.Second
+	}
+
+	requestedAt := pod.DeletionTimestamp.Time.Add(-gracePeriod)
+	podInfo.TerminatingFor = c.now().Sub(requestedAt)
+	podInfo.TerminationGracePeriod = gracePeriod
+}
+
+// collectSchedulingConstraints summarizes the pod (anti-)affinity and topology spread rules of a
+// pending pod, which FailedScheduling events refer to without spelling them out
+func (c *Collector) collectSchedulingConstraints(pod *corev1.Pod) []string {
+	if pod.Status.Phase != corev1.PodPending {
+		return nil
+	}
+
+	var constraints []string
+	if affinity := pod.Spec.Affinity; affinity != nil {
+		if affinity.PodAntiAffinity != nil {
+			constraints = append(constraints, describePodAffinityTerms("not co-located with",
+				affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+				affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)...)
+		}
+		if affinity.PodAffinity != nil {
+			constraints = append(constraints, describePodAffinityTerms("co-located with",
+				affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+				affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution)...)
+		}
+	}
+
+	for _, spread := range pod.Spec.TopologySpreadConstraints {
+		constraint := fmt.Sprintf("spread by %s maxSkew %d", topologyName(spread.TopologyKey), spread.MaxSkew)
+		if spread.LabelSelector != nil {
+			constraint += fmt.Sprintf(" across %s", metav1.FormatLabelSelector(spread.LabelSelector))
+		}
+		if spread.WhenUnsatisfiable == corev1.ScheduleAnyway {
+			constraint += " (best effort)"
+		}
+		constraints = append(constraints, constraint)
+	}
+
+	return constraints
+}
+
+// describePodAffinityTerms summarizes required and preferred pod (anti-)affinity terms
+func describePodAffinityTerms(relation string, required []corev1.PodAffinityTerm, preferred []corev1.WeightedPodAffinityTerm) []string {
+	var descriptions []string
+	for _, term := range required {
+		descriptions = append(descriptions, fmt.Sprintf("requires %s %s per %s",
+			relation, metav1.FormatLabelSelector(term.LabelSelector), topologyName(term.TopologyKey)))
+	}
+	for _, weighted := range preferred {
+		term := weighted.PodAffinityTerm
+		descriptions = append(descriptions, fmt.Sprintf("prefers %s %s per %s (weight %d)",
+			relation, metav1.FormatLabelSelector(term.LabelSelector), topologyName(term.TopologyKey), weighted.Weight))
+	}
+	return descriptions
+}
+
+// describeNodeAffinity summarizes required and preferred node affinity terms
+func describeNodeAffinity(affinity *corev1.Affinity) []string {
+	if affinity == nil || affinity.NodeAffinity == nil {
+		return nil
+	}
+
+	var descriptions []string
+	if required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+		// Terms are ORed, so a node has to match any one of them
+		var terms []string
+		for _, term := range required.NodeSelectorTerms {
+			terms = append(terms, describeNodeSelectorTerm(term))
+		}
+		descriptions = append(descriptions, "requires "+strings.Join(terms, " or "))
+	}
+	for _, preferred := range affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		descriptions = append(descriptions, fmt.Sprintf("prefers %s (weight %d)", describeNodeSelectorTerm(preferred.Preference), preferred.Weight))
+	}
+	return descriptions
+}
+
+// describeNodeSelectorTerm summarizes the ANDed requirements of a node selector term
+func describeNodeSelectorTerm(term corev1.NodeSelectorTerm) string {
+	all := make([]corev1.NodeSelectorRequirement, 0, len(term.MatchExpressions)+len(term.MatchFields))
+	all = append(append(all, term.MatchExpressions...), term.MatchFields...)
+
+	var requirements []string
+	for _, requirement := range all {
+		switch requirement.Operator {
+		case corev1.NodeSelectorOpExists:
+			requirements = append(requirements, requirement.Key)
+		case corev1.NodeSelectorOpDoesNotExist:
+			requirements = append(requirements, "!"+requirement.Key)
+		case corev1.NodeSelectorOpGt:
+			requirements = append(requirements, fmt.Sprintf("%s>%s", requirement.Key, strings.Join(requirement.Values, ",")))
+		case corev1.NodeSelectorOpLt:
+			requirements = append(requirements, fmt.Sprintf("%s<%s", requirement.Key, strings.Join(requirement.Values, ",")))
+		default:
+			requirements = append(requirements, fmt.Sprintf("%s %s (%s)",
+				requirement.Key, strings.ToLower(string(requirement.Operator)), strings.Join(requirement.Values, ", ")))
+		}
+	}
+	return strings.Join(requirements, ", ")
+}
+
+// describeTolerations summarizes tolerations in taint notation (key=value:Effect), leaving out the
+// not-ready and unreachable tolerations the API server adds to every pod
+func describeTolerations(tolerations []corev1.Toleration) []string {
+	var descriptions []string
+	for _, toleration := range tolerations {
+		if isDefaultToleration(toleration) {
+			continue
+		}
+
+		description := toleration.Key
+		if toleration.Key == "" {
+			description = "*" // An empty key with Exists tolerates every taint
+		}
+		if toleration.Operator != corev1.TolerationOpExists && toleration.Value != "" {
+			description += "=" + toleration.Value
+		}
+		if toleration.Effect != "" {
+			description += ":" + string(toleration.Effect)
+		}
+		if toleration.TolerationSeconds != nil {
+			description += fmt.Sprintf(" for %ds", *toleration.TolerationSeconds)
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
+
+// isDefaultToleration reports whether the toleration is one the DefaultTolerationSeconds admission plugin adds
+func isDefaultToleration(toleration corev1.Toleration) bool {
+	return (toleration.Key == corev1.TaintNodeNotReady || toleration.Key == corev1.TaintNodeUnreachable) &&
+		toleration.Operator == corev1.TolerationOpExists &&
+		toleration.Effect == corev1.TaintEffectNoExecute &&
+		toleration.TolerationSeconds != nil && *toleration.TolerationSeconds == 300
+}
+
+// topologyName shortens the well-known topology keys to the names people use for them
+func topologyName(key string) string {
+	switch key {
+	case corev1.LabelHostname:
+		return "node"
+	case corev1.LabelTopologyZone, corev1.LabelFailureDomainBetaZone:
+		return "zone"
+	case corev1.LabelTopologyRegion, corev1.LabelFailureDomainBetaRegion:
+		return "region"
+	default:
+		return key
+	}
+}
+
 // int64Ptr returns a pointer to an int64 value
 func int64Ptr(i int64) *int64 {
 	return &i
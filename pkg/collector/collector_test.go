@@ -0,0 +1,595 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestRecordMetricsError(t *testing.T) {
+	podMetrics := schema.GroupResource{Group: "metrics.k8s.io", Resource: "pods"}
+
+	tests := []struct {
+		name        string
+		err         error
+		unavailable bool
+	}{
+		{"no metrics client", errMetricsAPIUnavailable, true},
+		{"wrapped no metrics client", fmt.Errorf("bulk: %w", errMetricsAPIUnavailable), true},
+		{"metrics API group missing", errors.New("the server could not find the requested resource (get pods.metrics.k8s.io)"), true},
+		{"metrics-server not ready", apierrors.NewServiceUnavailable("metrics-server is starting"), true},
+		{"metrics for one pod not ready yet", apierrors.NewNotFound(podMetrics, "web-1"), false},
+		{"unrelated error", errors.New("connection reset by peer"), false},
+		{"forbidden by RBAC", apierrors.NewForbidden(podMetrics, "", errors.New("cannot list resource")), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Collector{}
+			if got := c.recordMetricsError(tt.err); got != tt.unavailable {
+				t.Errorf("recordMetricsError() = %v, want %v", got, tt.unavailable)
+			}
+			if c.MetricsUnavailable() != tt.unavailable {
+				t.Errorf("MetricsUnavailable() = %v, want %v", c.MetricsUnavailable(), tt.unavailable)
+			}
+		})
+	}
+}
+
+func TestCollectSchedulingConstraints(t *testing.T) {
+	apiSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Affinity: &corev1.Affinity{
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+						{LabelSelector: apiSelector, TopologyKey: corev1.LabelHostname},
+					},
+					PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+						{Weight: 50, PodAffinityTerm: corev1.PodAffinityTerm{LabelSelector: apiSelector, TopologyKey: "rack"}},
+					},
+				},
+			},
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.DoNotSchedule, LabelSelector: apiSelector},
+				{MaxSkew: 2, TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.ScheduleAnyway},
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}
+
+	expected := []string{
+		"requires not co-located with app=api per node",
+		"prefers not co-located with app=api per rack (weight 50)",
+		"spread by zone maxSkew 1 across app=api",
+		"spread by node maxSkew 2 (best effort)",
+	}
+
+	c := &Collector{}
+	got := c.collectSchedulingConstraints(pod)
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d constraints, got %d: %v", len(expected), len(got), got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("constraint %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+
+	pod.Status.Phase = corev1.PodRunning
+	if got := c.collectSchedulingConstraints(pod); got != nil {
+		t.Errorf("expected no constraints for a running pod, got %v", got)
+	}
+}
+
+func TestDescribeNodeAffinity(t *testing.T) {
+	affinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"us-east-1a", "us-east-1b"}},
+						{Key: "gpu", Operator: corev1.NodeSelectorOpExists},
+					}},
+					{MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "spot", Operator: corev1.NodeSelectorOpDoesNotExist},
+					}},
+				},
+			},
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{
+				{Weight: 10, Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "cores", Operator: corev1.NodeSelectorOpGt, Values: []string{"8"}},
+				}}},
+			},
+		},
+	}
+
+	expected := []string{
+		"requires topology.kubernetes.io/zone in (us-east-1a, us-east-1b), gpu or !spot",
+		"prefers cores>8 (weight 10)",
+	}
+	got := describeNodeAffinity(affinity)
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("term %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+}
+
+func TestDescribeTolerations(t *testing.T) {
+	defaultSeconds := int64(300)
+	evictAfter := int64(60)
+	tolerations := []corev1.Toleration{
+		{Key: corev1.TaintNodeNotReady, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &defaultSeconds},
+		{Key: corev1.TaintNodeUnreachable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &evictAfter},
+		{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+		{Operator: corev1.TolerationOpExists},
+	}
+
+	expected := []string{
+		"node.kubernetes.io/unreachable:NoExecute for 60s",
+		"dedicated=gpu:NoSchedule",
+		"*",
+	}
+	got := describeTolerations(tolerations)
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("toleration %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+}
+
+func TestCollectResourceInfoMemoryFigures(t *testing.T) {
+	c := New(nil, nil)
+	container := corev1.Container{
+		Name: "app",
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		metrics types.ContainerMetrics
+		rss     string
+	}{
+		{"working set only", types.ContainerMetrics{MemoryUsage: "128Mi"}, ""},
+		{"working set and RSS", types.ContainerMetrics{MemoryUsage: "128Mi", MemoryRSS: "96Mi"}, "96Mi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podMetrics := &types.PodMetrics{Containers: map[string]types.ContainerMetrics{"app": tt.metrics}}
+			info := c.collectResourceInfo(container, "app", podMetrics)
+			if info.MemUsage != "128Mi" || info.MemPercentage != 50 {
+				t.Errorf("expected 128Mi working set at 50%% of the limit, got %s at %.0f%%", info.MemUsage, info.MemPercentage)
+			}
+			if info.MemRSS != tt.rss {
+				t.Errorf("expected RSS %q, got %q", tt.rss, info.MemRSS)
+			}
+			if !info.HasMemUsage || info.HasCPUUsage {
+				t.Errorf("expected only memory usage to be known, got CPU %v, memory %v", info.HasCPUUsage, info.HasMemUsage)
+			}
+		})
+	}
+
+	// Without metrics the zero usage is a placeholder, not a reading
+	if info := c.collectResourceInfo(container, "app", nil); info.HasCPUUsage || info.HasMemUsage {
+		t.Errorf("expected no usage without metrics, got %+v", info)
+	}
+}
+
+func TestCollectPodsWithoutEventsSkipsEventsAPI(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:1"}}},
+	}
+
+	workloads := []types.WorkloadInfo{
+		{Name: "web", Kind: "Deployment", Namespace: "default", Selector: map[string]string{"app": "web"}},
+		{Name: "web-1", Kind: "Pod", Namespace: "default"},
+	}
+	for _, workload := range workloads {
+		clientset := fake.NewSimpleClientset(pod)
+		eventCalls := 0
+		clientset.PrependReactor("*", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			eventCalls++
+			return false, nil, nil
+		})
+
+		collected, err := New(clientset, nil).CollectPods(context.Background(), workload, &types.Options{ShowEvents: false})
+		if err != nil {
+			t.Fatalf("%s: CollectPods() failed: %v", workload.Kind, err)
+		}
+		if len(collected) != 1 || len(collected[0].Events) != 0 {
+			t.Errorf("%s: expected one pod without events, got %+v", workload.Kind, collected)
+		}
+		if eventCalls != 0 {
+			t.Errorf("%s: expected no events API calls with events off, got %d", workload.Kind, eventCalls)
+		}
+	}
+}
+
+func TestCollectPodsIncludesFinishedPods(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:1"}}},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	clientset := fake.NewSimpleClientset(
+		pod("web-new", corev1.PodRunning),
+		pod("web-crashed", corev1.PodFailed),
+		pod("web-done", corev1.PodSucceeded),
+	)
+
+	// A replaced pod that still exists is evidence, so nothing is skipped by phase
+	workload := types.WorkloadInfo{Name: "web", Kind: "Deployment", Namespace: "default", Selector: map[string]string{"app": "web"}}
+	collected, err := New(clientset, nil).CollectPods(context.Background(), workload, &types.Options{})
+	if err != nil {
+		t.Fatalf("CollectPods() failed: %v", err)
+	}
+	statuses := make(map[string]string)
+	for _, pod := range collected {
+		statuses[pod.Name] = pod.Status
+	}
+	expected := map[string]string{"web-new": "Running", "web-crashed": "Failed", "web-done": "Succeeded"}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("expected %v, got %v", expected, statuses)
+	}
+}
+
+func TestSchedulingLatency(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	pod := func(conditions ...corev1.PodCondition) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+			Status:     corev1.PodStatus{Conditions: conditions},
+		}
+	}
+
+	scheduled := pod(corev1.PodCondition{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(created.Add(2 * time.Second))})
+	if latency := schedulingLatency(scheduled); latency == nil || *latency != 2*time.Second {
+		t.Errorf("expected a 2s scheduling latency, got %v", latency)
+	}
+
+	unschedulable := pod(corev1.PodCondition{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: "Unschedulable"})
+	if latency := schedulingLatency(unschedulable); latency != nil {
+		t.Errorf("expected no latency for an unscheduled pod, got %v", *latency)
+	}
+}
+
+func TestResolveResourceFieldRef(t *testing.T) {
+	container := corev1.Container{
+		Name: "app",
+		Resources: corev1.ResourceRequirements{
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi"), corev1.ResourceCPU: resource.MustParse("500m")},
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+		},
+	}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+		container,
+		{Name: "proxy", Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")}}},
+	}}}
+
+	tests := []struct {
+		name     string
+		ref      corev1.ResourceFieldSelector
+		expected string
+	}{
+		{name: "limits.memory without divisor", ref: corev1.ResourceFieldSelector{Resource: "limits.memory"}, expected: "1073741824"},
+		{name: "limits.memory in Mi", ref: corev1.ResourceFieldSelector{Resource: "limits.memory", Divisor: resource.MustParse("1Mi")}, expected: "1024"},
+		{name: "limits.memory in Gi", ref: corev1.ResourceFieldSelector{Resource: "limits.memory", Divisor: resource.MustParse("1Gi")}, expected: "1"},
+		{name: "limits.memory in M rounds up", ref: corev1.ResourceFieldSelector{Resource: "limits.memory", Divisor: resource.MustParse("1M")}, expected: "1074"},
+		{name: "limits.memory in Ki", ref: corev1.ResourceFieldSelector{Resource: "limits.memory", Divisor: resource.MustParse("1Ki")}, expected: "1048576"},
+		{name: "limits.cpu rounds up to whole cores", ref: corev1.ResourceFieldSelector{Resource: "limits.cpu"}, expected: "1"},
+		{name: "requests.cpu in millicores", ref: corev1.ResourceFieldSelector{Resource: "requests.cpu", Divisor: resource.MustParse("1m")}, expected: "250"},
+		{name: "unset request", ref: corev1.ResourceFieldSelector{Resource: "requests.memory"}, expected: "0"},
+		{name: "unset limit", ref: corev1.ResourceFieldSelector{Resource: "limits.ephemeral-storage"}, expected: "[resource:limits.ephemeral-storage unset, node allocatable]"},
+		{name: "other container", ref: corev1.ResourceFieldSelector{ContainerName: "proxy", Resource: "limits.memory", Divisor: resource.MustParse("1Mi")}, expected: "128"},
+		{name: "unknown container", ref: corev1.ResourceFieldSelector{ContainerName: "missing", Resource: "limits.memory"}, expected: "[resource:limits.memory of unknown container missing]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveResourceFieldRef(&tt.ref, container, pod); got != tt.expected {
+				t.Errorf("resolveResourceFieldRef() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseHTTPSProbeWithHeaders(t *testing.T) {
+	probe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{
+		Path:   "/healthz",
+		Port:   intstr.FromInt32(8443),
+		Host:   "app.internal",
+		Scheme: corev1.URISchemeHTTPS,
+		HTTPHeaders: []corev1.HTTPHeader{
+			{Name: "X-Probe", Value: "kubelet"},
+			{Name: "Authorization", Value: "Bearer s3cr3t"},
+		},
+	}}}
+
+	details := New(nil, nil).parseProbeDetails(probe)
+	if details.Type != "HTTP" || details.Scheme != "HTTPS" || details.Host != "app.internal" || details.Port != "8443" {
+		t.Errorf("unexpected probe details: %+v", details)
+	}
+	expectedHeaders := []string{"X-Probe: kubelet", "Authorization: ***"}
+	if !reflect.DeepEqual(details.Headers, expectedHeaders) {
+		t.Errorf("expected headers %v with credentials masked, got %v", expectedHeaders, details.Headers)
+	}
+
+	plain := New(nil, nil).parseProbeDetails(&corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/", Port: intstr.FromString("http")}}})
+	if plain.Scheme != "HTTP" {
+		t.Errorf("expected the scheme to default to HTTP, got %q", plain.Scheme)
+	}
+}
+
+func TestReadLogLines(t *testing.T) {
+	var logs strings.Builder
+	for i := 1; i <= 30; i++ {
+		level := "INFO"
+		if i%2 == 0 {
+			level = "ERROR"
+		}
+		fmt.Fprintf(&logs, "%s request %d\n\n", level, i)
+	}
+
+	tests := []struct {
+		name     string
+		pattern  *regexp.Regexp
+		expected []string
+	}{
+		{name: "tail", expected: []string{"INFO request 21", "ERROR request 22"}},
+		{name: "grep", pattern: regexp.MustCompile(`^ERROR`), expected: []string{"ERROR request 12", "ERROR request 14"}},
+		{name: "no match", pattern: regexp.MustCompile(`timeout`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := readLogLines(strings.NewReader(logs.String()), tt.pattern)
+			if err != nil {
+				t.Fatalf("readLogLines() failed: %v", err)
+			}
+			if len(tt.expected) == 0 {
+				if len(lines) != 0 {
+					t.Errorf("expected no lines, got %v", lines)
+				}
+				return
+			}
+			if len(lines) != logTailLines || !reflect.DeepEqual(lines[:2], tt.expected) {
+				t.Errorf("expected the last %d lines starting with %v, got %v", logTailLines, tt.expected, lines)
+			}
+		})
+	}
+}
+
+func TestCollectVolumeInfo(t *testing.T) {
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	tests := []struct {
+		name            string
+		source          corev1.VolumeSource
+		expectedType    string
+		expectedDetails string
+	}{
+		{
+			name:            "csi",
+			source:          corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{Driver: "secrets-store.csi.k8s.io"}},
+			expectedType:    "CSI",
+			expectedDetails: "csi/secrets-store.csi.k8s.io",
+		},
+		{
+			name: "projected",
+			source: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"}},
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "kube-root-ca.crt"}}},
+				{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "tls"}}},
+				{DownwardAPI: &corev1.DownwardAPIProjection{}},
+			}}},
+			expectedType:    "Projected",
+			expectedDetails: "projected (serviceAccountToken, configmap/kube-root-ca.crt, secret/tls, downwardAPI)",
+		},
+		{
+			name:            "projected without sources",
+			source:          corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{}},
+			expectedType:    "Projected",
+			expectedDetails: "projected",
+		},
+		{
+			name:            "host path",
+			source:          corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/log", Type: &hostPathType}},
+			expectedType:    "HostPath",
+			expectedDetails: "hostPath:/var/log (DirectoryOrCreate)",
+		},
+		{
+			name:            "host path without type",
+			source:          corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"}},
+			expectedType:    "HostPath",
+			expectedDetails: "hostPath:/var/run/docker.sock",
+		},
+		{
+			name: "downward API",
+			source: corev1.VolumeSource{DownwardAPI: &corev1.DownwardAPIVolumeSource{Items: []corev1.DownwardAPIVolumeFile{
+				{Path: "labels"}, {Path: "annotations"},
+			}}},
+			expectedType:    "DownwardAPI",
+			expectedDetails: "downwardAPI (2 items)",
+		},
+		{
+			name:            "nfs",
+			source:          corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{Server: "nfs.internal", Path: "/exports/data"}},
+			expectedType:    "NFS",
+			expectedDetails: "nfs/nfs.internal:/exports/data",
+		},
+	}
+
+	c := New(fake.NewSimpleClientset(), nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container := corev1.Container{Name: "app", VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}}
+			pod := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "data", VolumeSource: tt.source}}}}
+
+			volumes := c.collectVolumeInfo(container, pod)
+			if len(volumes) != 1 {
+				t.Fatalf("expected one volume, got %+v", volumes)
+			}
+			if volumes[0].VolumeType != tt.expectedType || volumes[0].Details != tt.expectedDetails {
+				t.Errorf("expected %s %q, got %s %q", tt.expectedType, tt.expectedDetails, volumes[0].VolumeType, volumes[0].Details)
+			}
+		})
+	}
+}
+
+func TestCollectWaitingMessage(t *testing.T) {
+	message := `Back-off pulling image "registry.example.com/app:2.0"`
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-1", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "registry.example.com/app:2.0"}, {Name: "proxy", Image: "envoy:1.29"}}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: message}}},
+				{Name: "proxy", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		},
+	}
+	workload := types.WorkloadInfo{Name: "app-1", Kind: "Pod", Namespace: "default"}
+
+	collected, err := New(fake.NewSimpleClientset(pod), nil).CollectPods(context.Background(), workload, &types.Options{})
+	if err != nil {
+		t.Fatalf("CollectPods() failed: %v", err)
+	}
+	if len(collected) != 1 || len(collected[0].Containers) != 2 {
+		t.Fatalf("expected one pod with two containers, got %+v", collected)
+	}
+	for _, container := range collected[0].Containers {
+		expected := ""
+		if container.Name == "app" {
+			expected = message
+		}
+		if container.StatusMessage != expected {
+			t.Errorf("%s: expected status message %q, got %q", container.Name, expected, container.StatusMessage)
+		}
+	}
+	if app := collected[0].Containers[0]; app.Status != "ImagePullBackOff" {
+		t.Errorf("expected the waiting reason as status, got %q", app.Status)
+	}
+}
+
+func TestCollectPodsInvalidFieldSelector(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewBadRequest("field label not supported: spec.unknown")
+	})
+	workload := types.WorkloadInfo{Name: "web", Kind: "Deployment", Namespace: "default", Selector: map[string]string{"app": "web"}}
+
+	_, err := New(clientset, nil).CollectPods(context.Background(), workload, &types.Options{FieldSelector: "spec.unknown=x"})
+	if err == nil || !strings.Contains(err.Error(), `invalid field selector "spec.unknown=x"`) {
+		t.Errorf("expected an invalid field selector error, got %v", err)
+	}
+
+	// Without a field selector, a bad request is not blamed on one
+	_, err = New(clientset, nil).CollectPods(context.Background(), workload, &types.Options{})
+	if err == nil || strings.Contains(err.Error(), "field selector") {
+		t.Errorf("expected a plain pod listing error, got %v", err)
+	}
+}
+
+func TestEventsWarningsOnly(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}}
+	event := func(name, eventType, reason string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "default"},
+			Type:           eventType,
+			Reason:         reason,
+			LastTimestamp:  metav1.NewTime(time.Now().Add(-time.Minute)),
+		}
+	}
+	c := New(fake.NewSimpleClientset(pod, event("web-1.pulled", corev1.EventTypeNormal, "Pulled"), event("web-1.backoff", corev1.EventTypeWarning, "BackOff")), nil)
+
+	reasons := func(events []types.EventInfo) []string {
+		var reasons []string
+		for _, event := range events {
+			reasons = append(reasons, event.Reason)
+		}
+		sort.Strings(reasons)
+		return reasons
+	}
+	for _, tt := range []struct {
+		warningsOnly bool
+		expected     []string
+	}{
+		{warningsOnly: false, expected: []string{"BackOff", "Pulled"}},
+		{warningsOnly: true, expected: []string{"BackOff"}},
+	} {
+		bulk, err := c.collectBulkEvents(context.Background(), "default", []corev1.Pod{*pod}, tt.warningsOnly)
+		if err != nil {
+			t.Fatalf("collectBulkEvents() failed: %v", err)
+		}
+		if got := reasons(bulk["web-1"]); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("bulk, warnings only %v: expected %v, got %v", tt.warningsOnly, tt.expected, got)
+		}
+
+		single, err := c.collectPodEvents(context.Background(), pod, tt.warningsOnly)
+		if err != nil {
+			t.Fatalf("collectPodEvents() failed: %v", err)
+		}
+		if got := reasons(single); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("single pod, warnings only %v: expected %v, got %v", tt.warningsOnly, tt.expected, got)
+		}
+	}
+}
+
+func TestUsePhaseTimer(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:1"}}},
+	})
+	workload := types.WorkloadInfo{Name: "web", Kind: "Deployment", Namespace: "default", Selector: map[string]string{"app": "web"}}
+	options := &types.Options{ShowEvents: true}
+
+	// Without a timer, collection doesn't time anything
+	if _, err := New(clientset, nil).CollectPods(context.Background(), workload, options); err != nil {
+		t.Fatalf("CollectPods() failed: %v", err)
+	}
+
+	var started, stopped []string
+	c := New(clientset, nil)
+	c.UsePhaseTimer(func(phase string) func() {
+		started = append(started, phase)
+		return func() { stopped = append(stopped, phase) }
+	})
+	if _, err := c.CollectPods(context.Background(), workload, options); err != nil {
+		t.Fatalf("CollectPods() failed: %v", err)
+	}
+	expected := []string{"pod listing", "metrics", "events", "pod details"}
+	if !reflect.DeepEqual(started, expected) || !reflect.DeepEqual(stopped, expected) {
+		t.Errorf("expected phases %v to start and stop, got %v and %v", expected, started, stopped)
+	}
+}
@@ -0,0 +1,338 @@
+package collector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// PodDump holds the pods and events read from `kubectl get -o yaml` or `-o json` output, for analysis
+// without a cluster
+type PodDump struct {
+	Pods   []corev1.Pod
+	Events []corev1.Event
+
+	// Pods of each workload returned by Workloads, by workloadKey
+	workloadPods map[string][]corev1.Pod
+
+	// When the dump was taken, see ReferenceTime
+	referenceTime time.Time
+}
+
+// dumpObject is just enough of an object or list to tell which one it is
+type dumpObject struct {
+	Kind  string            `json:"kind"`
+	Items []json.RawMessage `json:"items"`
+}
+
+// LoadPodDump reads pods and events from one or more dump files. Each file may hold a single object, a
+// List (what `kubectl get pods,events -o yaml` prints), a PodList or EventList, or several YAML documents.
+// Other kinds are ignored, so a dump of a whole namespace works too.
+func LoadPodDump(paths []string) (*PodDump, error) {
+	dump := &PodDump{}
+	var modified time.Time
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dump: %w", err)
+		}
+		if info, err := file.Stat(); err == nil && info.ModTime().After(modified) {
+			modified = info.ModTime()
+		}
+		err = dump.read(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse dump %s: %w", path, err)
+		}
+	}
+	if len(dump.Pods) == 0 {
+		return nil, fmt.Errorf("no pods found in %s", strings.Join(paths, ", "))
+	}
+	// The files were written when the dump was taken, unless they were copied since. A timestamp in the
+	// dump newer than that means the file times can't be trusted.
+	dump.referenceTime = modified
+	if newest := dump.newestTime(); newest.After(modified) {
+		dump.referenceTime = newest
+	}
+	return dump, nil
+}
+
+// ReferenceTime approximates when the dump was taken, which it doesn't record itself: when its files
+// were last modified, or its newest timestamp if that is later. Ages, grace periods and event windows
+// are measured up to this time rather than the current one, so an old dump reads the way the cluster
+// looked when it was taken.
+func (d *PodDump) ReferenceTime() time.Time {
+	return d.referenceTime
+}
+
+// SetReferenceTime sets when the dump was taken, for dumps whose files were copied or edited since
+func (d *PodDump) SetReferenceTime(taken time.Time) {
+	d.referenceTime = taken
+}
+
+// newestTime returns the newest timestamp among the dump's events, pod creations, conditions and
+// container state changes
+func (d *PodDump) newestTime() time.Time {
+	var newest time.Time
+	observe := func(t time.Time) {
+		if t.After(newest) {
+			newest = t
+		}
+	}
+	for i := range d.Events {
+		observe(latestEventTime(&d.Events[i]))
+	}
+	for _, pod := range d.Pods {
+		observe(pod.CreationTimestamp.Time)
+		for _, condition := range pod.Status.Conditions {
+			observe(condition.LastTransitionTime.Time)
+		}
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			for _, state := range []corev1.ContainerState{status.State, status.LastTerminationState} {
+				if state.Running != nil {
+					observe(state.Running.StartedAt.Time)
+				}
+				if state.Terminated != nil {
+					observe(state.Terminated.FinishedAt.Time)
+				}
+			}
+		}
+	}
+	return newest
+}
+
+// read adds every pod and event in a YAML or JSON stream
+func (d *PodDump) read(reader io.Reader) error {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue // An empty YAML document, e.g. after a trailing ---
+		}
+		if err := d.add(raw, ""); err != nil {
+			return err
+		}
+	}
+}
+
+// add adds one object, or the items of a list. Items of a typed list may leave out their kind, so it
+// is taken from the list.
+func (d *PodDump) add(raw json.RawMessage, itemKind string) error {
+	var object dumpObject
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return err
+	}
+	kind := object.Kind
+	if kind == "" {
+		kind = itemKind
+	}
+
+	switch kind {
+	case "List", "PodList", "EventList":
+		for _, item := range object.Items {
+			if err := d.add(item, strings.TrimSuffix(kind, "List")); err != nil {
+				return err
+			}
+		}
+	case "Pod":
+		var pod corev1.Pod
+		if err := json.Unmarshal(raw, &pod); err != nil {
+			return fmt.Errorf("invalid pod: %w", err)
+		}
+		d.Pods = append(d.Pods, pod)
+	case "Event":
+		var event corev1.Event
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return fmt.Errorf("invalid event: %w", err)
+		}
+		d.Events = append(d.Events, event)
+	}
+	return nil
+}
+
+// Workloads groups the dump's pods the way the resolver groups a cluster's, but from the pods' owner
+// references alone: a Deployment is recognized from its ReplicaSet's name and the pod-template-hash
+// label. The target and filters are applied to the pods in the dump: -n only when given (there is no
+// kubeconfig namespace to fall back on), --selector, --field-selector, and a resource name, where a pod's
+// own name selects just that pod.
+func (d *PodDump) Workloads(options *types.Options) ([]types.WorkloadInfo, error) {
+	selector, err := labels.Parse(options.Selector)
+	if err != nil {
+		return nil, err
+	}
+	fieldSelector, err := fields.ParseSelector(options.FieldSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field selector %q: %w", options.FieldSelector, err)
+	}
+
+	workloads := make(map[string]*types.WorkloadInfo)
+	d.workloadPods = make(map[string][]corev1.Pod)
+	for _, pod := range d.Pods {
+		if options.Namespace != "" && !options.AllNamespaces && pod.Namespace != options.Namespace {
+			continue
+		}
+		if !selector.Matches(labels.Set(pod.Labels)) || !fieldSelector.Matches(podFields(&pod)) {
+			continue
+		}
+
+		workload := dumpOwner(&pod)
+		if options.ResourceName != "" {
+			if pod.Name == options.ResourceName && dumpKindMatches("Pod", options.ResourceType) {
+				workload = types.WorkloadInfo{Name: pod.Name, Kind: "Pod", Namespace: pod.Namespace, Labels: pod.Labels}
+			} else if workload.Name != options.ResourceName || !dumpKindMatches(workload.Kind, options.ResourceType) {
+				continue
+			}
+		}
+
+		key := workloadKey(workload)
+		if _, exists := workloads[key]; !exists {
+			workloads[key] = &workload
+		}
+		d.workloadPods[key] = append(d.workloadPods[key], pod)
+	}
+
+	if len(workloads) == 0 {
+		return nil, fmt.Errorf("no pods in the dump match")
+	}
+
+	result := make([]types.WorkloadInfo, 0, len(workloads))
+	for key, workload := range workloads {
+		pods := d.workloadPods[key]
+		ready := 0
+		for i := range pods {
+			if isPodReady(&pods[i]) {
+				ready++
+			}
+		}
+		workload.Replicas = fmt.Sprintf("%d/%d", ready, len(pods))
+		result = append(result, *workload)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// dumpOwner returns the workload a pod belongs to, judging by its controller owner reference only
+func dumpOwner(pod *corev1.Pod) types.WorkloadInfo {
+	workload := types.WorkloadInfo{Name: pod.Name, Kind: "Pod", Namespace: pod.Namespace, Labels: pod.Labels}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller == nil || !*owner.Controller {
+			continue
+		}
+		workload.Kind, workload.Name = owner.Kind, owner.Name
+		// A Deployment names its ReplicaSets <deployment>-<pod-template-hash>
+		hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+		if owner.Kind == "ReplicaSet" && hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+			workload.Kind, workload.Name = "Deployment", strings.TrimSuffix(owner.Name, "-"+hash)
+		}
+		break
+	}
+	return workload
+}
+
+// dumpKindAliases maps the short resource names kubectl accepts to kinds
+var dumpKindAliases = map[string]string{
+	"po":     "pod",
+	"deploy": "deployment",
+	"sts":    "statefulset",
+	"ds":     "daemonset",
+	"rs":     "replicaset",
+}
+
+// dumpKindMatches checks whether a resource type argument, e.g. "deploy" or "statefulsets", names the kind
+func dumpKindMatches(kind, resourceType string) bool {
+	if resourceType == "" {
+		return true
+	}
+	resourceType = strings.ToLower(resourceType)
+	if alias, ok := dumpKindAliases[resourceType]; ok {
+		resourceType = alias
+	}
+	return strings.TrimSuffix(resourceType, "s") == strings.ToLower(kind)
+}
+
+// podFields returns the pod fields the API server supports in field selectors
+func podFields(pod *corev1.Pod) fields.Set {
+	return fields.Set{
+		"metadata.name":            pod.Name,
+		"metadata.namespace":       pod.Namespace,
+		"spec.nodeName":            pod.Spec.NodeName,
+		"spec.restartPolicy":       string(pod.Spec.RestartPolicy),
+		"spec.schedulerName":       pod.Spec.SchedulerName,
+		"spec.serviceAccountName":  pod.Spec.ServiceAccountName,
+		"status.phase":             string(pod.Status.Phase),
+		"status.podIP":             pod.Status.PodIP,
+		"status.nominatedNodeName": pod.Status.NominatedNodeName,
+	}
+}
+
+// isPodReady checks the pod's Ready condition
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// pods returns the dump's pods of a workload returned by Workloads
+func (d *PodDump) pods(workload types.WorkloadInfo) []corev1.Pod {
+	return d.workloadPods[workloadKey(workload)]
+}
+
+// namespaceEvents returns the dump's events in a namespace
+func (d *PodDump) namespaceEvents(namespace string) []corev1.Event {
+	var events []corev1.Event
+	for _, event := range d.Events {
+		if event.Namespace == namespace {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// UsePodDump makes the collector read pods and events from a dump instead of the API
+func (c *Collector) UsePodDump(dump *PodDump) {
+	c.podDump = dump
+}
+
+// now returns the time ages are measured up to: the dump's reference time when reading a dump
+func (c *Collector) now() time.Time {
+	if c.podDump != nil && !c.podDump.ReferenceTime().IsZero() {
+		return c.podDump.ReferenceTime()
+	}
+	return time.Now()
+}
+
+// DumpWorkloads groups the pods of the dump set with UsePodDump into workloads, see PodDump.Workloads
+func (c *Collector) DumpWorkloads(options *types.Options) ([]types.WorkloadInfo, error) {
+	return c.podDump.Workloads(options)
+}
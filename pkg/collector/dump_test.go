@@ -0,0 +1,335 @@
+package collector
+
+import (
+	"context"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// dumpYAML is a `kubectl get pods,events -o yaml` List followed by a second document with a kindless PodList item
+const dumpYAML = `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: api-7d9f8-abcde
+    namespace: shop
+    labels: {app: api, pod-template-hash: 7d9f8}
+    ownerReferences:
+    - {apiVersion: apps/v1, kind: ReplicaSet, name: api-7d9f8, uid: "1", controller: true}
+  spec:
+    containers: [{name: app, image: "shop/api:1.4"}]
+  status:
+    phase: Running
+    conditions: [{type: Ready, status: "True"}]
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: api-7d9f8-fghij
+    namespace: shop
+    labels: {app: api, pod-template-hash: 7d9f8}
+    ownerReferences:
+    - {apiVersion: apps/v1, kind: ReplicaSet, name: api-7d9f8, uid: "1", controller: true}
+  spec:
+    containers: [{name: app, image: "shop/api:1.4"}]
+  status:
+    phase: Failed
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: db-0
+    namespace: shop
+    labels: {app: db}
+    ownerReferences:
+    - {apiVersion: apps/v1, kind: StatefulSet, name: db, uid: "2", controller: true}
+  spec:
+    containers: [{name: postgres, image: "postgres:16"}]
+  status:
+    phase: Running
+- apiVersion: v1
+  kind: Event
+  metadata: {name: api-7d9f8-fghij.1, namespace: shop}
+  involvedObject: {kind: Pod, name: api-7d9f8-fghij, namespace: shop}
+  type: Warning
+  reason: BackOff
+  message: Back-off restarting failed container app
+  lastTimestamp: "2024-05-01T10:00:00Z"
+- apiVersion: v1
+  kind: Event
+  metadata: {name: api-7d9f8-fghij.2, namespace: shop}
+  involvedObject: {kind: Pod, name: api-7d9f8-fghij, namespace: shop}
+  type: Normal
+  reason: Pulled
+  message: Image pulled hours before the crash
+  lastTimestamp: "2024-05-01T07:00:00Z"
+- apiVersion: v1
+  kind: ConfigMap
+  metadata: {name: ignored, namespace: shop}
+---
+kind: PodList
+apiVersion: v1
+items:
+- metadata:
+    name: debug
+    namespace: tools
+  spec:
+    containers: [{name: shell, image: busybox}]
+  status:
+    phase: Succeeded
+`
+
+func TestLoadPodDump(t *testing.T) {
+	dump, err := LoadPodDump([]string{writeSnapshot(t, "dump.yaml", dumpYAML)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, pod := range dump.Pods {
+		names = append(names, pod.Name)
+	}
+	if expected := []string{"api-7d9f8-abcde", "api-7d9f8-fghij", "db-0", "debug"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected pods %v, got %v", expected, names)
+	}
+	if len(dump.Events) != 2 {
+		t.Errorf("expected 2 events, got %d", len(dump.Events))
+	}
+
+	jsonPath := writeSnapshot(t, "pod.json", `{"kind": "Pod", "apiVersion": "v1", "metadata": {"name": "web", "namespace": "default"}}`)
+	if dump, err := LoadPodDump([]string{jsonPath}); err != nil || len(dump.Pods) != 1 {
+		t.Errorf("expected one pod from a JSON object, got %v (%v)", dump, err)
+	}
+
+	for name, content := range map[string]string{
+		"no pods":    "kind: EventList\napiVersion: v1\nitems: []\n",
+		"not a dump": "kind: [Pod\n",
+	} {
+		if _, err := LoadPodDump([]string{writeSnapshot(t, "bad.yaml", content)}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPodDumpWorkloads(t *testing.T) {
+	dump, err := LoadPodDump([]string{writeSnapshot(t, "dump.yaml", dumpYAML)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		options  types.Options
+		expected []string // kind/namespace/name replicas
+	}{
+		{
+			name:     "everything",
+			expected: []string{"Deployment/shop/api 1/2", "StatefulSet/shop/db 0/1", "Pod/tools/debug 0/1"},
+		},
+		{
+			name:     "namespace",
+			options:  types.Options{Namespace: "tools"},
+			expected: []string{"Pod/tools/debug 0/1"},
+		},
+		{
+			name:     "workload by type and name",
+			options:  types.Options{ResourceType: "deploy", ResourceName: "api"},
+			expected: []string{"Deployment/shop/api 1/2"},
+		},
+		{
+			name:     "pod of a workload by name",
+			options:  types.Options{ResourceName: "api-7d9f8-fghij"},
+			expected: []string{"Pod/shop/api-7d9f8-fghij 0/1"},
+		},
+		{
+			name:     "selector and field selector",
+			options:  types.Options{Selector: "app=api", FieldSelector: "status.phase!=Failed"},
+			expected: []string{"Deployment/shop/api 1/1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workloads, err := dump.Workloads(&tt.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, workload := range workloads {
+				got = append(got, workload.Kind+"/"+workload.Namespace+"/"+workload.Name+" "+workload.Replicas)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := dump.Workloads(&types.Options{ResourceType: "statefulset", ResourceName: "api"}); err == nil {
+		t.Error("expected an error when nothing in the dump matches")
+	}
+}
+
+func TestCollectPodsFromDump(t *testing.T) {
+	// The dump was written a few minutes after its last event
+	path := writeSnapshot(t, "dump.yaml", dumpYAML)
+	taken := time.Date(2024, 5, 1, 10, 5, 0, 0, time.UTC)
+	if err := os.Chtimes(path, taken, taken); err != nil {
+		t.Fatal(err)
+	}
+	dump, err := LoadPodDump([]string{path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c := New(nil, nil)
+	c.SetWarningOutput(io.Discard)
+	c.UsePodDump(dump)
+
+	options := &types.Options{ResourceName: "api", ShowEvents: true, ShowResourceUsage: true}
+	workloads, err := c.DumpWorkloads(options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pods, err := c.CollectPods(context.Background(), workloads[0], options)
+	if err != nil {
+		t.Fatalf("CollectPods() failed: %v", err)
+	}
+	if len(pods) != 2 {
+		t.Fatalf("expected 2 pods, got %d", len(pods))
+	}
+
+	// The dump is old, so the last hour of events is counted back from when it was taken
+	var failed types.PodInfo
+	for _, pod := range pods {
+		if pod.Name == "api-7d9f8-fghij" {
+			failed = pod
+		}
+	}
+	if failed.Status != "Failed" || len(failed.Events) != 1 || failed.Events[0].Reason != "BackOff" {
+		t.Errorf("expected the failed pod with its BackOff event, got %s with %+v", failed.Status, failed.Events)
+	}
+	if age := time.Since(failed.Events[0].Time); age < 24*time.Hour {
+		t.Errorf("expected the event to keep its own time, got one %s old", age)
+	}
+
+	// A dump has no usage of its own, only what a metrics snapshot adds
+	if !c.MetricsUnavailable() {
+		t.Error("expected metrics to be unavailable for a dump without a metrics snapshot")
+	}
+	withUsage := New(nil, nil)
+	withUsage.UsePodDump(dump)
+	withUsage.UseMetricsSnapshot(&MetricsSnapshot{})
+	if withUsage.MetricsUnavailable() {
+		t.Error("expected metrics to be available once a metrics snapshot is given")
+	}
+}
+
+// oldDumpYAML was taken at 12:01, right after its newest event, on a day long past
+const oldDumpYAML = `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: worker-new
+    namespace: jobs
+    creationTimestamp: "2020-01-01T11:58:00Z"
+  spec:
+    containers: [{name: app, image: "worker:2"}]
+  status:
+    phase: Pending
+    containerStatuses:
+    - {name: app, image: "worker:2", ready: false, restartCount: 0, state: {waiting: {reason: ContainerCreating}}}
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: worker-old
+    namespace: jobs
+    creationTimestamp: "2020-01-01T10:00:00Z"
+    deletionTimestamp: "2020-01-01T12:00:30Z"
+    deletionGracePeriodSeconds: 30
+  spec:
+    containers: [{name: app, image: "worker:1"}]
+  status:
+    phase: Running
+    conditions: [{type: Ready, status: "True", lastTransitionTime: "2020-01-01T11:59:30Z"}]
+    containerStatuses:
+    - {name: app, image: "worker:1", ready: true, restartCount: 2, state: {running: {startedAt: "2020-01-01T11:59:00Z"}}}
+- apiVersion: v1
+  kind: Event
+  metadata: {name: worker-old.1, namespace: jobs}
+  involvedObject: {kind: Pod, name: worker-old, namespace: jobs}
+  type: Normal
+  reason: Killing
+  message: Stopping container app
+  lastTimestamp: "2020-01-01T12:01:00Z"
+`
+
+func TestPodDumpReferenceTime(t *testing.T) {
+	path := writeSnapshot(t, "old.yaml", oldDumpYAML)
+	newest := time.Date(2020, 1, 1, 12, 1, 0, 0, time.UTC)
+
+	// A file modified before its newest timestamp has an unreliable time, so the timestamp is used
+	if err := os.Chtimes(path, newest, newest.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	dump, err := LoadPodDump([]string{path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dump.ReferenceTime().Equal(newest) {
+		t.Errorf("expected the newest event time %s as the reference, got %s", newest, dump.ReferenceTime())
+	}
+
+	// Otherwise the dump was taken when the file was written, which can be well after the last event
+	taken := newest.Add(30 * time.Minute)
+	if err := os.Chtimes(path, taken, taken); err != nil {
+		t.Fatal(err)
+	}
+	dump, err = LoadPodDump([]string{path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dump.ReferenceTime().Equal(taken) {
+		t.Errorf("expected the file modification time %s as the reference, got %s", taken, dump.ReferenceTime())
+	}
+
+	// --dump-time overrides both
+	dump.SetReferenceTime(newest)
+
+	c := New(nil, nil)
+	c.SetWarningOutput(io.Discard)
+	c.UsePodDump(dump)
+	options := &types.Options{ShowEvents: true}
+	workloads, err := c.DumpWorkloads(options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pods := make(map[string]types.PodInfo)
+	for _, workload := range workloads {
+		collected, err := c.CollectPods(context.Background(), workload, options)
+		if err != nil {
+			t.Fatalf("CollectPods() failed: %v", err)
+		}
+		for _, pod := range collected {
+			pods[pod.Name] = pod
+		}
+	}
+
+	// Ages count up to when the dump was taken, not to now
+	if age := pods["worker-new"].Age; age != 3*time.Minute {
+		t.Errorf("expected worker-new to be 3m old in the dump, got %s", age)
+	}
+	if age := pods["worker-old"].Age; age != 2*time.Hour+time.Minute {
+		t.Errorf("expected worker-old to be 2h1m old in the dump, got %s", age)
+	}
+	if terminating := pods["worker-old"].TerminatingFor; terminating != time.Minute {
+		t.Errorf("expected worker-old to have been terminating for 1m, got %s", terminating)
+	}
+	if events := pods["worker-old"].Events; len(events) != 1 || events[0].Reason != "Killing" {
+		t.Errorf("expected the Killing event within the last hour of the dump, got %+v", events)
+	}
+}
@@ -0,0 +1,35 @@
+package collector
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ForbiddenError explains an API call denied by RBAC in terms of what was being attempted,
+// e.g. "you don't have permission to list pods in namespace prod"
+type ForbiddenError struct {
+	Verb      string // e.g. list, get
+	Resource  string // e.g. pods, deployments
+	Namespace string // Empty for a request across all namespaces
+	Err       error  // The API server's Forbidden error
+}
+
+func (e *ForbiddenError) Error() string {
+	if e.Namespace == "" {
+		return fmt.Sprintf("you don't have permission to %s %s across all namespaces", e.Verb, e.Resource)
+	}
+	return fmt.Sprintf("you don't have permission to %s %s in namespace %s", e.Verb, e.Resource, e.Namespace)
+}
+
+func (e *ForbiddenError) Unwrap() error {
+	return e.Err
+}
+
+// PodListError wraps a failed pod list, explaining a Forbidden response as a missing permission
+func PodListError(err error, namespace string) error {
+	if apierrors.IsForbidden(err) {
+		return &ForbiddenError{Verb: "list", Resource: "pods", Namespace: namespace, Err: err}
+	}
+	return fmt.Errorf("failed to list pods: %w", err)
+}
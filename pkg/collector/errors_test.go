@@ -0,0 +1,167 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// forbid makes every request for resource fail the way a scoped RBAC role does
+func forbid(clientset *fake.Clientset, verb, resource string) {
+	clientset.PrependReactor(verb, resource, func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: resource}, "", errors.New("RBAC: access denied"))
+	})
+}
+
+func TestCollectPodsWithForbiddenEvents(t *testing.T) {
+	var pods []runtime.Object
+	for _, name := range []string{"web-1", "web-2"} {
+		pods = append(pods, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:1"}}},
+		})
+	}
+	clientset := fake.NewSimpleClientset(pods...)
+	forbid(clientset, "list", "events")
+
+	var warnings bytes.Buffer
+	c := New(clientset, nil)
+	c.SetWarningOutput(&warnings)
+
+	workload := types.WorkloadInfo{Name: "web", Kind: "Deployment", Namespace: "default", Selector: map[string]string{"app": "web"}}
+	collected, err := c.CollectPods(context.Background(), workload, &types.Options{ShowEvents: true})
+	if err != nil {
+		t.Fatalf("expected collection to continue without events, got %v", err)
+	}
+	if len(collected) != 2 {
+		t.Errorf("expected 2 pods, got %d", len(collected))
+	}
+	if !c.EventsForbidden() {
+		t.Error("expected EventsForbidden to be recorded")
+	}
+	if warnings.Len() != 0 {
+		t.Errorf("expected no warnings for forbidden events, got %q", warnings.String())
+	}
+}
+
+func TestCollectPodsWithForbiddenPods(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	forbid(clientset, "list", "pods")
+
+	c := New(clientset, nil)
+	workload := types.WorkloadInfo{Name: "web", Kind: "Deployment", Namespace: "shop", Selector: map[string]string{"app": "web"}}
+	_, err := c.CollectPods(context.Background(), workload, &types.Options{})
+
+	var forbidden *ForbiddenError
+	if !errors.As(err, &forbidden) {
+		t.Fatalf("expected a ForbiddenError, got %v", err)
+	}
+	if err.Error() != "you don't have permission to list pods in namespace shop" {
+		t.Errorf("unexpected message: %q", err.Error())
+	}
+	if !apierrors.IsForbidden(err) {
+		t.Error("expected the API error to remain inspectable")
+	}
+
+	if got := PodListError(forbidden.Err, "").Error(); got != "you don't have permission to list pods across all namespaces" {
+		t.Errorf("unexpected all-namespaces message: %q", got)
+	}
+	if got := PodListError(errors.New("timeout"), "shop").Error(); got != "failed to list pods: timeout" {
+		t.Errorf("unexpected message for other errors: %q", got)
+	}
+}
+
+func TestDeferredWarnings(t *testing.T) {
+	var output bytes.Buffer
+	c := New(fake.NewSimpleClientset(), nil)
+	c.SetWarningOutput(&output)
+	c.DeferWarnings()
+
+	// Pods are collected concurrently, so warnings arrive from many goroutines at once
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.warnf("Failed to collect logs for container app-%d: %v", i%10, errors.New("timeout"))
+		}(i)
+	}
+	wg.Wait()
+
+	warnings := c.TakeWarnings()
+	if len(warnings) != 10 {
+		t.Errorf("expected 10 distinct warnings, got %d: %v", len(warnings), warnings)
+	}
+	if output.Len() != 0 {
+		t.Errorf("expected deferred warnings not to be printed, got %q", output.String())
+	}
+	if again := c.TakeWarnings(); len(again) != 0 {
+		t.Errorf("expected TakeWarnings to drain the warnings, got %v", again)
+	}
+}
+
+func TestKeepCollectedPodsSkipsFailedPods(t *testing.T) {
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-3"}},
+	}
+	broken := context.DeadlineExceeded
+	noLogs := errors.New("failed to collect logs for container app: failed to get logs: connection refused")
+
+	var warnings bytes.Buffer
+	c := New(fake.NewSimpleClientset(), nil)
+	c.SetWarningOutput(&warnings)
+
+	collected, err := c.keepCollectedPods(pods,
+		[]*types.PodInfo{{Name: "web-1"}, nil, {Name: "web-3"}},
+		[]error{nil, broken, noLogs})
+	if err != nil {
+		t.Fatalf("expected the collected pods to be kept, got %v", err)
+	}
+	if len(collected) != 2 || collected[0].Name != "web-1" || collected[1].Name != "web-3" {
+		t.Errorf("expected web-1 and web-3 in order, got %+v", collected)
+	}
+	if !strings.Contains(warnings.String(), "Skipping pod web-2: context deadline exceeded") {
+		t.Errorf("expected a warning naming the skipped pod, got %q", warnings.String())
+	}
+	if !strings.Contains(warnings.String(), "Pod web-3: failed to collect logs for container app") {
+		t.Errorf("expected a warning about the partly collected pod, got %q", warnings.String())
+	}
+
+	_, err = c.keepCollectedPods(pods[:1], []*types.PodInfo{nil}, []error{broken})
+	if err == nil || !errors.Is(err, broken) {
+		t.Errorf("expected an error wrapping the pod's failure when nothing was collected, got %v", err)
+	}
+}
+
+func TestCollectPodsAfterCancel(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:1"}}},
+	})
+	var warnings bytes.Buffer
+	c := New(clientset, nil)
+	c.SetWarningOutput(&warnings)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	workload := types.WorkloadInfo{Name: "web", Kind: "Deployment", Namespace: "default", Selector: map[string]string{"app": "web"}}
+	_, err := c.CollectPods(ctx, workload, &types.Options{})
+	if err == nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancellation to be reported, got %v", err)
+	}
+}
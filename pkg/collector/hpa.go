@@ -0,0 +1,125 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// CollectHPAs returns the HorizontalPodAutoscalers in the workload's namespace whose scaleTargetRef
+// points at the workload, which is what explains replica counts that change on their own
+func (c *Collector) CollectHPAs(ctx context.Context, workload types.WorkloadInfo) ([]types.HPAInfo, error) {
+	hpas, err := c.clientset.AutoscalingV2().HorizontalPodAutoscalers(workload.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list horizontal pod autoscalers: %w", err)
+	}
+
+	var matched []types.HPAInfo
+	for _, hpa := range hpas.Items {
+		target := hpa.Spec.ScaleTargetRef
+		if !strings.EqualFold(target.Kind, workload.Kind) || target.Name != workload.Name {
+			continue
+		}
+
+		info := types.HPAInfo{
+			Name:            hpa.Name,
+			CurrentReplicas: hpa.Status.CurrentReplicas,
+			DesiredReplicas: hpa.Status.DesiredReplicas,
+			MinReplicas:     1,
+			MaxReplicas:     hpa.Spec.MaxReplicas,
+		}
+		if hpa.Spec.MinReplicas != nil {
+			info.MinReplicas = *hpa.Spec.MinReplicas
+		}
+		for _, spec := range hpa.Spec.Metrics {
+			info.Metrics = append(info.Metrics, hpaMetric(spec, hpa.Status.CurrentMetrics))
+		}
+		matched = append(matched, info)
+	}
+	return matched, nil
+}
+
+// hpaMetric pairs a metric's target with the autoscaler's last reading of it
+func hpaMetric(spec autoscalingv2.MetricSpec, statuses []autoscalingv2.MetricStatus) types.HPAMetric {
+	metric := types.HPAMetric{Current: "<unknown>"}
+
+	var target autoscalingv2.MetricTarget
+	switch {
+	case spec.Resource != nil:
+		metric.Name = string(spec.Resource.Name)
+		target = spec.Resource.Target
+	case spec.ContainerResource != nil:
+		metric.Name = fmt.Sprintf("%s (%s)", spec.ContainerResource.Name, spec.ContainerResource.Container)
+		target = spec.ContainerResource.Target
+	case spec.Pods != nil:
+		metric.Name = spec.Pods.Metric.Name
+		target = spec.Pods.Target
+	case spec.Object != nil:
+		metric.Name = spec.Object.Metric.Name
+		target = spec.Object.Target
+	case spec.External != nil:
+		metric.Name = spec.External.Metric.Name
+		target = spec.External.Target
+	default:
+		metric.Name = string(spec.Type)
+	}
+	metric.Target = formatMetricTarget(target)
+
+	for _, status := range statuses {
+		if status.Type != spec.Type {
+			continue
+		}
+		var current *autoscalingv2.MetricValueStatus
+		switch {
+		case status.Resource != nil && spec.Resource != nil && status.Resource.Name == spec.Resource.Name:
+			current = &status.Resource.Current
+		case status.ContainerResource != nil && spec.ContainerResource != nil &&
+			status.ContainerResource.Name == spec.ContainerResource.Name && status.ContainerResource.Container == spec.ContainerResource.Container:
+			current = &status.ContainerResource.Current
+		case status.Pods != nil && spec.Pods != nil && status.Pods.Metric.Name == spec.Pods.Metric.Name:
+			current = &status.Pods.Current
+		case status.Object != nil && spec.Object != nil && status.Object.Metric.Name == spec.Object.Metric.Name:
+			current = &status.Object.Current
+		case status.External != nil && spec.External != nil && status.External.Metric.Name == spec.External.Metric.Name:
+			current = &status.External.Current
+		}
+		if current != nil {
+			metric.Current = formatMetricValue(*current)
+			break
+		}
+	}
+	return metric
+}
+
+// formatMetricTarget formats a target as a utilization percentage or a quantity
+func formatMetricTarget(target autoscalingv2.MetricTarget) string {
+	switch {
+	case target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *target.AverageUtilization)
+	case target.AverageValue != nil:
+		return target.AverageValue.String()
+	case target.Value != nil:
+		return target.Value.String()
+	default:
+		return "<unset>"
+	}
+}
+
+// formatMetricValue formats a reading the same way as its target
+func formatMetricValue(value autoscalingv2.MetricValueStatus) string {
+	switch {
+	case value.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *value.AverageUtilization)
+	case value.AverageValue != nil:
+		return value.AverageValue.String()
+	case value.Value != nil:
+		return value.Value.String()
+	default:
+		return "<unknown>"
+	}
+}
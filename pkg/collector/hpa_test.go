@@ -0,0 +1,84 @@
+package collector
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestCollectHPAs(t *testing.T) {
+	int32Ptr := func(v int32) *int32 { return &v }
+	hpa := func(name, kind, target string) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: kind, Name: target},
+				MinReplicas:    int32Ptr(2),
+				MaxReplicas:    10,
+			},
+		}
+	}
+
+	api := hpa("api", "Deployment", "api")
+	api.Spec.Metrics = []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name:   corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: int32Ptr(70)},
+			},
+		},
+		{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "requests_per_second"},
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: resource.NewQuantity(100, resource.DecimalSI)},
+			},
+		},
+	}
+	api.Status = autoscalingv2.HorizontalPodAutoscalerStatus{
+		CurrentReplicas: 3,
+		DesiredReplicas: 5,
+		CurrentMetrics: []autoscalingv2.MetricStatus{{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricStatus{
+				Name:    corev1.ResourceCPU,
+				Current: autoscalingv2.MetricValueStatus{AverageUtilization: int32Ptr(85)},
+			},
+		}},
+	}
+
+	clientset := fake.NewSimpleClientset(
+		api,
+		hpa("worker", "Deployment", "worker"),
+		hpa("api-statefulset", "StatefulSet", "api"),
+	)
+
+	hpas, err := New(clientset, nil).CollectHPAs(context.Background(), types.WorkloadInfo{Kind: "Deployment", Name: "api", Namespace: "default"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []types.HPAInfo{{
+		Name:            "api",
+		CurrentReplicas: 3,
+		DesiredReplicas: 5,
+		MinReplicas:     2,
+		MaxReplicas:     10,
+		Metrics: []types.HPAMetric{
+			{Name: "cpu", Current: "85%", Target: "70%"},
+			{Name: "requests_per_second", Current: "<unknown>", Target: "100"},
+		},
+	}}
+	if !reflect.DeepEqual(hpas, expected) {
+		t.Errorf("CollectHPAs() =\n%+v\nwant\n%+v", hpas, expected)
+	}
+}
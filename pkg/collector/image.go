@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"strings"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// defaultRegistry is where images without a registry host are pulled from
+const defaultRegistry = "docker.io"
+
+// parseImageRef splits an image reference like registry:port/org/repo:tag@sha256:... into its parts,
+// applying the same defaults as the container runtime: docker.io for bare names, library/ for
+// official images and the latest tag when neither a tag nor a digest is given
+func parseImageRef(image string) types.ImageRef {
+	var ref types.ImageRef
+
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+	}
+
+	// A colon after the last slash starts the tag; earlier colons belong to a registry port
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+
+	// The first component is a registry host only if it looks like one
+	ref.Registry = defaultRegistry
+	if i := strings.Index(name, "/"); i >= 0 {
+		host := name[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.Registry = host
+			name = name[i+1:]
+		}
+	}
+	if ref.Registry == "index.docker.io" {
+		ref.Registry = defaultRegistry
+	}
+	if ref.Registry == defaultRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	ref.Repository = name
+
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+	return ref
+}
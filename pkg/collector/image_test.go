@@ -0,0 +1,35 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestParseImageRef(t *testing.T) {
+	digest := "sha256:4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac"
+
+	tests := []struct {
+		image    string
+		expected types.ImageRef
+	}{
+		{"nginx", types.ImageRef{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"}},
+		{"nginx:1.25", types.ImageRef{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"}},
+		{"bitnami/redis:7.2", types.ImageRef{Registry: "docker.io", Repository: "bitnami/redis", Tag: "7.2"}},
+		{"docker.io/library/busybox", types.ImageRef{Registry: "docker.io", Repository: "library/busybox", Tag: "latest"}},
+		{"index.docker.io/nginx:1.25", types.ImageRef{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"}},
+		{"gcr.io/google-containers/pause:3.9", types.ImageRef{Registry: "gcr.io", Repository: "google-containers/pause", Tag: "3.9"}},
+		{"registry.example.com:5000/org/team/app:v1.2.3", types.ImageRef{Registry: "registry.example.com:5000", Repository: "org/team/app", Tag: "v1.2.3"}},
+		{"localhost/app", types.ImageRef{Registry: "localhost", Repository: "app", Tag: "latest"}},
+		{"localhost:5000/app@" + digest, types.ImageRef{Registry: "localhost:5000", Repository: "app", Digest: digest}},
+		{"quay.io/prometheus/node-exporter:v1.7.0@" + digest, types.ImageRef{Registry: "quay.io", Repository: "prometheus/node-exporter", Tag: "v1.7.0", Digest: digest}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := parseImageRef(tt.image); got != tt.expected {
+				t.Errorf("parseImageRef(%q) = %+v, expected %+v", tt.image, got, tt.expected)
+			}
+		})
+	}
+}
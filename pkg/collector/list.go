@@ -0,0 +1,34 @@
+package collector
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodLister is the part of the pods client that ListPods needs
+type PodLister interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*corev1.PodList, error)
+}
+
+// ListPods lists pods in pages of at most chunkSize items, following continue tokens until the
+// list is complete. Paging keeps very large namespaces from producing one huge response that can
+// time out on the API server. A chunkSize of 0 fetches everything in a single request.
+func ListPods(ctx context.Context, lister PodLister, listOptions metav1.ListOptions, chunkSize int64) ([]corev1.Pod, error) {
+	listOptions.Limit = chunkSize
+
+	var pods []corev1.Pod
+	for {
+		podList, err := lister.List(ctx, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, podList.Items...)
+
+		if podList.Continue == "" {
+			return pods, nil
+		}
+		listOptions.Continue = podList.Continue
+	}
+}
@@ -0,0 +1,81 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// pagingLister serves a fixed number of pods in pages, using the next offset as the continue token
+type pagingLister struct {
+	total    int
+	requests []metav1.ListOptions
+}
+
+func (l *pagingLister) List(ctx context.Context, opts metav1.ListOptions) (*corev1.PodList, error) {
+	l.requests = append(l.requests, opts)
+
+	start := 0
+	if opts.Continue != "" {
+		start, _ = strconv.Atoi(opts.Continue)
+	}
+	end := l.total
+	if opts.Limit > 0 && start+int(opts.Limit) < end {
+		end = start + int(opts.Limit)
+	}
+
+	list := &corev1.PodList{}
+	for i := start; i < end; i++ {
+		list.Items = append(list.Items, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i)}})
+	}
+	if end < l.total {
+		list.Continue = strconv.Itoa(end)
+	}
+	return list, nil
+}
+
+func TestListPodsConsumesAllPages(t *testing.T) {
+	lister := &pagingLister{total: 7}
+
+	pods, err := ListPods(context.Background(), lister, metav1.ListOptions{LabelSelector: "app=web"}, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(lister.requests) != 3 {
+		t.Errorf("expected 3 page requests, got %d", len(lister.requests))
+	}
+	for _, request := range lister.requests {
+		if request.Limit != 3 {
+			t.Errorf("expected limit 3 on every page, got %d", request.Limit)
+		}
+		if request.LabelSelector != "app=web" {
+			t.Errorf("expected the label selector on every page, got %q", request.LabelSelector)
+		}
+	}
+
+	if len(pods) != lister.total {
+		t.Fatalf("expected %d pods across all pages, got %d", lister.total, len(pods))
+	}
+	for i, pod := range pods {
+		if want := fmt.Sprintf("pod-%d", i); pod.Name != want {
+			t.Errorf("position %d: expected %s, got %s", i, want, pod.Name)
+		}
+	}
+}
+
+func TestListPodsWithoutPaging(t *testing.T) {
+	lister := &pagingLister{total: 7}
+
+	pods, err := ListPods(context.Background(), lister, metav1.ListOptions{}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(lister.requests) != 1 || len(pods) != lister.total {
+		t.Errorf("expected all %d pods in 1 request, got %d pods in %d requests", lister.total, len(pods), len(lister.requests))
+	}
+}
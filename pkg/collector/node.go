@@ -0,0 +1,123 @@
+package collector
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// nodeLookup is a node fetched, or still being fetched, for the current collection
+type nodeLookup struct {
+	done chan struct{} // Closed once node and err are set
+	node *corev1.Node
+	err  error
+}
+
+// getNode fetches a node once per collection, since every pod on it needs the same allocatable figures.
+// A failure is remembered as well, and the lock is only held to find the lookup, so pods on other nodes
+// don't wait for this one.
+func (c *Collector) getNode(ctx context.Context, name string) (*corev1.Node, error) {
+	c.nodeCacheMu.Lock()
+	lookup, started := c.nodeCache[name]
+	if !started {
+		if c.nodeCache == nil {
+			c.nodeCache = make(map[string]*nodeLookup)
+		}
+		lookup = &nodeLookup{done: make(chan struct{})}
+		c.nodeCache[name] = lookup
+	}
+	c.nodeCacheMu.Unlock()
+
+	if !started {
+		lookup.node, lookup.err = c.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		close(lookup.done)
+	}
+	select {
+	case <-lookup.done:
+		return lookup.node, lookup.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Reset forgets the nodes fetched so far, so that polling modes see their current conditions each round
+func (c *Collector) Reset() {
+	c.nodeCacheMu.Lock()
+	defer c.nodeCacheMu.Unlock()
+	c.nodeCache = nil
+}
+
+// collectNodeContext compares the pod's usage with its node's allocatable CPU and memory, which shows
+// whether the node itself is the bottleneck. It warns and returns nil when the node can't be read.
+func (c *Collector) collectNodeContext(ctx context.Context, pod *corev1.Pod, podMetrics *types.PodMetrics) *types.NodeUsageInfo {
+	if pod.Spec.NodeName == "" {
+		return nil
+	}
+	node, err := c.getNode(ctx, pod.Spec.NodeName)
+	if err != nil {
+		c.warnf("Failed to get node %s: %v", pod.Spec.NodeName, err)
+		return nil
+	}
+	return c.nodeUsage(node, podMetrics)
+}
+
+// collectNodeProblems lists the unhealthy conditions of the pod's node, so pod symptoms can be traced back
+// to the node. It warns and returns nil when the node can't be read.
+func (c *Collector) collectNodeProblems(ctx context.Context, pod *corev1.Pod) []string {
+	if pod.Spec.NodeName == "" {
+		return nil
+	}
+	node, err := c.getNode(ctx, pod.Spec.NodeName)
+	if err != nil {
+		c.warnf("Failed to get node %s: %v", pod.Spec.NodeName, err)
+		return nil
+	}
+	return nodeProblems(node)
+}
+
+// nodeProblems returns "NotReady" unless the node's Ready condition is True, followed by every other
+// condition that is True, such as MemoryPressure, DiskPressure, PIDPressure or NetworkUnavailable
+func nodeProblems(node *corev1.Node) []string {
+	var problems []string
+	ready := false
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			ready = condition.Status == corev1.ConditionTrue
+			continue
+		}
+		if condition.Status == corev1.ConditionTrue {
+			problems = append(problems, string(condition.Type))
+		}
+	}
+	if !ready {
+		problems = append([]string{"NotReady"}, problems...)
+	}
+	return problems
+}
+
+// nodeUsage computes the pod's share of the node's allocatable capacity
+func (c *Collector) nodeUsage(node *corev1.Node, podMetrics *types.PodMetrics) *types.NodeUsageInfo {
+	cpu := node.Status.Allocatable.Cpu()
+	memory := node.Status.Allocatable.Memory()
+
+	usage := &types.NodeUsageInfo{
+		NodeName:       node.Name,
+		CPUAllocatable: c.formatCPUUsage(cpu.String()),
+		MemAllocatable: c.formatMemoryUsage(memory.String()),
+	}
+	if podMetrics == nil {
+		return usage
+	}
+
+	usage.HasUsage = true
+	if milli := cpu.MilliValue(); milli > 0 {
+		usage.CPUPercentage = float64(podMetrics.CPUUsageMilli) / float64(milli) * 100
+	}
+	if bytes := memory.Value(); bytes > 0 {
+		usage.MemPercentage = float64(podMetrics.MemoryUsageBytes) / float64(bytes) * 100
+	}
+	return usage
+}
@@ -0,0 +1,152 @@
+package collector
+
+import (
+	"context"
+	"io"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestCollectNodeContext(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+		},
+	}
+	clientset := fake.NewSimpleClientset(node)
+	c := New(clientset, nil)
+	c.SetWarningOutput(io.Discard)
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{NodeName: "node-1"}}
+	metrics := &types.PodMetrics{CPUUsageMilli: 1000, MemoryUsageBytes: 4 * 1024 * 1024 * 1024}
+
+	usage := c.collectNodeContext(context.Background(), pod, metrics)
+	if usage == nil {
+		t.Fatal("expected node usage")
+	}
+	if usage.NodeName != "node-1" || usage.CPUAllocatable != "4.0" || usage.MemAllocatable != "16.0Gi" {
+		t.Errorf("unexpected allocatable: %+v", usage)
+	}
+	if !usage.HasUsage || math.Abs(usage.CPUPercentage-25) > 0.01 || math.Abs(usage.MemPercentage-25) > 0.01 {
+		t.Errorf("expected 25%% of CPU and memory, got %+v", usage)
+	}
+
+	// A second pod on the same node reuses the cached node instead of another API call
+	nodeGets := func() int {
+		gets := 0
+		for _, action := range clientset.Actions() {
+			if action.GetVerb() == "get" && action.GetResource().Resource == "nodes" {
+				gets++
+			}
+		}
+		return gets
+	}
+	c.collectNodeContext(context.Background(), pod, metrics)
+	if gets := nodeGets(); gets != 1 {
+		t.Errorf("expected the node to be fetched once, got %d gets", gets)
+	}
+
+	if usage := c.collectNodeContext(context.Background(), pod, nil); usage.HasUsage {
+		t.Errorf("expected no usage without pod metrics, got %+v", usage)
+	}
+	if usage := c.collectNodeContext(context.Background(), &corev1.Pod{Spec: corev1.PodSpec{NodeName: "missing"}}, metrics); usage != nil {
+		t.Errorf("expected nil for a node that can't be read, got %+v", usage)
+	}
+	if usage := c.collectNodeContext(context.Background(), &corev1.Pod{}, metrics); usage != nil {
+		t.Errorf("expected nil for an unscheduled pod, got %+v", usage)
+	}
+}
+
+func TestCollectNodeProblems(t *testing.T) {
+	node := func(name string, conditions ...corev1.NodeCondition) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: corev1.NodeStatus{Conditions: conditions}}
+	}
+	ready := corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue}
+	notReady := corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionUnknown}
+	memoryPressure := corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue}
+	noDiskPressure := corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse}
+
+	c := New(fake.NewSimpleClientset(
+		node("healthy", ready, noDiskPressure),
+		node("pressured", ready, memoryPressure, noDiskPressure),
+		node("lost", notReady, memoryPressure),
+	), nil)
+	c.SetWarningOutput(io.Discard)
+
+	tests := []struct {
+		node     string
+		expected []string
+	}{
+		{node: "healthy"},
+		{node: "pressured", expected: []string{"MemoryPressure"}},
+		{node: "lost", expected: []string{"NotReady", "MemoryPressure"}},
+		{node: ""},
+		{node: "missing"},
+	}
+	for _, tt := range tests {
+		pod := &corev1.Pod{Spec: corev1.PodSpec{NodeName: tt.node}}
+		if problems := c.collectNodeProblems(context.Background(), pod); !reflect.DeepEqual(problems, tt.expected) {
+			t.Errorf("node %q: expected problems %v, got %v", tt.node, tt.expected, problems)
+		}
+	}
+}
+
+func TestGetNodeCachesFailures(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+	c := New(clientset, nil)
+	c.SetWarningOutput(io.Discard)
+
+	nodeGets := func(name string) int {
+		gets := 0
+		for _, action := range clientset.Actions() {
+			if get, ok := action.(k8stesting.GetAction); ok && get.GetResource().Resource == "nodes" && get.GetName() == name {
+				gets++
+			}
+		}
+		return gets
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.getNode(context.Background(), "missing"); err == nil {
+				t.Error("expected an error for a missing node")
+			}
+			if _, err := c.getNode(context.Background(), "node-1"); err != nil {
+				t.Errorf("expected node-1, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if gets := nodeGets("missing"); gets != 1 {
+		t.Errorf("expected the failed lookup to be made once, got %d gets", gets)
+	}
+	if gets := nodeGets("node-1"); gets != 1 {
+		t.Errorf("expected node-1 to be fetched once, got %d gets", gets)
+	}
+
+	// The next polling round fetches the node again
+	c.Reset()
+	if _, err := c.getNode(context.Background(), "node-1"); err != nil {
+		t.Fatalf("expected node-1 after reset, got %v", err)
+	}
+	if gets := nodeGets("node-1"); gets != 2 {
+		t.Errorf("expected node-1 to be fetched again after Reset, got %d gets", gets)
+	}
+}
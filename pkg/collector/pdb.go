@@ -0,0 +1,41 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// CollectPDBs returns the PodDisruptionBudgets in the workload's namespace that select any of its pods,
+// which is what decides whether evictions and node drains can proceed
+func (c *Collector) CollectPDBs(ctx context.Context, workload types.WorkloadInfo) ([]types.PDBInfo, error) {
+	pdbs, err := c.clientset.PolicyV1().PodDisruptionBudgets(workload.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod disruption budgets: %w", err)
+	}
+
+	var matched []types.PDBInfo
+	for _, pdb := range pdbs.Items {
+		// A nil selector matches no pods, an empty one every pod in the namespace
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		for _, pod := range workload.Pods {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				matched = append(matched, types.PDBInfo{
+					Name:               pdb.Name,
+					DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+					CurrentHealthy:     pdb.Status.CurrentHealthy,
+					ExpectedPods:       pdb.Status.ExpectedPods,
+				})
+				break
+			}
+		}
+	}
+	return matched, nil
+}
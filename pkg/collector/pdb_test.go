@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"context"
+	"testing"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestCollectPDBs(t *testing.T) {
+	pdb := func(name string, selector *metav1.LabelSelector, allowed int32) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: selector},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: allowed, CurrentHealthy: 3, ExpectedPods: 3},
+		}
+	}
+
+	clientset := fake.NewSimpleClientset(
+		pdb("web", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}, 0),
+		pdb("db", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}, 1),
+		pdb("everything", &metav1.LabelSelector{}, 2),
+		pdb("nothing", nil, 5),
+	)
+
+	workload := types.WorkloadInfo{
+		Namespace: "default",
+		Pods:      []types.PodInfo{{Name: "web-1", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+	}
+
+	c := New(clientset, nil)
+	pdbs, err := c.CollectPDBs(context.Background(), workload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	matched := make(map[string]types.PDBInfo)
+	for _, pdb := range pdbs {
+		matched[pdb.Name] = pdb
+	}
+	if len(matched) != 2 {
+		t.Fatalf("expected the web and everything budgets, got %v", pdbs)
+	}
+	if web, ok := matched["web"]; !ok || web.DisruptionsAllowed != 0 || web.CurrentHealthy != 3 || web.ExpectedPods != 3 {
+		t.Errorf("unexpected web budget: %+v", web)
+	}
+	if _, ok := matched["everything"]; !ok {
+		t.Error("expected an empty selector to match every pod")
+	}
+}
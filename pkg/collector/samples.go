@@ -0,0 +1,121 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// sampledWorkload is a workload's pods and their sampled metrics, by pod name, as read by SampleWorkloads
+type sampledWorkload struct {
+	pods    []corev1.Pod
+	metrics map[string]*types.PodMetrics
+}
+
+// SampleWorkloads lists the pods of every workload and reads their metrics options.Samples times,
+// options.SampleInterval apart, for CollectPods to use. Every workload is read at each interval, so
+// sampling takes (samples-1) intervals however many workloads there are.
+func (c *Collector) SampleWorkloads(ctx context.Context, workloads []types.WorkloadInfo, options *types.Options) error {
+	c.sampled = make(map[string]*sampledWorkload, len(workloads))
+	pods := make([][]corev1.Pod, len(workloads))
+	stopListing := c.track("pod listing")
+	for i, workload := range workloads {
+		listed, err := c.listPods(ctx, workload, options)
+		if err != nil {
+			return err
+		}
+		pods[i] = listed
+	}
+	stopListing()
+
+	// Readings of all workloads are merged under workload/pod keys, as pod names may repeat across namespaces
+	read := func() map[string]*types.PodMetrics {
+		reading := make(map[string]*types.PodMetrics)
+		for i, workload := range workloads {
+			for podName, metrics := range c.readMetrics(ctx, workload, pods[i], options) {
+				reading[workloadKey(workload)+"/"+podName] = metrics
+			}
+		}
+		return reading
+	}
+	defer c.track("metrics")()
+	sampled := read()
+	if len(sampled) > 0 {
+		sampled = c.sampleMetrics(ctx, sampled, options.Samples, options.SampleInterval, read)
+	}
+
+	for i, workload := range workloads {
+		metrics := make(map[string]*types.PodMetrics)
+		for _, pod := range pods[i] {
+			if podMetrics := sampled[workloadKey(workload)+"/"+pod.Name]; podMetrics != nil {
+				metrics[pod.Name] = podMetrics
+			}
+		}
+		c.sampled[workloadKey(workload)] = &sampledWorkload{pods: pods[i], metrics: metrics}
+	}
+	return nil
+}
+
+// sampleMetrics keeps reading metrics until there are samples readings in total, interval apart, starting
+// from the first one already taken. It returns the latest reading of each pod with every container's
+// readings attached as its Samples. A failed or empty reading is skipped, and a cancelled context stops
+// sampling early with what was read so far.
+func (c *Collector) sampleMetrics(ctx context.Context, first map[string]*types.PodMetrics, samples int, interval time.Duration, read func() map[string]*types.PodMetrics) map[string]*types.PodMetrics {
+	latest := make(map[string]*types.PodMetrics, len(first))
+	series := make(map[string]map[string][]types.MetricSample)
+	record := func(reading map[string]*types.PodMetrics, at time.Time) {
+		for podName, metrics := range reading {
+			if metrics == nil {
+				continue
+			}
+			latest[podName] = metrics
+			if series[podName] == nil {
+				series[podName] = make(map[string][]types.MetricSample)
+			}
+			for containerName, containerMetrics := range metrics.Containers {
+				series[podName][containerName] = append(series[podName][containerName], metricSample(containerMetrics, at))
+			}
+		}
+	}
+
+	record(first, time.Now())
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for taken := 1; taken < samples; taken++ {
+		select {
+		case <-ctx.Done():
+			return attachSamples(latest, series)
+		case <-timer.C:
+		}
+		record(read(), time.Now())
+		timer.Reset(interval)
+	}
+	return attachSamples(latest, series)
+}
+
+// attachSamples stores each container's series on its latest metrics
+func attachSamples(latest map[string]*types.PodMetrics, series map[string]map[string][]types.MetricSample) map[string]*types.PodMetrics {
+	for podName, metrics := range latest {
+		for containerName, containerMetrics := range metrics.Containers {
+			containerMetrics.Samples = series[podName][containerName]
+			metrics.Containers[containerName] = containerMetrics
+		}
+	}
+	return latest
+}
+
+// metricSample converts one container reading into a sample; unparseable quantities count as zero
+func metricSample(metrics types.ContainerMetrics, at time.Time) types.MetricSample {
+	sample := types.MetricSample{Time: at}
+	if quantity, err := resource.ParseQuantity(metrics.CPUUsage); err == nil {
+		sample.CPUMilli = quantity.MilliValue()
+	}
+	if quantity, err := resource.ParseQuantity(metrics.MemoryUsage); err == nil {
+		sample.MemoryBytes = quantity.Value()
+	}
+	return sample
+}
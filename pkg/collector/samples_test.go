@@ -0,0 +1,115 @@
+package collector
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestSampleMetrics(t *testing.T) {
+	reading := func(cpu, memory string) map[string]*types.PodMetrics {
+		return map[string]*types.PodMetrics{"api-1": {
+			Containers: map[string]types.ContainerMetrics{"app": {CPUUsage: cpu, MemoryUsage: memory}},
+		}}
+	}
+	c := New(fake.NewSimpleClientset(), nil)
+	c.SetWarningOutput(io.Discard)
+
+	// The second reading fails and is skipped, so four samples are left from five readings
+	readings := []map[string]*types.PodMetrics{nil, reading("200m", "110Mi"), reading("150m", "120Mi"), reading("300m", "130Mi")}
+	next := 0
+	metrics := c.sampleMetrics(context.Background(), reading("100m", "100Mi"), 5, time.Millisecond, func() map[string]*types.PodMetrics {
+		next++
+		return readings[next-1]
+	})
+
+	app := metrics["api-1"].Containers["app"]
+	if app.CPUUsage != "300m" || app.MemoryUsage != "130Mi" {
+		t.Errorf("expected the latest reading to be current, got CPU %s and memory %s", app.CPUUsage, app.MemoryUsage)
+	}
+	expectedCPU := []int64{100, 200, 150, 300}
+	if len(app.Samples) != len(expectedCPU) {
+		t.Fatalf("expected %d samples, got %d", len(expectedCPU), len(app.Samples))
+	}
+	for i, sample := range app.Samples {
+		if sample.CPUMilli != expectedCPU[i] {
+			t.Errorf("sample %d: expected %dm CPU, got %dm", i, expectedCPU[i], sample.CPUMilli)
+		}
+		if expectedMemory := int64(100+10*i) * 1024 * 1024; sample.MemoryBytes != expectedMemory {
+			t.Errorf("sample %d: expected %d bytes, got %d", i, expectedMemory, sample.MemoryBytes)
+		}
+	}
+
+	// A cancelled context stops after the first reading
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	metrics = c.sampleMetrics(ctx, reading("100m", "100Mi"), 3, time.Hour, func() map[string]*types.PodMetrics {
+		t.Fatal("read after the context was cancelled")
+		return nil
+	})
+	if samples := metrics["api-1"].Containers["app"].Samples; len(samples) != 1 {
+		t.Errorf("expected 1 sample after cancelling, got %d", len(samples))
+	}
+}
+
+func TestSampleWorkloads(t *testing.T) {
+	var objects []runtime.Object
+	var workloads []types.WorkloadInfo
+	snapshot := "namespace,pod,container,cpu,memory\n"
+	for _, app := range []string{"api", "web", "worker"} {
+		workloads = append(workloads, types.WorkloadInfo{Name: app, Kind: "Deployment", Namespace: "default", Selector: map[string]string{"app": app}})
+		for _, suffix := range []string{"1", "2"} {
+			name := app + "-" + suffix
+			objects = append(objects, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": app}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+				Status: corev1.PodStatus{
+					Phase:             corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{Name: "app", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}},
+				},
+			})
+			snapshot += "default," + name + ",app,100m,64Mi\n"
+		}
+	}
+	metricsSnapshot, err := LoadMetricsSnapshot(writeSnapshot(t, "top.csv", snapshot))
+	if err != nil {
+		t.Fatalf("failed to load snapshot: %v", err)
+	}
+	c := New(fake.NewSimpleClientset(objects...), nil)
+	c.SetWarningOutput(io.Discard)
+	c.UseMetricsSnapshot(metricsSnapshot)
+
+	interval := 40 * time.Millisecond
+	options := &types.Options{Samples: 3, SampleInterval: interval, ShowResourceUsage: true}
+	start := time.Now()
+	if err := c.SampleWorkloads(context.Background(), workloads, options); err != nil {
+		t.Fatalf("sampling failed: %v", err)
+	}
+	// Sampled together, the workloads take two intervals; one after another they would take six
+	if elapsed := time.Since(start); elapsed >= 4*interval {
+		t.Errorf("expected sampling %d workloads to take about %s, took %s", len(workloads), 2*interval, elapsed)
+	}
+
+	for _, workload := range workloads {
+		pods, err := c.CollectPods(context.Background(), workload, options)
+		if err != nil {
+			t.Fatalf("collect %s failed: %v", workload.Name, err)
+		}
+		if len(pods) != 2 {
+			t.Fatalf("%s: expected 2 pods, got %d", workload.Name, len(pods))
+		}
+		for _, pod := range pods {
+			if samples := pod.Containers[0].Resources.Samples; len(samples) != 3 {
+				t.Errorf("%s: expected 3 samples, got %d", pod.Name, len(samples))
+			}
+		}
+	}
+}
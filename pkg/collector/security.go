@@ -0,0 +1,44 @@
+package collector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// collectSecurityInfo merges the pod security context with the container's, where container
+// settings override the pod-level defaults the same way the kubelet applies them
+func collectSecurityInfo(container corev1.Container, pod *corev1.Pod) types.SecurityInfo {
+	var info types.SecurityInfo
+
+	if podContext := pod.Spec.SecurityContext; podContext != nil {
+		info.RunAsUser = podContext.RunAsUser
+		info.RunAsNonRoot = podContext.RunAsNonRoot
+	}
+
+	securityContext := container.SecurityContext
+	if securityContext == nil {
+		return info
+	}
+	if securityContext.RunAsUser != nil {
+		info.RunAsUser = securityContext.RunAsUser
+	}
+	if securityContext.RunAsNonRoot != nil {
+		info.RunAsNonRoot = securityContext.RunAsNonRoot
+	}
+	if securityContext.Privileged != nil {
+		info.Privileged = *securityContext.Privileged
+	}
+	if securityContext.ReadOnlyRootFilesystem != nil {
+		info.ReadOnlyRootFilesystem = *securityContext.ReadOnlyRootFilesystem
+	}
+	if capabilities := securityContext.Capabilities; capabilities != nil {
+		for _, capability := range capabilities.Add {
+			info.CapabilitiesAdded = append(info.CapabilitiesAdded, string(capability))
+		}
+		for _, capability := range capabilities.Drop {
+			info.CapabilitiesDropped = append(info.CapabilitiesDropped, string(capability))
+		}
+	}
+	return info
+}
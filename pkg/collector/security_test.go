@@ -0,0 +1,67 @@
+package collector
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCollectSecurityInfo(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{RunAsUser: int64Ptr(1000), RunAsNonRoot: boolPtr(true)},
+		},
+	}
+
+	t.Run("pod defaults", func(t *testing.T) {
+		info := collectSecurityInfo(corev1.Container{Name: "app"}, pod)
+		if info.RunAsUser == nil || *info.RunAsUser != 1000 {
+			t.Errorf("expected runAsUser 1000 from the pod, got %v", info.RunAsUser)
+		}
+		if info.RunAsNonRoot == nil || !*info.RunAsNonRoot {
+			t.Errorf("expected runAsNonRoot true from the pod, got %v", info.RunAsNonRoot)
+		}
+		if info.Privileged || info.ReadOnlyRootFilesystem {
+			t.Errorf("expected unprivileged, writable root filesystem, got %+v", info)
+		}
+	})
+
+	t.Run("container overrides", func(t *testing.T) {
+		container := corev1.Container{
+			Name: "debug",
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser:              int64Ptr(0),
+				RunAsNonRoot:           boolPtr(false),
+				Privileged:             boolPtr(true),
+				ReadOnlyRootFilesystem: boolPtr(true),
+				Capabilities: &corev1.Capabilities{
+					Add:  []corev1.Capability{"NET_ADMIN"},
+					Drop: []corev1.Capability{"ALL"},
+				},
+			},
+		}
+		info := collectSecurityInfo(container, pod)
+		if info.RunAsUser == nil || *info.RunAsUser != 0 {
+			t.Errorf("expected the container's runAsUser 0, got %v", info.RunAsUser)
+		}
+		if info.RunAsNonRoot == nil || *info.RunAsNonRoot {
+			t.Errorf("expected the container's runAsNonRoot false, got %v", info.RunAsNonRoot)
+		}
+		if !info.Privileged || !info.ReadOnlyRootFilesystem {
+			t.Errorf("expected privileged with a read-only root filesystem, got %+v", info)
+		}
+		if len(info.CapabilitiesAdded) != 1 || info.CapabilitiesAdded[0] != "NET_ADMIN" ||
+			len(info.CapabilitiesDropped) != 1 || info.CapabilitiesDropped[0] != "ALL" {
+			t.Errorf("unexpected capabilities: +%v -%v", info.CapabilitiesAdded, info.CapabilitiesDropped)
+		}
+	})
+
+	t.Run("nothing set", func(t *testing.T) {
+		info := collectSecurityInfo(corev1.Container{Name: "app"}, &corev1.Pod{})
+		if info.RunAsUser != nil || info.RunAsNonRoot != nil {
+			t.Errorf("expected runAsUser and runAsNonRoot to be left to the image, got %+v", info)
+		}
+	})
+}
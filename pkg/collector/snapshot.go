@@ -0,0 +1,144 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metricsapi "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// MetricsSnapshot holds container usage loaded from a file, used instead of querying metrics-server
+type MetricsSnapshot struct {
+	pods map[string][]snapshotContainer // keyed by namespace/name
+}
+
+type snapshotContainer struct {
+	Name   string
+	CPU    resource.Quantity
+	Memory resource.Quantity
+}
+
+// LoadMetricsSnapshot reads container usage from a file. Two formats are accepted:
+//   - JSON: a PodMetricsList, e.g. from `kubectl get --raw /apis/metrics.k8s.io/v1beta1/pods`
+//   - CSV: rows of namespace,pod,container,cpu,memory (e.g. default,web-1,app,250m,128Mi), with an optional header
+func LoadMetricsSnapshot(path string) (*MetricsSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metrics snapshot: %w", err)
+	}
+
+	var snapshot *MetricsSnapshot
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		snapshot, err = parseMetricsJSON(trimmed)
+	} else {
+		snapshot, err = parseMetricsCSV(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics snapshot %s: %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// parseMetricsJSON parses a PodMetricsList as served by the metrics API
+func parseMetricsJSON(data []byte) (*MetricsSnapshot, error) {
+	var list metricsapi.PodMetricsList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	snapshot := &MetricsSnapshot{pods: make(map[string][]snapshotContainer)}
+	for _, podMetrics := range list.Items {
+		key := podMetrics.Namespace + "/" + podMetrics.Name
+		for _, container := range podMetrics.Containers {
+			entry := snapshotContainer{Name: container.Name}
+			if cpu := container.Usage.Cpu(); cpu != nil {
+				entry.CPU = *cpu
+			}
+			if memory := container.Usage.Memory(); memory != nil {
+				entry.Memory = *memory
+			}
+			snapshot.pods[key] = append(snapshot.pods[key], entry)
+		}
+	}
+	return snapshot, nil
+}
+
+// parseMetricsCSV parses namespace,pod,container,cpu,memory rows
+func parseMetricsCSV(data []byte) (*MetricsSnapshot, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = 5
+	reader.TrimLeadingSpace = true
+
+	snapshot := &MetricsSnapshot{pods: make(map[string][]snapshotContainer)}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && strings.EqualFold(record[0], "namespace") {
+			continue
+		}
+
+		cpu, err := resource.ParseQuantity(record[3])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid cpu %q: %w", line, record[3], err)
+		}
+		memory, err := resource.ParseQuantity(record[4])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid memory %q: %w", line, record[4], err)
+		}
+
+		key := record[0] + "/" + record[1]
+		snapshot.pods[key] = append(snapshot.pods[key], snapshotContainer{Name: record[2], CPU: cpu, Memory: memory})
+	}
+	return snapshot, nil
+}
+
+// UseMetricsSnapshot makes the collector read resource usage from the snapshot instead of metrics-server
+func (c *Collector) UseMetricsSnapshot(snapshot *MetricsSnapshot) {
+	c.metricsSnapshot = snapshot
+}
+
+// snapshotPodMetrics returns the snapshot usage of a pod, or nil if the snapshot has no entry for it
+func (c *Collector) snapshotPodMetrics(pod *corev1.Pod) *types.PodMetrics {
+	containers, ok := c.metricsSnapshot.pods[pod.Namespace+"/"+pod.Name]
+	if !ok {
+		return nil
+	}
+
+	metrics := &types.PodMetrics{
+		Containers: make(map[string]types.ContainerMetrics),
+	}
+
+	// Store metrics for each container and aggregate totals
+	totalCPU := resource.NewQuantity(0, resource.DecimalSI)
+	totalMem := resource.NewQuantity(0, resource.BinarySI)
+	for _, container := range containers {
+		metrics.Containers[container.Name] = types.ContainerMetrics{
+			CPUUsage:    container.CPU.String(),
+			MemoryUsage: container.Memory.String(),
+		}
+		totalCPU.Add(container.CPU)
+		totalMem.Add(container.Memory)
+	}
+
+	// Set aggregated pod-level usage
+	metrics.CPUUsage = c.formatCPUUsage(totalCPU.String())
+	metrics.MemoryUsage = c.formatMemoryUsage(totalMem.String())
+	metrics.CPUUsageMilli = totalCPU.MilliValue()
+	metrics.MemoryUsageBytes = totalMem.Value()
+
+	return metrics
+}
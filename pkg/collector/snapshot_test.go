@@ -0,0 +1,85 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func writeSnapshot(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+	return path
+}
+
+func TestLoadMetricsSnapshotCSV(t *testing.T) {
+	path := writeSnapshot(t, "top.csv", `namespace,pod,container,cpu,memory
+default,web-1,app,250m,128Mi
+default,web-1,sidecar,50m,32Mi
+default,web-2,app,1500m,1Gi
+`)
+
+	snapshot, err := LoadMetricsSnapshot(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := &Collector{metricsSnapshot: snapshot}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1"}}
+	metrics := c.snapshotPodMetrics(pod)
+	if metrics == nil {
+		t.Fatal("expected metrics for default/web-1")
+	}
+	if metrics.CPUUsage != "300m" || metrics.MemoryUsage != "160Mi" {
+		t.Errorf("expected pod totals 300m/160Mi, got %s/%s", metrics.CPUUsage, metrics.MemoryUsage)
+	}
+	if metrics.Containers["sidecar"].CPUUsage != "50m" {
+		t.Errorf("expected sidecar CPU 50m, got %s", metrics.Containers["sidecar"].CPUUsage)
+	}
+
+	missing := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "web-1"}}
+	if c.snapshotPodMetrics(missing) != nil {
+		t.Error("expected no metrics for a pod missing from the snapshot")
+	}
+}
+
+func TestLoadMetricsSnapshotJSON(t *testing.T) {
+	path := writeSnapshot(t, "pods.json", `{
+  "kind": "PodMetricsList",
+  "apiVersion": "metrics.k8s.io/v1beta1",
+  "items": [
+    {
+      "metadata": {"name": "web-1", "namespace": "default"},
+      "containers": [{"name": "app", "usage": {"cpu": "250m", "memory": "128Mi"}}]
+    }
+  ]
+}`)
+
+	snapshot, err := LoadMetricsSnapshot(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := &Collector{metricsSnapshot: snapshot}
+	metrics := c.snapshotPodMetrics(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1"}})
+	if metrics == nil || metrics.Containers["app"].MemoryUsage != "128Mi" {
+		t.Errorf("expected app memory 128Mi, got %+v", metrics)
+	}
+}
+
+func TestLoadMetricsSnapshotInvalid(t *testing.T) {
+	path := writeSnapshot(t, "bad.csv", "default,web-1,app,lots,128Mi\n")
+	if _, err := LoadMetricsSnapshot(path); err == nil {
+		t.Error("expected an error for an invalid cpu quantity")
+	}
+
+	if _, err := LoadMetricsSnapshot(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,206 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v3"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// regressionRatio is the usage ratio at which the comparison set is flagged as a regression
+const regressionRatio = 2.0
+
+// comparisonStats holds aggregate statistics for one side of a comparison
+type comparisonStats struct {
+	Label    string
+	Pods     int
+	Healthy  int
+	Degraded int
+	Critical int
+	Level    string
+	Restarts int32
+	CPUP90   int64 // millicores
+	MemP90   int64 // bytes
+}
+
+// OutputComparison outputs the baseline and comparison workloads side by side
+func (f *Formatter) OutputComparison(baseline, comparison []types.WorkloadInfo) error {
+	switch f.options.OutputFormat {
+	case "json":
+		data, err := json.MarshalIndent(map[string][]types.WorkloadInfo{
+			"baseline":   baseline,
+			"comparison": comparison,
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Fprintln(f.out, string(data))
+		return nil
+	case "yaml":
+		data, err := yaml.Marshal(map[string][]types.WorkloadInfo{
+			"baseline":   baseline,
+			"comparison": comparison,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+		fmt.Fprintln(f.out, string(data))
+		return nil
+	}
+
+	base := f.calculateComparisonStats(workloadLabel(baseline), baseline)
+	other := f.calculateComparisonStats(fmt.Sprintf("selector:%s", f.options.Compare), comparison)
+
+	separatorColor := color.New(color.FgHiBlack)
+	headerColor := color.New(color.FgCyan, color.Bold)
+	fmt.Fprintln(f.out, separatorColor.Sprint(strings.Repeat("─", 60)))
+	fmt.Fprintf(f.out, "🔀 %s\n\n", headerColor.Sprint("COMPARISON"))
+
+	table := tablewriter.NewWriter(f.out)
+	table.SetHeader([]string{"METRIC", "BASELINE: " + base.Label, "COMPARE: " + other.Label})
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetBorder(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	table.Append([]string{"Pods", fmt.Sprintf("%d", base.Pods), fmt.Sprintf("%d", other.Pods)})
+	table.Append([]string{
+		"Health",
+		fmt.Sprintf("%s %s", f.analyzer.GetHealthIcon(base.Level), base.Level),
+		fmt.Sprintf("%s %s", f.analyzer.GetHealthIcon(other.Level), other.Level),
+	})
+	table.Append([]string{
+		"Healthy/Degraded/Critical",
+		fmt.Sprintf("%d/%d/%d", base.Healthy, base.Degraded, base.Critical),
+		fmt.Sprintf("%d/%d/%d", other.Healthy, other.Degraded, other.Critical),
+	})
+
+	otherRestarts := fmt.Sprintf("%d", other.Restarts)
+	if restartsPerPod(other) > restartsPerPod(base) {
+		otherRestarts = f.highlightRegression(otherRestarts + " ▲")
+	}
+	table.Append([]string{"Total Restarts", fmt.Sprintf("%d", base.Restarts), otherRestarts})
+
+	table.Append([]string{
+		"CPU p90 (per pod)",
+		formatMilliCPU(base.CPUP90),
+		f.formatComparedValue(formatMilliCPU(other.CPUP90), base.CPUP90, other.CPUP90),
+	})
+	table.Append([]string{
+		"Memory p90 (per pod)",
+		formatBytes(base.MemP90),
+		f.formatComparedValue(formatBytes(other.MemP90), base.MemP90, other.MemP90),
+	})
+
+	table.Render()
+	fmt.Fprintln(f.out)
+	f.printWarnings(f.out, append(append([]types.WorkloadInfo{}, baseline...), comparison...))
+	return nil
+}
+
+// calculateComparisonStats aggregates health, restarts and p90 usage across all pods of the workloads
+func (f *Formatter) calculateComparisonStats(label string, workloads []types.WorkloadInfo) comparisonStats {
+	stats := comparisonStats{
+		Label: label,
+		Level: string(types.HealthLevelHealthy),
+	}
+
+	var cpuValues, memValues []int64
+	for _, workload := range workloads {
+		stats.Level = worseHealthLevel(stats.Level, workload.Health.Level)
+
+		for _, pod := range workload.Pods {
+			stats.Pods++
+			switch pod.Health.Level {
+			case string(types.HealthLevelHealthy):
+				stats.Healthy++
+			case string(types.HealthLevelDegraded):
+				stats.Degraded++
+			case string(types.HealthLevelCritical):
+				stats.Critical++
+			}
+
+			for _, container := range append(pod.InitContainers, pod.Containers...) {
+				stats.Restarts += container.RestartCount
+			}
+
+			// The exact readings, not the rounded display strings
+			if pod.Metrics != nil {
+				if pod.Metrics.CPUUsage != "" {
+					cpuValues = append(cpuValues, pod.Metrics.CPUUsageMilli)
+				}
+				if pod.Metrics.MemoryUsage != "" {
+					memValues = append(memValues, pod.Metrics.MemoryUsageBytes)
+				}
+			}
+		}
+	}
+
+	if stats.Pods == 0 {
+		stats.Level = string(types.HealthLevelCritical)
+	}
+
+	stats.CPUP90 = percentileInt64(cpuValues, 0.9)
+	stats.MemP90 = percentileInt64(memValues, 0.9)
+	return stats
+}
+
+// formatComparedValue annotates a comparison value with its ratio to the baseline, highlighting regressions
+func (f *Formatter) formatComparedValue(value string, base, other int64) string {
+	if base <= 0 || other <= 0 {
+		return value
+	}
+
+	ratio := float64(other) / float64(base)
+	annotated := fmt.Sprintf("%s (%.1fx)", value, ratio)
+	if ratio >= regressionRatio {
+		return f.highlightRegression(annotated + " ▲")
+	}
+	return annotated
+}
+
+// highlightRegression colors a value to mark it as a regression
+func (f *Formatter) highlightRegression(value string) string {
+	if f.options.NoColor {
+		return value
+	}
+	return color.New(color.FgHiRed, color.Bold).Sprint(value)
+}
+
+// workloadLabel builds a short display label for a set of workloads
+func workloadLabel(workloads []types.WorkloadInfo) string {
+	var names []string
+	for _, workload := range workloads {
+		names = append(names, fmt.Sprintf("%s/%s", strings.ToLower(workload.Kind), workload.Name))
+	}
+	if len(names) > 2 {
+		return fmt.Sprintf("%s (+%d more)", strings.Join(names[:2], ", "), len(names)-2)
+	}
+	return strings.Join(names, ", ")
+}
+
+// worseHealthLevel returns the more severe of two health levels
+func worseHealthLevel(a, b string) string {
+	rank := map[string]int{
+		string(types.HealthLevelHealthy):  0,
+		string(types.HealthLevelDegraded): 1,
+		string(types.HealthLevelCritical): 2,
+	}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// restartsPerPod returns the average number of restarts per pod
+func restartsPerPod(stats comparisonStats) float64 {
+	if stats.Pods == 0 {
+		return 0
+	}
+	return float64(stats.Restarts) / float64(stats.Pods)
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestCalculateComparisonStats(t *testing.T) {
+	formatter := &Formatter{
+		options: &types.Options{NoColor: true},
+	}
+
+	workloads := []types.WorkloadInfo{
+		{
+			Name:   "api",
+			Kind:   "Deployment",
+			Health: types.HealthStatus{Level: string(types.HealthLevelDegraded)},
+			Pods: []types.PodInfo{
+				{
+					Name:       "api-1",
+					Health:     types.HealthStatus{Level: string(types.HealthLevelHealthy)},
+					Containers: []types.ContainerInfo{{RestartCount: 1}},
+					Metrics:    &types.PodMetrics{CPUUsage: "100m", MemoryUsage: "128Mi", CPUUsageMilli: 100, MemoryUsageBytes: 128 * 1024 * 1024},
+				},
+				{
+					Name:       "api-2",
+					Health:     types.HealthStatus{Level: string(types.HealthLevelDegraded)},
+					Containers: []types.ContainerInfo{{RestartCount: 2}},
+					Metrics:    &types.PodMetrics{CPUUsage: "1.2", MemoryUsage: "512Mi", CPUUsageMilli: 1234, MemoryUsageBytes: 512*1024*1024 + 4096},
+				},
+			},
+		},
+	}
+
+	stats := formatter.calculateComparisonStats("deployment/api", workloads)
+
+	if stats.Pods != 2 || stats.Healthy != 1 || stats.Degraded != 1 || stats.Critical != 0 {
+		t.Errorf("unexpected pod counts: %+v", stats)
+	}
+	if stats.Level != string(types.HealthLevelDegraded) {
+		t.Errorf("expected level Degraded, got %s", stats.Level)
+	}
+	if stats.Restarts != 3 {
+		t.Errorf("expected 3 restarts, got %d", stats.Restarts)
+	}
+	// Exact readings, not the rounded "1.2" and "512Mi"
+	if stats.CPUP90 != 1234 {
+		t.Errorf("expected CPU p90 of 1234m, got %dm", stats.CPUP90)
+	}
+	if stats.MemP90 != 512*1024*1024+4096 {
+		t.Errorf("expected memory p90 of 512Mi and 4Ki, got %d bytes", stats.MemP90)
+	}
+}
+
+func TestFormatComparedValue(t *testing.T) {
+	formatter := &Formatter{
+		options: &types.Options{NoColor: true},
+	}
+
+	tests := []struct {
+		name     string
+		base     int64
+		other    int64
+		expected string
+	}{
+		{"similar usage", 100, 110, "x (1.1x)"},
+		{"regression", 100, 250, "x (2.5x) ▲"},
+		{"missing baseline", 0, 100, "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatter.formatComparedValue("x", tt.base, tt.other)
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
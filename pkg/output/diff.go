@@ -0,0 +1,240 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// podDiff describes how one pod differs between a saved snapshot and the current state
+type podDiff struct {
+	Namespace string
+	Pod       string
+	Workload  string   // kind/name of the owning workload
+	Change    string   // added, removed or changed
+	Details   []string // What changed, e.g. "health Healthy → Critical (CrashLoopBackOff)"
+}
+
+// Pod change kinds reported by --diff
+const (
+	podAdded   = "added"
+	podRemoved = "removed"
+	podChanged = "changed"
+)
+
+// WriteSnapshot saves the workloads to a file in the same JSON form as --output json, for a later --diff
+func WriteSnapshot(path string, workloads []types.WorkloadInfo) error {
+	data, err := json.MarshalIndent(workloads, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// ReadSnapshot loads workloads saved with --snapshot (or --output json) and the time the file was written
+func ReadSnapshot(path string) ([]types.WorkloadInfo, time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var workloads []types.WorkloadInfo
+	if err := json.Unmarshal(data, &workloads); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	return workloads, info.ModTime(), nil
+}
+
+// OutputDiff prints what changed between a snapshot taken at savedAt and the current workloads
+func (f *Formatter) OutputDiff(previous, current []types.WorkloadInfo, source string, savedAt time.Time) error {
+	diffs := diffWorkloads(previous, current)
+
+	switch f.options.OutputFormat {
+	case "json":
+		data, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Fprintln(f.out, string(data))
+		f.printWarnings(os.Stderr, current)
+		return nil
+	case "yaml":
+		data, err := yaml.Marshal(diffs)
+		if err != nil {
+			return fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+		fmt.Fprintln(f.out, string(data))
+		f.printWarnings(os.Stderr, current)
+		return nil
+	}
+
+	headerColor := color.New(color.FgCyan, color.Bold)
+	fmt.Fprintf(f.out, "🔍 %s %s (saved %s)\n\n", headerColor.Sprint("CHANGES SINCE"), source, f.formatTime(savedAt))
+
+	counts := make(map[string]int)
+	for _, diff := range diffs {
+		counts[diff.Change]++
+		fmt.Fprintln(f.out, f.formatPodDiff(diff))
+	}
+	if len(diffs) == 0 {
+		fmt.Fprintln(f.out, "  No changes")
+	}
+
+	fmt.Fprintf(f.out, "\n%d added, %d removed, %d changed, %d unchanged\n",
+		counts[podAdded], counts[podRemoved], counts[podChanged], countPods(current)-counts[podAdded]-counts[podChanged])
+	f.printWarnings(f.out, current)
+	return nil
+}
+
+// formatPodDiff renders one pod change, e.g. "  ~ default/api-1 (deployment/api) changed: app restarts +3"
+func (f *Formatter) formatPodDiff(diff podDiff) string {
+	marker := "~"
+	markerColor := f.getHealthColor(string(types.HealthLevelDegraded))
+	switch diff.Change {
+	case podAdded:
+		marker = "+"
+		markerColor = f.getHealthColor(string(types.HealthLevelHealthy))
+	case podRemoved:
+		marker = "-"
+		markerColor = f.getHealthColor(string(types.HealthLevelCritical))
+	}
+
+	line := fmt.Sprintf("  %s %s/%s (%s) %s", markerColor.Sprint(marker), diff.Namespace, diff.Pod, diff.Workload, diff.Change)
+	if len(diff.Details) > 0 {
+		line += ": " + strings.Join(diff.Details, "; ")
+	}
+	return line
+}
+
+// snapshotPod is a pod together with the workload it was collected for
+type snapshotPod struct {
+	workload string
+	pod      types.PodInfo
+}
+
+// diffWorkloads matches pods by namespace and name and reports the pods added, removed or changed,
+// sorted by namespace and name
+func diffWorkloads(previous, current []types.WorkloadInfo) []podDiff {
+	before := indexPods(previous)
+	after := indexPods(current)
+
+	var diffs []podDiff
+	for key, now := range after {
+		then, existed := before[key]
+		if !existed {
+			diffs = append(diffs, podDiff{
+				Namespace: now.pod.Namespace,
+				Pod:       now.pod.Name,
+				Workload:  now.workload,
+				Change:    podAdded,
+				Details:   []string{fmt.Sprintf("health %s", now.pod.Health.Level)},
+			})
+			continue
+		}
+		if details := podChanges(then.pod, now.pod); len(details) > 0 {
+			diffs = append(diffs, podDiff{
+				Namespace: now.pod.Namespace,
+				Pod:       now.pod.Name,
+				Workload:  now.workload,
+				Change:    podChanged,
+				Details:   details,
+			})
+		}
+	}
+	for key, then := range before {
+		if _, exists := after[key]; !exists {
+			diffs = append(diffs, podDiff{
+				Namespace: then.pod.Namespace,
+				Pod:       then.pod.Name,
+				Workload:  then.workload,
+				Change:    podRemoved,
+			})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Namespace != diffs[j].Namespace {
+			return diffs[i].Namespace < diffs[j].Namespace
+		}
+		return diffs[i].Pod < diffs[j].Pod
+	})
+	return diffs
+}
+
+// indexPods keys every pod of the workloads by namespace/name
+func indexPods(workloads []types.WorkloadInfo) map[string]snapshotPod {
+	pods := make(map[string]snapshotPod)
+	for _, workload := range workloads {
+		owner := fmt.Sprintf("%s/%s", strings.ToLower(workload.Kind), workload.Name)
+		for _, pod := range workload.Pods {
+			pods[pod.Namespace+"/"+pod.Name] = snapshotPod{workload: owner, pod: pod}
+		}
+	}
+	return pods
+}
+
+// countPods returns the total number of pods across the workloads
+func countPods(workloads []types.WorkloadInfo) int {
+	total := 0
+	for _, workload := range workloads {
+		total += len(workload.Pods)
+	}
+	return total
+}
+
+// podChanges lists the differences between two observations of the same pod: health and status
+// transitions, restart deltas, image changes and containers that came or went
+func podChanges(then, now types.PodInfo) []string {
+	var details []string
+	if then.Health.Level != now.Health.Level {
+		detail := fmt.Sprintf("health %s → %s", then.Health.Level, now.Health.Level)
+		if now.Health.Reason != "" {
+			detail += fmt.Sprintf(" (%s)", now.Health.Reason)
+		}
+		details = append(details, detail)
+	}
+	if then.Status != now.Status {
+		details = append(details, fmt.Sprintf("status %s → %s", then.Status, now.Status))
+	}
+
+	previousContainers := make(map[string]types.ContainerInfo)
+	for _, container := range allContainers(then) {
+		previousContainers[container.Name] = container
+	}
+	seen := make(map[string]bool)
+	for _, container := range allContainers(now) {
+		seen[container.Name] = true
+		old, existed := previousContainers[container.Name]
+		if !existed {
+			details = append(details, fmt.Sprintf("container %s added", container.Name))
+			continue
+		}
+		if delta := container.RestartCount - old.RestartCount; delta > 0 {
+			details = append(details, fmt.Sprintf("%s restarts +%d", container.Name, delta))
+		}
+		if old.Image != container.Image {
+			details = append(details, fmt.Sprintf("%s image %s → %s", container.Name, old.Image, container.Image))
+		}
+	}
+	for _, container := range allContainers(then) {
+		if !seen[container.Name] {
+			details = append(details, fmt.Sprintf("container %s removed", container.Name))
+		}
+	}
+	return details
+}
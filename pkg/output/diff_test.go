@@ -0,0 +1,122 @@
+package output
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestDiffWorkloads(t *testing.T) {
+	pod := func(name, level, image string, restarts int32) types.PodInfo {
+		return types.PodInfo{
+			Name:       name,
+			Namespace:  "default",
+			Status:     "Running",
+			Health:     types.HealthStatus{Level: level},
+			Containers: []types.ContainerInfo{{Name: "app", Image: image, RestartCount: restarts}},
+		}
+	}
+	healthy := string(types.HealthLevelHealthy)
+
+	previous := []types.WorkloadInfo{{
+		Kind: "Deployment",
+		Name: "api",
+		Pods: []types.PodInfo{
+			pod("api-1", healthy, "api:1.2", 0),
+			pod("api-2", healthy, "api:1.2", 1),
+			pod("api-3", healthy, "api:1.2", 0),
+		},
+	}}
+
+	crashing := pod("api-2", string(types.HealthLevelCritical), "api:1.3", 4)
+	crashing.Health.Reason = "CrashLoopBackOff"
+	current := []types.WorkloadInfo{{
+		Kind: "Deployment",
+		Name: "api",
+		Pods: []types.PodInfo{
+			pod("api-1", healthy, "api:1.2", 0),
+			crashing,
+			pod("api-4", healthy, "api:1.3", 0),
+		},
+	}}
+
+	expected := []podDiff{
+		{Namespace: "default", Pod: "api-2", Workload: "deployment/api", Change: podChanged, Details: []string{
+			"health Healthy → Critical (CrashLoopBackOff)",
+			"app restarts +3",
+			"app image api:1.2 → api:1.3",
+		}},
+		{Namespace: "default", Pod: "api-3", Workload: "deployment/api", Change: podRemoved},
+		{Namespace: "default", Pod: "api-4", Workload: "deployment/api", Change: podAdded, Details: []string{"health Healthy"}},
+	}
+
+	if diffs := diffWorkloads(previous, current); !reflect.DeepEqual(diffs, expected) {
+		t.Errorf("diffWorkloads() =\n%+v\nwant\n%+v", diffs, expected)
+	}
+	if diffs := diffWorkloads(current, current); len(diffs) != 0 {
+		t.Errorf("expected no changes against an identical snapshot, got %+v", diffs)
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "before.json")
+	workloads := []types.WorkloadInfo{{
+		Kind: "Deployment",
+		Name: "api",
+		Pods: []types.PodInfo{{
+			Name:       "api-1",
+			Namespace:  "default",
+			Age:        90 * time.Minute,
+			Health:     types.HealthStatus{Level: string(types.HealthLevelHealthy)},
+			Containers: []types.ContainerInfo{{Name: "app", Image: "api:1.2", RestartCount: 2}},
+		}},
+	}}
+
+	if err := WriteSnapshot(path, workloads); err != nil {
+		t.Fatalf("WriteSnapshot() failed: %v", err)
+	}
+	loaded, savedAt, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatalf("ReadSnapshot() failed: %v", err)
+	}
+	if savedAt.IsZero() {
+		t.Error("expected the snapshot's modification time")
+	}
+	if diffs := diffWorkloads(loaded, workloads); len(diffs) != 0 {
+		t.Errorf("expected a saved snapshot to match what was saved, got %+v", diffs)
+	}
+
+	if _, _, err := ReadSnapshot(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing snapshot")
+	}
+}
+
+func TestOutputDiffSavedTime(t *testing.T) {
+	savedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		options  *types.Options
+		expected string
+	}{
+		{name: "relative", options: &types.Options{}, expected: " ago)"},
+		{name: "timestamps", options: &types.Options{Timestamps: true, UTC: true}, expected: "(saved 2026-10-16T12:00:00Z)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewWithWriter(tt.options, &buf).OutputDiff(nil, nil, "before.json", savedAt); err != nil {
+				t.Fatalf("OutputDiff failed: %v", err)
+			}
+			header := strings.SplitN(buf.String(), "\n", 2)[0]
+			if !strings.HasSuffix(header, tt.expected) {
+				t.Errorf("expected header ending in %q, got %q", tt.expected, header)
+			}
+		})
+	}
+}
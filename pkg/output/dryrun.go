@@ -0,0 +1,67 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// OutputSelectorMatches prints what --dry-run found: how many pods the selector matched and the owners
+// they resolve to, one line per owner
+func (f *Formatter) OutputSelectorMatches(owners []types.SelectorOwner, matched int) error {
+	switch f.options.OutputFormat {
+	case "json":
+		data, err := json.MarshalIndent(owners, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Fprintln(f.out, string(data))
+		return nil
+	case "yaml":
+		data, err := yaml.Marshal(owners)
+		if err != nil {
+			return fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+		fmt.Fprintln(f.out, string(data))
+		return nil
+	}
+
+	headerColor := color.New(color.FgCyan, color.Bold)
+	fmt.Fprintf(f.out, "🔎 %s %q matches %s", headerColor.Sprint("SELECTOR"), f.options.Selector, countNoun(matched, "pod"))
+	if len(owners) == 0 {
+		fmt.Fprintln(f.out)
+		return nil
+	}
+	fmt.Fprintf(f.out, " owned by %s:\n", countNoun(len(owners), "workload"))
+
+	width := 0
+	for _, owner := range owners {
+		width = max(width, len(f.selectorOwnerName(owner)))
+	}
+	for _, owner := range owners {
+		fmt.Fprintf(f.out, "  %-*s  %s\n", width, f.selectorOwnerName(owner), countNoun(owner.Pods, "pod"))
+	}
+	return nil
+}
+
+// selectorOwnerName renders an owner as kind/name, prefixed with its namespace across namespaces
+func (f *Formatter) selectorOwnerName(owner types.SelectorOwner) string {
+	name := fmt.Sprintf("%s/%s", strings.ToLower(owner.Kind), owner.Name)
+	if f.options.AllNamespaces {
+		name = owner.Namespace + "/" + name
+	}
+	return name
+}
+
+// countNoun renders a count with its noun, e.g. "1 pod" or "3 pods"
+func countNoun(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestOutputSelectorMatches(t *testing.T) {
+	var output bytes.Buffer
+	f := NewWithWriter(&types.Options{OutputFormat: "table", NoColor: true, Selector: "app=web"}, &output)
+	owners := []types.SelectorOwner{
+		{Kind: "Deployment", Name: "web", Namespace: "default", Pods: 2},
+		{Kind: "Pod", Name: "web-debug", Namespace: "default", Pods: 1},
+	}
+
+	if err := f.OutputSelectorMatches(owners, 3); err != nil {
+		t.Fatalf("OutputSelectorMatches() failed: %v", err)
+	}
+	for _, want := range []string{
+		`"app=web" matches 3 pods owned by 2 workloads:`,
+		"  deployment/web  2 pods\n",
+		"  pod/web-debug   1 pod\n",
+	} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, output.String())
+		}
+	}
+
+	output.Reset()
+	if err := f.OutputSelectorMatches(nil, 0); err != nil {
+		t.Fatalf("OutputSelectorMatches() failed: %v", err)
+	}
+	if !strings.Contains(output.String(), "matches 0 pods\n") {
+		t.Errorf("expected a zero count, got:\n%s", output.String())
+	}
+}
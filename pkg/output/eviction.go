@@ -0,0 +1,139 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// Eviction risk levels shown by --eviction-risk
+const (
+	evictionRiskHigh    = "High"
+	evictionRiskMedium  = "Medium"
+	evictionRiskLow     = "Low"
+	evictionRiskUnknown = "Unknown"
+)
+
+// evictionRiskRow is one pod's standing in the order the kubelet evicts pods under memory pressure
+type evictionRiskRow struct {
+	Pod        string
+	QOSClass   string
+	Priority   int32
+	MemUsage   int64 // Bytes across the pod's containers
+	HasUsage   bool  // Whether every container had a usage reading; MemUsage is zero otherwise
+	MemRequest int64 // Bytes across the pod's containers, zero when nothing is requested
+	Risk       string
+	tier       int // Lower tiers are evicted first
+}
+
+// overRequest is how many bytes the pod uses beyond what it requested
+func (r evictionRiskRow) overRequest() int64 {
+	return r.MemUsage - r.MemRequest
+}
+
+// evictionRanking orders pods the way the kubelet picks memory-pressure victims: pods using more than
+// they request (BestEffort pods request nothing) before pods within their requests, then lower priority
+// first, then the biggest usage above the request. QoS class only sets the risk label, Guaranteed pods
+// staying within their requests by construction. Pods without a usage reading can't be placed in that
+// order, so they come last, by priority, with an unknown risk.
+func evictionRanking(pods []types.PodInfo) []evictionRiskRow {
+	rows := make([]evictionRiskRow, 0, len(pods))
+	for _, pod := range pods {
+		row := evictionRiskRow{Pod: pod.Name, QOSClass: pod.QOSClass, HasUsage: len(pod.Containers) > 0}
+		if pod.Priority != nil {
+			row.Priority = *pod.Priority
+		}
+		for _, container := range pod.Containers {
+			row.MemUsage += parseBytes(container.Resources.MemUsage)
+			row.MemRequest += parseBytes(container.Resources.MemRequest)
+			row.HasUsage = row.HasUsage && container.Resources.HasMemUsage
+		}
+
+		switch {
+		case !row.HasUsage:
+			row.MemUsage = 0
+			row.tier, row.Risk = 2, evictionRiskUnknown
+		case row.overRequest() > 0:
+			row.tier, row.Risk = 0, evictionRiskHigh
+		case pod.QOSClass == "Guaranteed":
+			row.tier, row.Risk = 1, evictionRiskLow
+		default:
+			row.tier, row.Risk = 1, evictionRiskMedium
+		}
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].tier != rows[j].tier {
+			return rows[i].tier < rows[j].tier
+		}
+		if rows[i].Priority != rows[j].Priority {
+			return rows[i].Priority < rows[j].Priority
+		}
+		if rows[i].overRequest() != rows[j].overRequest() {
+			return rows[i].overRequest() > rows[j].overRequest()
+		}
+		return rows[i].Pod < rows[j].Pod
+	})
+	return rows
+}
+
+// printEvictionRisk prints the workload's pods in the order they would be evicted, for --eviction-risk
+func (f *Formatter) printEvictionRisk(workload types.WorkloadInfo) {
+	if len(workload.Pods) == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.out, "EVICTION RISK (pods the kubelet evicts first under memory pressure at the top):")
+	table := tablewriter.NewWriter(f.out)
+	table.SetHeader([]string{"#", "POD", "QOS", "PRIORITY", "MEM USAGE", "MEM REQUEST", "RISK"})
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetBorder(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	for i, row := range evictionRanking(workload.Pods) {
+		qos := row.QOSClass
+		if qos == "" {
+			qos = "-"
+		}
+		request := "-"
+		if row.MemRequest > 0 {
+			request = formatBytes(row.MemRequest)
+		}
+		usage := "unknown"
+		if row.HasUsage {
+			usage = formatBytes(row.MemUsage)
+		}
+		table.Append([]string{
+			fmt.Sprintf("%d", i+1),
+			row.Pod,
+			qos,
+			fmt.Sprintf("%d", row.Priority),
+			usage,
+			request,
+			f.formatEvictionRisk(row.Risk),
+		})
+	}
+
+	table.Render()
+	fmt.Fprintln(f.out)
+}
+
+// formatEvictionRisk colors a risk level like the matching health level
+func (f *Formatter) formatEvictionRisk(risk string) string {
+	switch risk {
+	case evictionRiskHigh:
+		return f.getHealthColor(string(types.HealthLevelCritical)).Sprint(risk)
+	case evictionRiskMedium:
+		return f.getHealthColor(string(types.HealthLevelDegraded)).Sprint(risk)
+	case evictionRiskUnknown:
+		return risk
+	default:
+		return f.getHealthColor(string(types.HealthLevelHealthy)).Sprint(risk)
+	}
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestEvictionRanking(t *testing.T) {
+	pod := func(name, qos string, priority int32, usage, request string) types.PodInfo {
+		return types.PodInfo{
+			Name:       name,
+			QOSClass:   qos,
+			Priority:   &priority,
+			Containers: []types.ContainerInfo{{Name: "app", Resources: types.ResourceInfo{MemUsage: usage, MemRequest: request, HasMemUsage: usage != ""}}},
+		}
+	}
+
+	pods := []types.PodInfo{
+		pod("guaranteed", "Guaranteed", 0, "900Mi", "1Gi"),
+		pod("within-request", "Burstable", 0, "200Mi", "256Mi"),
+		pod("slightly-over", "Burstable", 0, "300Mi", "256Mi"),
+		pod("far-over", "Burstable", 0, "900Mi", "256Mi"),
+		pod("far-over-critical", "Burstable", 1000, "2Gi", "256Mi"),
+		pod("best-effort", "BestEffort", 0, "50Mi", ""),
+		// Priority counts before QoS class, like in the kubelet
+		pod("best-effort-critical", "BestEffort", 2000, "10Mi", ""),
+		// Without metrics a pod can't be placed
+		pod("no-metrics", "Burstable", 0, "", "256Mi"),
+	}
+
+	var order, risks []string
+	for _, row := range evictionRanking(pods) {
+		order = append(order, row.Pod)
+		risks = append(risks, row.Risk)
+	}
+
+	expectedOrder := []string{"far-over", "best-effort", "slightly-over", "far-over-critical", "best-effort-critical", "within-request", "guaranteed", "no-metrics"}
+	if !reflect.DeepEqual(order, expectedOrder) {
+		t.Errorf("evictionRanking() order = %v, want %v", order, expectedOrder)
+	}
+	expectedRisks := []string{evictionRiskHigh, evictionRiskHigh, evictionRiskHigh, evictionRiskHigh, evictionRiskHigh, evictionRiskMedium, evictionRiskLow, evictionRiskUnknown}
+	if !reflect.DeepEqual(risks, expectedRisks) {
+		t.Errorf("evictionRanking() risks = %v, want %v", risks, expectedRisks)
+	}
+}
@@ -1,8 +1,11 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"sort"
 	"strings"
@@ -11,6 +14,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/nareshku/kubectl-container-status/pkg/analyzer"
 	"github.com/nareshku/kubectl-container-status/pkg/types"
@@ -21,26 +25,125 @@ import (
 type Formatter struct {
 	options  *types.Options
 	analyzer *analyzer.Analyzer
+	location *time.Location // Time zone for absolute timestamps
+	out      io.Writer      // Where the formatted output goes; stderr is only used for warnings next to machine-readable output
 }
 
-// New creates a new formatter instance
+// New creates a new formatter instance that writes to stdout
 func New(options *types.Options) *Formatter {
+	return NewWithWriter(options, os.Stdout)
+}
+
+// NewWithWriter creates a formatter that writes to w, e.g. the --output-file or a buffer in tests
+func NewWithWriter(options *types.Options, w io.Writer) *Formatter {
 	return &Formatter{
 		options:  options,
 		analyzer: analyzer.New(),
+		location: timestampLocation(options),
+		out:      w,
+	}
+}
+
+// timestampLocation returns the time zone absolute timestamps are rendered in
+func timestampLocation(options *types.Options) *time.Location {
+	if options.UTC {
+		return time.UTC
+	}
+	if options.Timezone != "" {
+		if location, err := time.LoadLocation(options.Timezone); err == nil {
+			return location
+		}
 	}
+	return time.Local
 }
 
-// Output formats and outputs the workload information
+// Output formats and outputs the workload information. With --status, only pods running a container
+// in one of the statuses are output.
 func (f *Formatter) Output(workloads []types.WorkloadInfo) error {
+	if len(f.options.Statuses) > 0 {
+		matching := filterContainerStatuses(workloads, f.options.Statuses)
+		if len(matching) == 0 && f.options.OutputFormat == "table" {
+			fmt.Fprintf(f.out, "No containers with status %s found\n", strings.Join(f.options.Statuses, ", "))
+			f.printWarnings(f.out, workloads)
+			return nil
+		}
+		workloads = matching
+	}
+
 	switch f.options.OutputFormat {
 	case "json":
 		return f.outputJSON(workloads)
 	case "yaml":
 		return f.outputYAML(workloads)
+	case "html":
+		f.printWarnings(os.Stderr, workloads)
+		return f.outputHTML(workloads)
+	case "name":
+		f.printWarnings(os.Stderr, workloads)
+		return f.outputNames(workloads)
+	case "junit":
+		f.printWarnings(os.Stderr, workloads)
+		return f.outputJUnit(workloads)
 	default:
-		return f.outputTable(workloads)
+		if f.options.Quiet {
+			f.printWarnings(os.Stderr, workloads)
+			return f.outputQuiet(workloads)
+		}
+		var err error
+		if f.options.Summary {
+			err = f.outputSummary(workloads)
+		} else if f.options.Top {
+			err = f.outputTop(workloads)
+		} else {
+			err = f.outputTable(workloads)
+		}
+		if err == nil && f.options.Explain {
+			f.printRemediations(f.analyzer.Explain(workloads))
+		}
+		return err
+	}
+}
+
+// printRemediations prints a block of suggested next steps per distinct issue
+func (f *Formatter) printRemediations(remediations []analyzer.Remediation) {
+	fmt.Fprintln(f.out)
+	if len(remediations) == 0 {
+		fmt.Fprintln(f.out, "NEXT STEPS: no issues found")
+		return
+	}
+
+	fmt.Fprintln(f.out, "NEXT STEPS:")
+	for _, remediation := range remediations {
+		fmt.Fprintln(f.out)
+		fmt.Fprintf(f.out, "  %s\n", f.getHealthColor(string(types.HealthLevelDegraded)).Sprint(remediation.Issue))
+		fmt.Fprintf(f.out, "    Affected pods: %s\n", strings.Join(remediation.Pods, ", "))
+		for _, hint := range remediation.Hints {
+			fmt.Fprintf(f.out, "    • %s\n", hint)
+		}
+	}
+}
+
+// outputNames prints one pod reference per line, like kubectl get -o name, for use in shell pipelines
+func (f *Formatter) outputNames(workloads []types.WorkloadInfo) error {
+	for _, name := range podNames(workloads, f.options.AllNamespaces) {
+		fmt.Fprintln(f.out, name)
+	}
+	return nil
+}
+
+// podNames returns pod/<name> for every pod, or <namespace>/<name> when pods span namespaces
+func podNames(workloads []types.WorkloadInfo, allNamespaces bool) []string {
+	var names []string
+	for _, workload := range workloads {
+		for _, pod := range workload.Pods {
+			if allNamespaces {
+				names = append(names, pod.Namespace+"/"+pod.Name)
+			} else {
+				names = append(names, "pod/"+pod.Name)
+			}
+		}
 	}
+	return names
 }
 
 // outputJSON outputs workloads in JSON format
@@ -49,7 +152,7 @@ func (f *Formatter) outputJSON(workloads []types.WorkloadInfo) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(f.out, string(data))
 	return nil
 }
 
@@ -59,7 +162,7 @@ func (f *Formatter) outputYAML(workloads []types.WorkloadInfo) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal YAML: %w", err)
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(f.out, string(data))
 	return nil
 }
 
@@ -67,16 +170,196 @@ func (f *Formatter) outputYAML(workloads []types.WorkloadInfo) error {
 func (f *Formatter) outputTable(workloads []types.WorkloadInfo) error {
 	for i, workload := range workloads {
 		if i > 0 {
-			fmt.Println() // Add blank line between workloads
+			fmt.Fprintln(f.out) // Add blank line between workloads
 		}
 
 		if err := f.formatWorkload(workload); err != nil {
 			return err
 		}
 	}
+	f.printMetricsUnavailableNote(workloads)
+	f.printWarnings(f.out, workloads)
+	return nil
+}
+
+// collectedWarnings returns the distinct warnings from all workloads, in the order they were raised
+func collectedWarnings(workloads []types.WorkloadInfo) []string {
+	seen := make(map[string]bool)
+	var warnings []string
+	for _, workload := range workloads {
+		for _, warning := range workload.Warnings {
+			if !seen[warning] {
+				seen[warning] = true
+				warnings = append(warnings, warning)
+			}
+		}
+	}
+	return warnings
+}
+
+// printWarnings prints one consolidated block of everything that was degraded during collection
+func (f *Formatter) printWarnings(w io.Writer, workloads []types.WorkloadInfo) {
+	warnings := collectedWarnings(workloads)
+	if len(warnings) == 0 {
+		return
+	}
+	fmt.Fprintln(w, f.getHealthColor(string(types.HealthLevelDegraded)).Sprint("⚠ Warnings:"))
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "  • %s\n", warning)
+	}
+}
+
+// missingUsage is the placeholder shown for CPU/memory usage that could not be collected
+func (f *Formatter) missingUsage(metricsUnavailable bool) string {
+	if metricsUnavailable {
+		return "n/a"
+	}
+	return "-"
+}
+
+// metricsUnavailable reports whether usage couldn't be read for any of the workloads
+func metricsUnavailable(workloads []types.WorkloadInfo) bool {
+	for _, workload := range workloads {
+		if workload.MetricsUnavailable {
+			return true
+		}
+	}
+	return false
+}
+
+// metricsForbidden reports whether reading usage was denied for any of the workloads
+func metricsForbidden(workloads []types.WorkloadInfo) bool {
+	for _, workload := range workloads {
+		if workload.MetricsForbidden {
+			return true
+		}
+	}
+	return false
+}
+
+// printMetricsUnavailableNote prints a single footer explaining n/a usage when the metrics API is missing or forbidden
+func (f *Formatter) printMetricsUnavailableNote(workloads []types.WorkloadInfo) {
+	if !metricsUnavailable(workloads) {
+		return
+	}
+	if metricsForbidden(workloads) {
+		fmt.Fprintln(f.out, "ℹ️  Resource usage is n/a: metrics unavailable (forbidden to read pods.metrics.k8s.io)")
+		return
+	}
+	if len(f.options.FromFile) > 0 {
+		fmt.Fprintln(f.out, "ℹ️  Resource usage is n/a: a pod dump has no usage (add it with --metrics-from)")
+		return
+	}
+	fmt.Fprintln(f.out, "ℹ️  Resource usage is n/a: the metrics API is not available (is metrics-server installed?)")
+}
+
+// outputSummary outputs a single roll-up row per workload
+func (f *Formatter) outputSummary(workloads []types.WorkloadInfo) error {
+	table := tablewriter.NewWriter(f.out)
+	headers := []string{"WORKLOAD", "READY", "HEALTH", "RESTARTS", "CPU (cores)", "MEMORY (working set)"}
+	if f.options.AllNamespaces {
+		headers = append([]string{"NAMESPACE"}, headers...)
+	}
+	table.SetHeader(headers)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetBorder(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	// Order rows by namespace and name for stable output
+	sorted := make([]types.WorkloadInfo, len(workloads))
+	copy(sorted, workloads)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	for _, workload := range sorted {
+		row := f.summaryRow(workload)
+		if f.options.AllNamespaces {
+			row = append([]string{workload.Namespace}, row...)
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+	f.printMetricsUnavailableNote(workloads)
+	f.printWarnings(f.out, workloads)
+	return nil
+}
+
+// outputQuiet prints a single status line per workload, e.g. "api: Healthy 3/3 restarts=0"
+func (f *Formatter) outputQuiet(workloads []types.WorkloadInfo) error {
+	for _, workload := range workloads {
+		name := workload.Name
+		if f.options.AllNamespaces {
+			name = fmt.Sprintf("%s/%s", workload.Namespace, workload.Name)
+		}
+		readyPods, totalRestarts := f.readyAndRestarts(workload)
+		fmt.Fprintf(f.out, "%s: %s %d/%d restarts=%d\n",
+			name,
+			f.getHealthColor(workload.Health.Level).Sprint(workload.Health.Level),
+			readyPods, len(workload.Pods),
+			totalRestarts)
+	}
 	return nil
 }
 
+// readyAndRestarts counts the fully ready pods of a workload and the restarts of all its containers
+func (f *Formatter) readyAndRestarts(workload types.WorkloadInfo) (int, int32) {
+	readyPods := 0
+	totalRestarts := int32(0)
+	for _, pod := range workload.Pods {
+		if len(pod.Containers) > 0 && f.getReadyCount(pod) == len(pod.Containers) {
+			readyPods++
+		}
+		for _, container := range append(pod.InitContainers, pod.Containers...) {
+			totalRestarts += container.RestartCount
+		}
+	}
+	return readyPods, totalRestarts
+}
+
+// summaryRow collapses a workload into its roll-up table row
+func (f *Formatter) summaryRow(workload types.WorkloadInfo) []string {
+	readyPods, totalRestarts := f.readyAndRestarts(workload)
+	var milliCPU, memBytes int64
+	hasMetrics := false
+
+	for _, pod := range workload.Pods {
+		// Summed from the exact readings, like --top, rather than the rounded display strings
+		if pod.Metrics != nil {
+			if pod.Metrics.CPUUsage != "" {
+				milliCPU += pod.Metrics.CPUUsageMilli
+				hasMetrics = true
+			}
+			if pod.Metrics.MemoryUsage != "" {
+				memBytes += pod.Metrics.MemoryUsageBytes
+				hasMetrics = true
+			}
+		}
+	}
+
+	cpuUsage := f.missingUsage(workload.MetricsUnavailable)
+	memoryUsage := f.missingUsage(workload.MetricsUnavailable)
+	if hasMetrics {
+		cpuUsage = f.formatCPUValue(formatMilliCPU(milliCPU), milliCPU)
+		memoryUsage = f.formatMemoryValue(formatBytes(memBytes), memBytes)
+	}
+
+	healthIcon := f.analyzer.GetHealthIcon(workload.Health.Level)
+	return []string{
+		fmt.Sprintf("%s/%s", strings.ToLower(workload.Kind), workload.Name),
+		fmt.Sprintf("%d/%d", readyPods, len(workload.Pods)),
+		fmt.Sprintf("%s %s", healthIcon, workload.Health.Level),
+		fmt.Sprintf("%d", totalRestarts),
+		cpuUsage,
+		memoryUsage,
+	}
+}
+
 // formatWorkload formats a single workload
 func (f *Formatter) formatWorkload(workload types.WorkloadInfo) error {
 	// Sort pods if requested
@@ -97,7 +380,7 @@ func (f *Formatter) formatWorkload(workload types.WorkloadInfo) error {
 		// Single pod: use detailed view (existing behavior)
 		f.printSummary(workload)
 		for _, pod := range workload.Pods {
-			if err := f.formatPodWithContext(pod, true); err != nil {
+			if err := f.formatPodWithContext(pod, true, workload.MetricsUnavailable); err != nil {
 				return err
 			}
 		}
@@ -106,6 +389,14 @@ func (f *Formatter) formatWorkload(workload types.WorkloadInfo) error {
 		// Multi-pod workload: use enhanced table view
 		f.printWorkloadSummary(workload)
 		f.printWorkloadTable(workload)
+		f.printWorkloadWaitingMessages(workload)
+		f.printWorkloadNodeProblems(workload)
+		if f.options.NodeSummary {
+			f.printNodeSpread(workload)
+		}
+		if f.options.EvictionRisk {
+			f.printEvictionRisk(workload)
+		}
 
 		// Show aggregated events if requested
 		f.printWorkloadEvents(workload)
@@ -126,9 +417,7 @@ func (f *Formatter) printWorkloadHeader(workload types.WorkloadInfo) {
 	if workload.Kind == "Pod" && len(workload.Pods) == 1 {
 		pod := workload.Pods[0]
 		// Only count regular containers (not init containers) to match kubectl behavior
-		totalContainers := len(pod.Containers)
-		readyContainers := f.getReadyCount(pod)
-		replicasInfo = fmt.Sprintf("CONTAINERS: %d/%d", readyContainers, totalContainers)
+		replicasInfo = fmt.Sprintf("CONTAINERS: %s", f.formatReadyCount(pod))
 	} else {
 		replicasInfo = fmt.Sprintf("REPLICAS: %s", workload.Replicas)
 	}
@@ -138,27 +427,36 @@ func (f *Formatter) printWorkloadHeader(workload types.WorkloadInfo) {
 	separator := separatorColor.Sprint(strings.Repeat("─", 60))
 
 	// Enhanced header with better visual hierarchy
-	fmt.Println(separator)
+	fmt.Fprintln(f.out, separator)
 
 	// For single pods, include NODE and AGE in the header to avoid redundancy
 	if workload.Kind == "Pod" && len(workload.Pods) == 1 {
 		pod := workload.Pods[0]
 
-		// Build the header with optional service account
+		// Build the header with optional service account. The workload is the pod itself here, so its
+		// name links with --pod-url; workload headers below have no URL template and stay plain.
 		baseInfo := fmt.Sprintf("🎯 %s: %s   %s   📍 NODE: %s   ⏰ AGE: %s   🏷️  NAMESPACE: %s",
 			headerColor.Sprintf("%s", strings.ToUpper(workload.Kind)),
-			headerColor.Sprintf("%s", workload.Name),
+			f.hyperlink(headerColor.Sprintf("%s", pod.Name), f.podURL(pod)),
 			replicasInfo,
-			pod.NodeName,
-			f.formatDuration(pod.Age),
+			f.hyperlink(pod.NodeName, f.nodeURL(pod.NodeName)),
+			f.formatAge(pod.Age),
 			workload.Namespace,
 		)
 
 		// Add service account if present and not default
 		if pod.ServiceAccount != "" && pod.ServiceAccount != "default" {
-			fmt.Printf("%s   🔐 SERVICE ACCOUNT: %s\n", baseInfo, pod.ServiceAccount)
-		} else {
-			fmt.Printf("%s\n", baseInfo)
+			baseInfo += fmt.Sprintf("   🔐 SERVICE ACCOUNT: %s", pod.ServiceAccount)
+		}
+		if priority := formatPriority(pod); priority != "" {
+			baseInfo += fmt.Sprintf("   ⚖️  PRIORITY: %s", priority)
+		}
+		fmt.Fprintf(f.out, "%s\n", baseInfo)
+		if f.options.ShowIDs {
+			fmt.Fprintf(f.out, "🆔 UID: %s   RESOURCE VERSION: %s\n", pod.UID, pod.ResourceVersion)
+		}
+		if scheduling := f.formatScheduling(pod); scheduling != "" {
+			fmt.Fprintf(f.out, "🗓️  SCHEDULING: %s\n", scheduling)
 		}
 
 		// Add network information for single pods
@@ -175,7 +473,7 @@ func (f *Formatter) printWorkloadHeader(workload types.WorkloadInfo) {
 			networkInfo = fmt.Sprintf("   🌐 NETWORK: %s", networkType)
 		}
 
-		fmt.Printf("🎯 %s: %s   %s   🏷️  NAMESPACE: %s%s\n",
+		fmt.Fprintf(f.out, "🎯 %s: %s   %s   🏷️  NAMESPACE: %s%s\n",
 			headerColor.Sprintf("%s", strings.ToUpper(workload.Kind)),
 			headerColor.Sprintf("%s", workload.Name),
 			replicasInfo,
@@ -184,20 +482,94 @@ func (f *Formatter) printWorkloadHeader(workload types.WorkloadInfo) {
 		)
 	}
 
+	if workload.Job != nil {
+		fmt.Fprintf(f.out, "🔁 JOB: %s\n", f.formatJobInfo(*workload.Job))
+	}
+	for _, pdb := range workload.PDBs {
+		fmt.Fprintf(f.out, "🛡️  PDB: %s\n", f.formatPDB(pdb))
+	}
+	for _, hpa := range workload.HPAs {
+		fmt.Fprintf(f.out, "📈 HPA: %s\n", f.formatHPA(hpa))
+	}
+
 	// Enhanced health status with box drawing characters for emphasis
 	healthBorder := "┌─ HEALTH STATUS ──────────────────────────────────────┐"
 	healthBottom := "└─────────────────────────────────────────────────────┘"
 
-	fmt.Println(separatorColor.Sprint(healthBorder))
-	fmt.Printf("│ %s %s %s (%s) %s│\n",
+	fmt.Fprintln(f.out, separatorColor.Sprint(healthBorder))
+	fmt.Fprintf(f.out, "│ %s %s %s (%s) %s│\n",
 		healthIcon,
 		healthColor.Sprintf("%-10s", strings.ToUpper(workload.Health.Level)),
 		healthColor.Sprintf("%-35s", workload.Health.Reason),
 		getHealthEmoji(workload.Health.Level),
 		strings.Repeat(" ", max(0, 8-len(getHealthEmoji(workload.Health.Level)))),
 	)
-	fmt.Println(separatorColor.Sprint(healthBottom))
-	fmt.Println()
+	fmt.Fprintln(f.out, separatorColor.Sprint(healthBottom))
+	fmt.Fprintln(f.out)
+}
+
+// formatPDB formats a disruption budget's allowance, highlighting budgets that currently block evictions
+func (f *Formatter) formatPDB(pdb types.PDBInfo) string {
+	allowed := fmt.Sprintf("%d disruptions allowed", pdb.DisruptionsAllowed)
+	if pdb.DisruptionsAllowed == 1 {
+		allowed = "1 disruption allowed"
+	}
+	if pdb.DisruptionsAllowed == 0 {
+		allowed = f.getHealthColor(string(types.HealthLevelDegraded)).Sprint(allowed)
+	}
+	return fmt.Sprintf("%s %s (%d/%d healthy)", pdb.Name, allowed, pdb.CurrentHealthy, pdb.ExpectedPods)
+}
+
+// formatHPA formats an autoscaler's replica decision and its metrics against their targets, e.g.
+// "api 3→5 replicas (cpu 85%/70% target)", highlighting autoscalers that can't scale up any further
+func (f *Formatter) formatHPA(hpa types.HPAInfo) string {
+	replicas := fmt.Sprintf("%d replicas", hpa.CurrentReplicas)
+	if hpa.DesiredReplicas != hpa.CurrentReplicas {
+		replicas = fmt.Sprintf("%d→%d replicas", hpa.CurrentReplicas, hpa.DesiredReplicas)
+	}
+
+	var details []string
+	for _, metric := range hpa.Metrics {
+		details = append(details, fmt.Sprintf("%s %s/%s target", metric.Name, metric.Current, metric.Target))
+	}
+	if hpa.MaxReplicas > 0 && hpa.DesiredReplicas >= hpa.MaxReplicas {
+		details = append(details, f.getHealthColor(string(types.HealthLevelDegraded)).Sprintf("at max %d", hpa.MaxReplicas))
+	} else {
+		details = append(details, fmt.Sprintf("range %d-%d", hpa.MinReplicas, hpa.MaxReplicas))
+	}
+	return fmt.Sprintf("%s %s (%s)", hpa.Name, replicas, strings.Join(details, ", "))
+}
+
+// formatJobInfo formats a Job's completion, retry and deadline settings on one line
+func (f *Formatter) formatJobInfo(job types.JobInfo) string {
+	retriesLeft := job.BackoffLimit - job.Failed
+	if retriesLeft < 0 {
+		retriesLeft = 0
+	}
+
+	retries := fmt.Sprintf("%d retries left", retriesLeft)
+	if retriesLeft == 0 && job.Failed > 0 {
+		retries = f.getHealthColor(string(types.HealthLevelCritical)).Sprint("no retries left")
+	} else if retriesLeft == 1 {
+		retries = f.getHealthColor(string(types.HealthLevelDegraded)).Sprint("1 retry left")
+	}
+
+	parts := []string{
+		fmt.Sprintf("completions %d/%d", job.Succeeded, job.Completions),
+		fmt.Sprintf("parallelism %d", job.Parallelism),
+		fmt.Sprintf("failed %d, backoffLimit %d (%s)", job.Failed, job.BackoffLimit, retries),
+	}
+	if job.Indexed {
+		parts = append(parts, "completionMode Indexed")
+	}
+	if job.RestartPolicy != "" {
+		parts = append(parts, fmt.Sprintf("restartPolicy %s", job.RestartPolicy))
+	}
+	if job.ActiveDeadlineSeconds != nil {
+		deadline := time.Duration(*job.ActiveDeadlineSeconds) * time.Second
+		parts = append(parts, fmt.Sprintf("activeDeadline %s", f.formatDuration(deadline)))
+	}
+	return strings.Join(parts, ", ")
 }
 
 // getHealthEmoji returns an additional emoji for health status
@@ -252,13 +624,13 @@ func (f *Formatter) printSummary(workload types.WorkloadInfo) {
 		}
 	}
 
-	fmt.Println("SUMMARY:")
+	fmt.Fprintln(f.out, "SUMMARY:")
 	if f.options.Problematic {
-		fmt.Printf("  • %d Problematic pods shown\n", len(workload.Pods))
+		fmt.Fprintf(f.out, "  • %d Problematic pods shown\n", len(workload.Pods))
 	} else {
-		fmt.Printf("  • %d Pods matched\n", len(workload.Pods))
+		fmt.Fprintf(f.out, "  • %d Pods matched\n", len(workload.Pods))
 	}
-	fmt.Printf("  • %d Running, %d Warning, %d Failed\n", running, warning, failed)
+	fmt.Fprintf(f.out, "  • %d Running, %d Warning, %d Failed\n", running, warning, failed)
 
 	// Format container names
 	var names []string
@@ -266,12 +638,13 @@ func (f *Formatter) printSummary(workload types.WorkloadInfo) {
 		names = append(names, name)
 	}
 	sort.Strings(names)
-	fmt.Printf("  • Containers: %s\n", strings.Join(names, ", "))
-	fmt.Printf("  • Total Restarts: %d\n\n", totalRestarts)
+	fmt.Fprintf(f.out, "  • Containers: %s\n", strings.Join(names, ", "))
+	fmt.Fprintf(f.out, "  • Total Restarts: %d\n\n", totalRestarts)
 }
 
 // formatPodWithContext formats a single pod with context about whether it's part of a single-pod workload
-func (f *Formatter) formatPodWithContext(pod types.PodInfo, isSinglePod bool) error {
+// and whether its usage could be read
+func (f *Formatter) formatPodWithContext(pod types.PodInfo, isSinglePod, metricsUnavailable bool) error {
 	// For multi-pod workloads, print pod header to distinguish between pods
 	if !isSinglePod {
 		f.printPodHeader(pod)
@@ -282,19 +655,94 @@ func (f *Formatter) formatPodWithContext(pod types.PodInfo, isSinglePod bool) er
 		return err
 	}
 
+	// Single pods have no pod header, so show conditions, scheduling constraints and finalizers here
+	if isSinglePod {
+		f.printPodConditions(pod)
+		f.printSchedulingConstraints(pod)
+		f.printNodePlacement(pod)
+		f.printNodeUsage(pod)
+		f.printNodeProblems(pod)
+		f.printPodFinalizers(pod)
+	}
+
 	f.printPodMetadata(pod)
 
-	for _, container := range pod.InitContainers {
-		f.printContainerDetails(container)
+	collapse := f.collapsesHealthyContainers(pod, isSinglePod)
+	hidden := 0
+	for _, container := range allContainers(pod) {
+		if !f.shouldShowContainer(container) {
+			continue
+		}
+		if collapse && container.Health.Level == string(types.HealthLevelHealthy) {
+			hidden++
+			continue
+		}
+		f.printContainerDetails(container, metricsUnavailable)
 	}
-	for _, container := range pod.Containers {
-		f.printContainerDetails(container)
+	if hidden > 0 {
+		containers := "containers"
+		if hidden == 1 {
+			containers = "container"
+		}
+		fmt.Fprintf(f.out, "%d healthy %s hidden (use --all)\n", hidden, containers)
 	}
 
-	fmt.Println() // Add spacing between pods
+	fmt.Fprintln(f.out) // Add spacing between pods
 	return nil
 }
 
+// collapseContainersThreshold is the container count from which healthy containers' details are
+// hidden by default, e.g. in pods carrying a service mesh proxy and several logging sidecars
+const collapseContainersThreshold = 8
+
+// collapsesHealthyContainers decides whether the pod's healthy containers are counted instead of detailed
+// in the single-pod view: always with --containers-summary-only, never with --all or for a container asked
+// for with -c, and otherwise for pods with many containers
+func (f *Formatter) collapsesHealthyContainers(pod types.PodInfo, isSinglePod bool) bool {
+	switch {
+	case !isSinglePod || f.options.AllContainers || f.options.ContainerName != "":
+		return false
+	case f.options.ContainersSummaryOnly:
+		return true
+	default:
+		return len(allContainers(pod)) >= collapseContainersThreshold
+	}
+}
+
+// summarizeEvictions describes the pods the kubelet evicted, which linger as Failed until deleted, by
+// what evicted them, e.g. "3 pods left behind (2 by node pressure, 1 by an ephemeral-storage limit)".
+// It returns "" when no pod was evicted.
+func (f *Formatter) summarizeEvictions(pods []types.PodInfo) string {
+	evicted := 0
+	counts := make(map[string]int)
+	var causes []string
+	for _, pod := range pods {
+		if !f.analyzer.IsPodEvicted(pod) {
+			continue
+		}
+		evicted++
+		cause := f.analyzer.EvictionCause(pod)
+		if counts[cause] == 0 {
+			causes = append(causes, cause)
+		}
+		counts[cause]++
+	}
+	switch len(causes) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("%s left behind by %s", countNoun(evicted, "pod"), causes[0])
+	}
+	sort.SliceStable(causes, func(i, j int) bool {
+		return counts[causes[i]] > counts[causes[j]]
+	})
+	var parts []string
+	for _, cause := range causes {
+		parts = append(parts, fmt.Sprintf("%d by %s", counts[cause], cause))
+	}
+	return fmt.Sprintf("%s left behind (%s)", countNoun(evicted, "pod"), strings.Join(parts, ", "))
+}
+
 // printPodHeader prints the pod header
 func (f *Formatter) printPodHeader(pod types.PodInfo) {
 	healthIcon := f.analyzer.GetHealthIcon(pod.Health.Level)
@@ -302,37 +750,87 @@ func (f *Formatter) printPodHeader(pod types.PodInfo) {
 
 	// Build pod header with status, optional service account
 	statusColor := f.getPodStatusColor(pod.Status)
+	status := pod.Status
+	if pod.StatusReason != "" {
+		status += " (" + pod.StatusReason + ")"
+	}
 	baseInfo := fmt.Sprintf("POD: %s   STATUS: %s   NODE: %s   AGE: %s",
-		color.New(color.Bold).Sprintf("%s", pod.Name),
-		statusColor.Sprintf("%s", pod.Status),
-		pod.NodeName,
-		f.formatDuration(pod.Age),
+		f.hyperlink(color.New(color.Bold).Sprintf("%s", pod.Name), f.podURL(pod)),
+		statusColor.Sprintf("%s", status),
+		f.hyperlink(pod.NodeName, f.nodeURL(pod.NodeName)),
+		f.formatAge(pod.Age),
 	)
 
 	// Add service account if present and not default
 	if pod.ServiceAccount != "" && pod.ServiceAccount != "default" {
-		fmt.Printf("%s   SERVICE ACCOUNT: %s\n", baseInfo, pod.ServiceAccount)
-	} else {
-		fmt.Printf("%s\n", baseInfo)
+		baseInfo += fmt.Sprintf("   SERVICE ACCOUNT: %s", pod.ServiceAccount)
 	}
+	if priority := formatPriority(pod); priority != "" {
+		baseInfo += fmt.Sprintf("   PRIORITY: %s", priority)
+	}
+	if pod.CompletionIndex != "" {
+		baseInfo += fmt.Sprintf("   INDEX: %s", pod.CompletionIndex)
+	}
+	fmt.Fprintf(f.out, "%s\n", baseInfo)
 
 	// Add network information
 	f.printNetworkInfo(pod)
 
-	fmt.Printf("%s HEALTH: %s (%s)\n",
+	fmt.Fprintf(f.out, "%s HEALTH: %s (%s)\n",
 		healthIcon,
 		healthColor.Sprintf("%s", pod.Health.Level),
 		pod.Health.Reason,
 	)
+	// The full message, e.g. an eviction's thresholds and per-container usage
+	if pod.StatusReason != "" && pod.StatusMessage != "" {
+		fmt.Fprintf(f.out, "   %s %s\n", statusColor.Sprintf("%s:", pod.StatusReason), pod.StatusMessage)
+	}
 
 	// Show conditions for pending pods or if there are failed conditions
 	f.printPodConditions(pod)
-	fmt.Println()
+	f.printNodeProblems(pod)
+	f.printPodFinalizers(pod)
+	fmt.Fprintln(f.out)
+}
+
+// formatScheduling describes which scheduler placed the pod and how long it took, e.g.
+// "scheduled by default-scheduler after 2s", or how long a pending pod has waited for a node
+func (f *Formatter) formatScheduling(pod types.PodInfo) string {
+	if pod.SchedulingLatency != nil {
+		scheduled := "scheduled"
+		if pod.SchedulerName != "" {
+			scheduled += " by " + pod.SchedulerName
+		}
+		return fmt.Sprintf("%s after %s", scheduled, f.formatDuration(*pod.SchedulingLatency))
+	}
+	if pod.Status == "Pending" {
+		waiting := "not yet scheduled"
+		// A custom scheduler that isn't running is a common reason for pods that never get a node
+		if pod.SchedulerName != "" && pod.SchedulerName != "default-scheduler" {
+			waiting += " by " + pod.SchedulerName
+		}
+		return f.getHealthColor(string(types.HealthLevelDegraded)).Sprintf("%s (pending %s)", waiting, f.formatAge(pod.Age))
+	}
+	return ""
+}
+
+// formatPriority formats the pod's priority class and value, or "" when the pod has the default priority
+func formatPriority(pod types.PodInfo) string {
+	switch {
+	case pod.PriorityClassName != "" && pod.Priority != nil:
+		return fmt.Sprintf("%s (%d)", pod.PriorityClassName, *pod.Priority)
+	case pod.PriorityClassName != "":
+		return pod.PriorityClassName
+	case pod.Priority != nil && *pod.Priority != 0:
+		return fmt.Sprintf("%d", *pod.Priority)
+	default:
+		return ""
+	}
 }
 
 // printContainerTable prints the container status table
 func (f *Formatter) printContainerTable(pod types.PodInfo) error {
-	table := tablewriter.NewWriter(os.Stdout)
+	table := tablewriter.NewWriter(f.out)
 	table.SetHeader([]string{"CONTAINER", "STATUS", "RESTARTS", "LAST STATE", "EXIT CODE"})
 	table.SetAutoFormatHeaders(false)
 	table.SetBorder(true)
@@ -340,41 +838,85 @@ func (f *Formatter) printContainerTable(pod types.PodInfo) error {
 	// Configure table formatting for better width handling
 	f.configureContainerTableWidths(table)
 
-	// Add init containers
-	for _, container := range pod.InitContainers {
-		if f.shouldShowContainer(container.Name) {
-			f.addContainerRow(table, container)
-		}
-	}
-
-	// Add regular containers
-	for _, container := range pod.Containers {
-		if f.shouldShowContainer(container.Name) {
+	// Init containers first, then regular and ephemeral containers
+	for _, container := range allContainers(pod) {
+		if f.shouldShowContainer(container) {
 			f.addContainerRow(table, container)
 		}
 	}
 
 	table.Render()
-	fmt.Println()
+	fmt.Fprintln(f.out)
 	return nil
 }
 
+// signalNames names the signals that commonly end a container
+var signalNames = map[int32]string{
+	1:  "SIGHUP",
+	2:  "SIGINT",
+	3:  "SIGQUIT",
+	4:  "SIGILL",
+	6:  "SIGABRT",
+	7:  "SIGBUS",
+	8:  "SIGFPE",
+	9:  "SIGKILL",
+	11: "SIGSEGV",
+	13: "SIGPIPE",
+	14: "SIGALRM",
+	15: "SIGTERM",
+}
+
+// maxSignal is the highest Linux signal number (SIGRTMAX)
+const maxSignal = 64
+
+// formatExitCode renders an exit code with the signal that caused it, e.g. "137 (SIGKILL)". The
+// signal comes from the runtime when it reports one, otherwise from the shell convention that
+// codes 129 to 192 mean the process died from signal code-128; higher codes (like 255) are plain
+// exit codes, since there are only 64 signals.
+func formatExitCode(exitCode, signal int32) string {
+	if signal == 0 && exitCode > 128 && exitCode <= 128+maxSignal {
+		signal = exitCode - 128
+	}
+	if signal == 0 {
+		return fmt.Sprintf("%d", exitCode)
+	}
+	name, ok := signalNames[signal]
+	if !ok {
+		name = fmt.Sprintf("signal %d", signal)
+	}
+	return fmt.Sprintf("%d (%s)", exitCode, name)
+}
+
+// waitingMessageWidth is the maximum width of a waiting-state message in the container table
+const waitingMessageWidth = 60
+
+// truncateMessage collapses a message onto one line and truncates it to at most width runes
+func truncateMessage(message string, width int) string {
+	message = strings.Join(strings.Fields(message), " ")
+	runes := []rune(message)
+	if len(runes) <= width {
+		return message
+	}
+	return string(runes[:width-3]) + "..."
+}
+
 // addContainerRow adds a container row to the table
 func (f *Formatter) addContainerRow(table *tablewriter.Table, container types.ContainerInfo) {
-	name := container.Name
-	if container.Type == string(types.ContainerTypeInit) {
-		name = fmt.Sprintf("[init] %s", container.Name)
-	}
+	name := containerDisplayName(container)
 
 	statusIcon := f.analyzer.GetStatusIcon(container.Status)
 	status := container.Status
+	// Show why a waiting container is stuck (e.g. ImagePullBackOff) without drilling into the pod
+	if container.StatusMessage != "" {
+		status = fmt.Sprintf("%s: %s", container.Status, truncateMessage(container.StatusMessage, waitingMessageWidth))
+	}
 	if !f.options.NoColor {
-		status = fmt.Sprintf("%s %s", statusIcon, container.Status)
+		status = fmt.Sprintf("%s %s", statusIcon, status)
 	}
 
 	exitCode := "-"
 	if container.ExitCode != nil {
-		exitCode = fmt.Sprintf("%d", *container.ExitCode)
+		exitCode = formatExitCode(*container.ExitCode, container.Signal)
 		if *container.ExitCode != 0 && !f.options.NoColor {
 			exitCode = color.RedString(exitCode)
 		}
@@ -395,31 +937,43 @@ func (f *Formatter) addContainerRow(table *tablewriter.Table, container types.Co
 	})
 }
 
-// printContainerDetails prints detailed container information
-func (f *Formatter) printContainerDetails(container types.ContainerInfo) {
+// printContainerDetails prints detailed container information; usage shows as n/a when metricsUnavailable
+func (f *Formatter) printContainerDetails(container types.ContainerInfo, metricsUnavailable bool) {
 	gearIcon := "⚙️"
 	statusIcon := f.analyzer.GetStatusIcon(container.Status)
 
-	// Add [init] prefix for init containers
-	containerName := container.Name
-	if container.Type == string(types.ContainerTypeInit) {
-		containerName = fmt.Sprintf("[init] %s", container.Name)
-	}
+	containerName := containerDisplayName(container)
 
-	fmt.Printf("%s  Container: %s\n", gearIcon, color.New(color.Bold).Sprintf("%s", containerName))
+	fmt.Fprintf(f.out, "%s  Container: %s\n", gearIcon, color.New(color.Bold).Sprintf("%s", containerName))
 
 	// Status
 	statusStr := fmt.Sprintf("%s %s", statusIcon, container.Status)
 	if container.StartedAt != nil {
-		statusStr += fmt.Sprintf(" (started %s ago)", f.formatDuration(time.Since(*container.StartedAt)))
+		statusStr += fmt.Sprintf(" (started %s)", f.formatTime(*container.StartedAt))
+	}
+	if container.StatusMessage != "" {
+		statusStr += ": " + container.StatusMessage
+	}
+	fmt.Fprintf(f.out, "  • Status:      %s\n", statusStr)
+
+	// Health as judged by the analyzer
+	if health := f.formatContainerHealth(container.Health); health != "" {
+		fmt.Fprintf(f.out, "  • Health:      %s\n", health)
 	}
-	fmt.Printf("  • Status:      %s\n", statusStr)
 
 	// Image
-	fmt.Printf("  • Image:       %s\n", container.Image)
+	fmt.Fprintf(f.out, "  • Image:       %s\n", container.Image)
+
+	if f.options.ShowSecurity {
+		fmt.Fprintf(f.out, "  • Security:    %s\n", f.formatSecurity(container.Security))
+	}
 
 	// Resources
-	f.printResourceUsage(container.Resources)
+	f.printResourceUsage(container.Resources, metricsUnavailable)
+	if container.Status == string(types.ContainerStatusRunning) {
+		f.printEfficiency(container.Resources, metricsUnavailable)
+	}
+	f.printUsageTrend(container.Resources.Samples)
 
 	// Probes
 	f.printProbes(container.Probes)
@@ -439,7 +993,7 @@ func (f *Formatter) printContainerDetails(container types.ContainerInfo) {
 	}
 
 	// Command and arguments
-	f.printCommand(container.Command, container.Args)
+	f.printCommand(container)
 
 	// Container logs (if requested)
 	if f.options.ShowLogs && len(container.Logs) > 0 {
@@ -449,54 +1003,126 @@ func (f *Formatter) printContainerDetails(container types.ContainerInfo) {
 	// Special handling for terminated containers
 	if container.Status == string(types.ContainerStatusTerminated) || container.RestartCount > 0 {
 		if container.ExitCode != nil {
-			fmt.Printf("  • Last Exit:   %s (exit code: %d)\n", container.TerminationReason, *container.ExitCode)
+			lastExit := "exit " + formatExitCode(*container.ExitCode, container.Signal)
+			if container.TerminationReason != "" {
+				lastExit = container.TerminationReason + ", " + lastExit
+			}
+			fmt.Fprintf(f.out, "  • Last Exit:   %s\n", lastExit)
 		}
 		if container.RestartCount > 0 {
 			restartInfo := fmt.Sprintf("  • Restart Count: %d", container.RestartCount)
 			if container.LastRestartTime != nil {
-				restartInfo += fmt.Sprintf(" (last restart: %s ago)", f.formatDuration(time.Since(*container.LastRestartTime)))
+				restartInfo += fmt.Sprintf(" (last restart: %s)", f.formatTime(*container.LastRestartTime))
 			}
 			// Add last restart reason if available
 			if container.LastStateReason != "" && container.LastState != "None" {
 				restartInfo += fmt.Sprintf(" - reason: %s", container.LastStateReason)
 			}
-			fmt.Printf("%s\n", restartInfo)
+			fmt.Fprintf(f.out, "%s\n", restartInfo)
 		}
+		if previous := f.formatPreviousInstance(container); previous != "" {
+			fmt.Fprintf(f.out, "  • Previous:    %s\n", previous)
+		}
+	}
+
+	fmt.Fprintln(f.out)
+}
+
+// formatPreviousInstance describes how long the container's previous instance ran and how it ended,
+// e.g. "ran 45s (started 10m ago, died 9m ago), exit 1", or "" when its timing isn't known
+func (f *Formatter) formatPreviousInstance(container types.ContainerInfo) string {
+	if container.LastStartedAt == nil || container.LastFinishedAt == nil {
+		return ""
+	}
+	previous := fmt.Sprintf("ran %s (started %s, died %s)",
+		f.formatDuration(container.LastFinishedAt.Sub(*container.LastStartedAt)),
+		f.formatTime(*container.LastStartedAt),
+		f.formatTime(*container.LastFinishedAt))
+	if container.LastExitCode != nil {
+		previous += ", exit " + formatExitCode(*container.LastExitCode, container.LastSignal)
+	}
+	return previous
+}
+
+// valueOrNone returns the value, or "none" if it is empty
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// formatSecurity renders a container's effective security context, with privileged and
+// root-running containers in red
+func (f *Formatter) formatSecurity(security types.SecurityInfo) string {
+	risky := f.getHealthColor(string(types.HealthLevelCritical))
+
+	var parts []string
+	if security.RunAsUser == nil {
+		parts = append(parts, "runAsUser: image default")
+	} else if *security.RunAsUser == 0 {
+		parts = append(parts, risky.Sprint("runAsUser: 0 (root)"))
+	} else {
+		parts = append(parts, fmt.Sprintf("runAsUser: %d", *security.RunAsUser))
+	}
+	if security.RunAsNonRoot != nil {
+		parts = append(parts, fmt.Sprintf("runAsNonRoot: %t", *security.RunAsNonRoot))
+	}
+	if security.Privileged {
+		parts = append(parts, risky.Sprint("privileged"))
 	}
+	parts = append(parts, fmt.Sprintf("readOnlyRootFilesystem: %t", security.ReadOnlyRootFilesystem))
 
-	fmt.Println()
+	var capabilities []string
+	for _, capability := range security.CapabilitiesAdded {
+		capabilities = append(capabilities, "+"+capability)
+	}
+	for _, capability := range security.CapabilitiesDropped {
+		capabilities = append(capabilities, "-"+capability)
+	}
+	if len(capabilities) > 0 {
+		parts = append(parts, "capabilities: "+strings.Join(capabilities, " "))
+	}
+	return strings.Join(parts, ", ")
 }
 
 // printPorts prints container port information
 func (f *Formatter) printPorts(ports []types.PortInfo) {
-	fmt.Printf("  • Ports:       \n")
+	fmt.Fprintf(f.out, "  • Ports:       \n")
 	for _, p := range ports {
 		desc := fmt.Sprintf("%d/%s", p.ContainerPort, strings.ToUpper(p.Protocol))
 		if p.HostPort != 0 {
 			desc += fmt.Sprintf(" (host:%d)", p.HostPort)
 		}
 		if p.Name != "" {
-			fmt.Printf("    - %s: %s\n", p.Name, desc)
+			fmt.Fprintf(f.out, "    - %s: %s\n", p.Name, desc)
 		} else {
-			fmt.Printf("    - %s\n", desc)
+			fmt.Fprintf(f.out, "    - %s\n", desc)
 		}
 	}
 }
 
 // printResourceUsage prints resource usage with progress bars
-func (f *Formatter) printResourceUsage(resources types.ResourceInfo) {
-	fmt.Printf("  • Resources:   ")
+func (f *Formatter) printResourceUsage(resources types.ResourceInfo, metricsUnavailable bool) {
+	fmt.Fprintf(f.out, "  • Resources:   ")
+
+	// Without metrics there is no usage to draw, only the configured limits
+	if metricsUnavailable {
+		fmt.Fprintf(f.out, "CPU: n/a (limit %s)\n", valueOrNone(resources.CPULimit))
+		fmt.Fprintf(f.out, "                 Mem: n/a (limit %s)\n", valueOrNone(resources.MemLimit))
+		return
+	}
 
 	// CPU
 	cpuBar := f.createProgressBar(resources.CPUPercentage)
 	cpuColor := f.getResourceColor(resources.CPUPercentage)
-	fmt.Printf("CPU: %s %.0f%% (%s/%s)\n",
+	fmt.Fprintf(f.out, "CPU: %s %.0f%% (%s/%s)\n",
 		cpuColor.Sprintf("%s", cpuBar),
 		resources.CPUPercentage,
-		resources.CPUUsage,
+		f.formatCPUValue(resources.CPUUsage, resources.CPUUsageMilli),
 		resources.CPULimit)
 
-	fmt.Printf("                 ")
+	fmt.Fprintf(f.out, "                 ")
 
 	// Memory
 	memBar := f.createProgressBar(resources.MemPercentage)
@@ -505,83 +1131,218 @@ func (f *Formatter) printResourceUsage(resources types.ResourceInfo) {
 	if resources.MemPercentage > 80 {
 		memWarning = " ⚠"
 	}
-	fmt.Printf("Mem: %s %.0f%% (%s/%s)%s\n",
+	// metrics-server reports the working set, which is neither RSS nor total usage, so say which it is
+	rss := ""
+	if resources.MemRSS != "" {
+		rss = fmt.Sprintf(", RSS %s", resources.MemRSS)
+	}
+	fmt.Fprintf(f.out, "Mem: %s %.0f%% (%s/%s working set%s)%s\n",
 		memColor.Sprintf("%s", memBar),
 		resources.MemPercentage,
-		resources.MemUsage,
+		f.formatMemoryValue(resources.MemUsage, resources.MemUsageBytes),
 		resources.MemLimit,
+		rss,
 		memWarning)
 }
 
+// formatContainerHealth renders the analyzer's level and reason for a container
+func (f *Formatter) formatContainerHealth(health types.HealthStatus) string {
+	if health.Level == "" {
+		return ""
+	}
+	result := fmt.Sprintf("%s %s", f.analyzer.GetHealthIcon(health.Level), f.getHealthColor(health.Level).Sprint(health.Level))
+	if health.Reason != "" {
+		result += " - " + health.Reason
+	}
+	return result
+}
+
+// formatCPUValue returns the rounded CPU usage, or the exact millicores with --raw-metrics
+func (f *Formatter) formatCPUValue(formatted string, milliCPU int64) string {
+	if f.options.RawMetrics {
+		return fmt.Sprintf("%dm", milliCPU)
+	}
+	return formatted
+}
+
+// formatMemoryValue returns the rounded memory usage, or the exact bytes with --raw-metrics
+func (f *Formatter) formatMemoryValue(formatted string, bytes int64) string {
+	if f.options.RawMetrics {
+		return fmt.Sprintf("%d", bytes)
+	}
+	return formatted
+}
+
+// Thresholds for usage as a percentage of the request
+const (
+	overProvisionedPercentage  = 10.0
+	underProvisionedPercentage = 100.0
+)
+
+// printEfficiency prints usage as a percentage of the request, flagging over- and under-provisioned
+// resources; a resource without a usage reading is left out rather than judged as unused
+func (f *Formatter) printEfficiency(resources types.ResourceInfo, metricsUnavailable bool) {
+	if metricsUnavailable {
+		return
+	}
+
+	var parts []string
+	if resources.CPURequest != "" && resources.HasCPUUsage {
+		parts = append(parts, "CPU "+f.formatEfficiency(resources.CPURequestPercentage))
+	}
+	if resources.MemRequest != "" && resources.HasMemUsage {
+		parts = append(parts, "Mem "+f.formatEfficiency(resources.MemRequestPercentage))
+	}
+	if len(parts) == 0 {
+		return
+	}
+	fmt.Fprintf(f.out, "  • Efficiency:  %s\n", strings.Join(parts, ", "))
+}
+
+// formatEfficiency formats usage as a percentage of the request with its provisioning verdict
+func (f *Formatter) formatEfficiency(percentage float64) string {
+	formatted := fmt.Sprintf("%.0f%% of request", percentage)
+	switch {
+	case percentage < overProvisionedPercentage:
+		return formatted + " " + f.getHealthColor(string(types.HealthLevelDegraded)).Sprint("(over-provisioned)")
+	case percentage > underProvisionedPercentage:
+		return formatted + " " + f.getHealthColor(string(types.HealthLevelCritical)).Sprint("(under-provisioned)")
+	default:
+		return formatted
+	}
+}
+
 // printProbes prints probe information
 func (f *Formatter) printProbes(probes types.ProbeInfo) {
 	if probes.Liveness.Configured {
 		icon := f.analyzer.GetProbeIcon(probes.Liveness.Passing, true)
-		fmt.Printf("  • Liveness:    %s %s %s on port %s (",
-			icon, probes.Liveness.Type, probes.Liveness.Path, probes.Liveness.Port)
+		fmt.Fprintf(f.out, "  • Liveness:    %s %s (", icon, f.formatProbeTarget(probes.Liveness))
 		if probes.Liveness.Passing {
-			fmt.Printf("passing)\n")
+			fmt.Fprintf(f.out, "passing)\n")
 		} else {
-			fmt.Printf("failing)\n")
+			fmt.Fprintf(f.out, "failing)\n")
 		}
 	}
 
 	if probes.Readiness.Configured {
 		icon := f.analyzer.GetProbeIcon(probes.Readiness.Passing, true)
-		fmt.Printf("  • Readiness:   %s %s %s on port %s (",
-			icon, probes.Readiness.Type, probes.Readiness.Path, probes.Readiness.Port)
+		fmt.Fprintf(f.out, "  • Readiness:   %s %s (", icon, f.formatProbeTarget(probes.Readiness))
 		if probes.Readiness.Passing {
-			fmt.Printf("passing)\n")
+			fmt.Fprintf(f.out, "passing)\n")
+		} else {
+			fmt.Fprintf(f.out, "failing)\n")
+		}
+	}
+
+	if probes.Startup.Configured {
+		icon := f.analyzer.GetProbeIcon(probes.Startup.Passing, true)
+		fmt.Fprintf(f.out, "  • Startup:     %s %s (", icon, f.formatProbeTarget(probes.Startup))
+		if probes.Startup.Passing {
+			fmt.Fprintf(f.out, "passed)\n")
 		} else {
-			fmt.Printf("failing)\n")
+			fmt.Fprintf(f.out, "not passed yet, deadline %s)\n", f.formatDuration(probes.Startup.Deadline))
 		}
 	}
 }
 
+// formatProbeTarget formats the probe type and endpoint, e.g. "HTTP /health on port 8080"
+func (f *Formatter) formatProbeTarget(probe types.ProbeDetails) string {
+	target := probe.Type
+	if f.options.WideProbes && probe.Scheme != "" {
+		target = probe.Scheme
+	}
+	if probe.Path != "" {
+		target += " " + probe.Path
+	}
+	if probe.Port != "" {
+		target += " on port " + probe.Port
+	}
+	if probe.Service != "" {
+		target += fmt.Sprintf(" (service: %s)", probe.Service)
+	}
+	if f.options.WideProbes {
+		if probe.Host != "" {
+			target += " host " + probe.Host
+		}
+		if len(probe.Headers) > 0 {
+			target += fmt.Sprintf(" [%s]", strings.Join(probe.Headers, ", "))
+		}
+	}
+	return target
+}
+
 // printVolumes prints volume information
 func (f *Formatter) printVolumes(volumes []types.VolumeInfo) {
-	fmt.Printf("  • Volumes:     \n")
+	fmt.Fprintf(f.out, "  • Volumes:     \n")
 	for _, volume := range volumes {
-		fmt.Printf("    - %s → %s (%s)\n", volume.MountPath, volume.Details, volume.VolumeType)
+		fmt.Fprintf(f.out, "    - %s\n", f.formatVolumeMount(volume))
+	}
+}
+
+// formatVolumeMount formats a volume mount with its read-only and subPath indicators
+func (f *Formatter) formatVolumeMount(volume types.VolumeInfo) string {
+	line := fmt.Sprintf("%s → %s (%s)", volume.MountPath, volume.Details, volume.VolumeType)
+
+	var flags []string
+	if volume.ReadOnly {
+		flags = append(flags, "ro")
+	}
+	if volume.SubPath != "" {
+		flags = append(flags, fmt.Sprintf("subPath=%s", volume.SubPath))
 	}
+	if len(flags) > 0 {
+		line += fmt.Sprintf(" [%s]", strings.Join(flags, ", "))
+	}
+	return line
 }
 
 // printEnvironment prints environment variables
 func (f *Formatter) printEnvironment(env []types.EnvVar) {
-	fmt.Printf("  • Environment: \n")
+	fmt.Fprintf(f.out, "  • Environment: \n")
 
 	// Determine how many environment variables to show
 	limit := 20
 
 	for i, envVar := range env {
 		if i >= limit {
-			fmt.Printf("    ... and %d more\n", len(env)-limit)
+			fmt.Fprintf(f.out, "    ... and %d more\n", len(env)-limit)
 			break
 		}
 		value := envVar.Value
 		if envVar.Masked {
 			value = "***"
 		}
-		fmt.Printf("    - %s=%s\n", envVar.Name, value)
+		fmt.Fprintf(f.out, "    - %s=%s\n", envVar.Name, value)
 	}
 }
 
 // printCommand prints container command and arguments
-func (f *Formatter) printCommand(command []string, args []string) {
+func (f *Formatter) printCommand(container types.ContainerInfo) {
+	command, args := container.Command, container.Args
+	// The image's own entrypoint isn't visible without pulling the image, so just say it's in use
 	if len(command) == 0 && len(args) == 0 {
+		fmt.Fprintf(f.out, "  • Command:     (using image default entrypoint)\n")
+		return
+	}
+
+	// Ephemeral debug containers usually run a one-off command, shown in full as it would be typed
+	if container.Type == string(types.ContainerTypeEphemeral) {
+		fmt.Fprintf(f.out, "  • Command:     %s\n", shellJoin(append(append([]string{}, command...), args...)))
 		return
 	}
 
-	fmt.Printf("  • Command:     \n")
+	fmt.Fprintf(f.out, "  • Command:     \n")
 
 	// Show command (entrypoint)
-	if len(command) > 0 {
+	if len(command) == 0 {
+		fmt.Fprintf(f.out, "    - Entrypoint: (image default)\n")
+	} else {
 		terminalWidth := f.getTerminalWidth()
 		indentWidth := 6 // "    - " prefix
 		maxLineWidth := terminalWidth - indentWidth
 
 		commandStr := strings.Join(command, " ")
-		fmt.Printf("    - Entrypoint: ")
+		fmt.Fprintf(f.out, "    - Entrypoint: ")
 		f.printWrappedCommandLine(commandStr, maxLineWidth-12, indentWidth+12) // 12 = len("Entrypoint: ")
 	}
 
@@ -591,22 +1352,35 @@ func (f *Formatter) printCommand(command []string, args []string) {
 		indentWidth := 6 // "    - " prefix
 		maxLineWidth := terminalWidth - indentWidth
 
-		fmt.Printf("    - Args:       ")
+		fmt.Fprintf(f.out, "    - Args:       ")
 		for i, arg := range args {
 			if i > 0 {
 				// Indent subsequent args to the same column as the first argument
-				fmt.Print(strings.Repeat(" ", indentWidth+12))
+				fmt.Fprint(f.out, strings.Repeat(" ", indentWidth+12))
 			}
 			f.printWrappedCommandLine(arg, maxLineWidth-12, indentWidth+12) // 12 = len("Args:       ")
 		}
 	}
 }
 
+// shellJoin joins a command line, quoting the words that contain spaces or quotes
+func shellJoin(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		if word == "" || strings.ContainsAny(word, " \t\n'\"$") {
+			quoted[i] = "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+		} else {
+			quoted[i] = word
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
 // printWrappedCommandLine prints a command line with intelligent wrapping
 func (f *Formatter) printWrappedCommandLine(line string, maxWidth, indentWidth int) {
 	if len(line) <= maxWidth {
 		// Line fits, print as-is
-		fmt.Printf("%s\n", line)
+		fmt.Fprintf(f.out, "%s\n", line)
 		return
 	}
 
@@ -622,12 +1396,12 @@ func (f *Formatter) printWrappedCommandLine(line string, maxWidth, indentWidth i
 	} else {
 		line = line[maxWidth:]
 	}
-	fmt.Printf("%s\n", firstLine)
+	fmt.Fprintf(f.out, "%s\n", firstLine)
 
 	// Print continuation lines
 	for len(line) > 0 {
 		if len(line) <= maxWidth {
-			fmt.Printf("%s%s\n", continuationIndent, line)
+			fmt.Fprintf(f.out, "%s%s\n", continuationIndent, line)
 			break
 		}
 
@@ -639,15 +1413,19 @@ func (f *Formatter) printWrappedCommandLine(line string, maxWidth, indentWidth i
 		} else {
 			line = line[maxWidth:]
 		}
-		fmt.Printf("%s%s\n", continuationIndent, continuationLine)
+		fmt.Fprintf(f.out, "%s%s\n", continuationIndent, continuationLine)
 	}
 }
 
 // printLogs prints recent container logs
 func (f *Formatter) printLogs(logs []string) {
-	fmt.Printf("  • Recent Logs:\n")
+	if f.options.LogPattern != nil {
+		fmt.Fprintf(f.out, "  • Recent Logs matching %q:\n", f.options.LogGrep)
+	} else {
+		fmt.Fprintf(f.out, "  • Recent Logs:\n")
+	}
 	if len(logs) == 0 {
-		fmt.Printf("    (no logs available)\n")
+		fmt.Fprintf(f.out, "    (no logs available)\n")
 		return
 	}
 
@@ -667,8 +1445,12 @@ func (f *Formatter) getTerminalWidth() int {
 	const defaultWidth = 120
 	const minWidth = 80
 
-	// Try to detect actual terminal width
-	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+	// Try to detect actual terminal width; output going to a regular file gets the default
+	file, isFile := f.out.(*os.File)
+	if !isFile {
+		return defaultWidth
+	}
+	if width, _, err := term.GetSize(int(file.Fd())); err == nil && width > 0 {
 		if width < minWidth {
 			return minWidth
 		}
@@ -681,9 +1463,12 @@ func (f *Formatter) getTerminalWidth() int {
 
 // printWrappedLogLine prints a log line with intelligent wrapping
 func (f *Formatter) printWrappedLogLine(line string, maxWidth, indentWidth int) {
+	// --log-grep matches against the whole line, so anchors and matches spanning a wrap still work
+	matches := f.logMatches(line)
+
 	if len(line) <= maxWidth {
 		// Line fits, print as-is
-		fmt.Printf("    %s\n", line)
+		fmt.Fprintf(f.out, "    %s\n", highlightLogMatches(line, 0, matches))
 		return
 	}
 
@@ -692,70 +1477,89 @@ func (f *Formatter) printWrappedLogLine(line string, maxWidth, indentWidth int)
 	continuationIndent := strings.Repeat(" ", indentWidth+2) // Extra 2 spaces for continuation
 
 	// Print first line
+	offset := 0
 	firstLine := line[:maxWidth]
+	next := maxWidth
 	// Try to break at a word boundary if possible
 	if lastSpace := strings.LastIndex(firstLine, " "); lastSpace > maxWidth*3/4 {
 		firstLine = line[:lastSpace]
-		line = line[lastSpace+1:] // Skip the space
-	} else {
-		line = line[maxWidth:]
+		next = lastSpace + 1 // Skip the space
 	}
-	fmt.Printf("%s%s\n", indent, firstLine)
+	fmt.Fprintf(f.out, "%s%s\n", indent, highlightLogMatches(firstLine, offset, matches))
+	offset = next
 
 	// Print continuation lines
-	for len(line) > 0 {
+	for offset < len(line) {
+		rest := line[offset:]
 		maxContinuationWidth := maxWidth - 2 // Account for continuation indent
-		if len(line) <= maxContinuationWidth {
-			fmt.Printf("%s%s\n", continuationIndent, line)
+		if len(rest) <= maxContinuationWidth {
+			fmt.Fprintf(f.out, "%s%s\n", continuationIndent, highlightLogMatches(rest, offset, matches))
 			break
 		}
 
-		continuationLine := line[:maxContinuationWidth]
+		continuationLine := rest[:maxContinuationWidth]
+		next := offset + maxContinuationWidth
 		// Try to break at word boundary
 		if lastSpace := strings.LastIndex(continuationLine, " "); lastSpace > maxContinuationWidth*3/4 {
-			continuationLine = line[:lastSpace]
-			line = line[lastSpace+1:]
-		} else {
-			line = line[maxContinuationWidth:]
+			continuationLine = rest[:lastSpace]
+			next = offset + lastSpace + 1
+		}
+		fmt.Fprintf(f.out, "%s%s\n", continuationIndent, highlightLogMatches(continuationLine, offset, matches))
+		offset = next
+	}
+}
+
+// logMatches returns the byte ranges of a log line that match --log-grep, or nil without it
+func (f *Formatter) logMatches(line string) [][]int {
+	if f.options.LogPattern == nil {
+		return nil
+	}
+	return f.options.LogPattern.FindAllStringIndex(line, -1)
+}
+
+// highlightLogMatches colors the parts of a wrapped piece of a log line that fall inside the matches, like
+// grep --color. The piece starts offset bytes into the line the matches were found in. Pieces are
+// highlighted after wrapping so the escape codes don't count towards the width.
+func highlightLogMatches(piece string, offset int, matches [][]int) string {
+	if len(matches) == 0 {
+		return piece
+	}
+	matchColor := color.New(color.FgRed, color.Bold)
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[0]-offset, match[1]-offset
+		if end <= 0 || start >= len(piece) || start == end {
+			continue
 		}
-		fmt.Printf("%s%s\n", continuationIndent, continuationLine)
+		start, end = max(start, 0), min(end, len(piece))
+		b.WriteString(piece[last:start])
+		b.WriteString(matchColor.Sprint(piece[start:end]))
+		last = end
 	}
+	b.WriteString(piece[last:])
+	return b.String()
 }
 
-// printEvents prints recent events
-func (f *Formatter) printEvents(events []types.EventInfo) {
+// printEvents prints recent events, or notes that listing them was forbidden
+func (f *Formatter) printEvents(events []types.EventInfo, forbidden bool) {
 	// Determine the time window message based on whether events flag is used
-	timeWindow := "last 1h"
+	timeWindow := f.eventsWindow()
 
 	// Enhanced events section with better visual structure
 	eventsColor := color.New(color.FgHiBlue, color.Bold)
-	fmt.Printf("📋 %s (%s):\n", eventsColor.Sprint("Recent Events"), timeWindow)
+	fmt.Fprintf(f.out, "📋 %s (%s):\n", eventsColor.Sprint("Recent Events"), timeWindow)
 
-	if len(events) == 0 {
-		fmt.Printf("  • ✨ No events found in %s\n", timeWindow)
+	if forbidden {
+		fmt.Fprintf(f.out, "  • events unavailable (forbidden)\n")
+	} else if len(events) == 0 {
+		fmt.Fprintf(f.out, "  • ✨ No events found in %s\n", timeWindow)
 	} else {
-		// Sort events with FailedScheduling first, then by time
-		sortedEvents := make([]types.EventInfo, len(events))
-		copy(sortedEvents, events)
-
-		sort.Slice(sortedEvents, func(i, j int) bool {
-			// Prioritize FailedScheduling events
-			iIsScheduling := sortedEvents[i].Reason == "FailedScheduling"
-			jIsScheduling := sortedEvents[j].Reason == "FailedScheduling"
-
-			if iIsScheduling && !jIsScheduling {
-				return true
-			}
-			if !iIsScheduling && jIsScheduling {
-				return false
-			}
-
-			// If both or neither are FailedScheduling, sort by time (newest first)
-			return sortedEvents[i].Time.After(sortedEvents[j].Time)
-		})
+		sortedEvents := sortEvents(aggregateEvents(events), f.options.EventsSort)
 
+		sortedEvents, hidden := limitEvents(sortedEvents, f.options.MaxEvents)
 		for _, event := range sortedEvents {
-			age := time.Since(event.Time)
+			age := f.since(event.Time)
 			eventIcon := ""
 			eventColor := color.New()
 
@@ -784,15 +1588,121 @@ func (f *Formatter) printEvents(events []types.EventInfo) {
 				message = f.wrapSchedulingMessage(message)
 			}
 
-			fmt.Printf("  • %s %s %s: %s (%s)\n",
+			fmt.Fprintf(f.out, "  • %s %s %s: %s (%s)%s\n",
 				eventIcon,
 				eventColor.Sprint(event.Type),
-				f.formatDuration(age),
+				f.formatAge(age),
 				message,
-				event.Reason)
+				event.Reason,
+				f.formatEventOccurrences(event))
+		}
+
+		if hidden > 0 {
+			fmt.Fprintf(f.out, "  💭 ... and %d more events\n", hidden)
+		}
+	}
+	fmt.Fprintln(f.out)
+}
+
+// aggregateEvents merges repeated events (same pod, type, reason and message) into one entry,
+// summing their counts and keeping the first and last time they were seen, like kubectl describe
+func aggregateEvents(events []types.EventInfo) []types.EventInfo {
+	type eventKey struct {
+		podName, eventType, reason, message string
+	}
+
+	var aggregated []types.EventInfo
+	index := make(map[eventKey]int)
+	for _, event := range events {
+		if event.Count < 1 {
+			event.Count = 1
+		}
+		if event.FirstSeen.IsZero() {
+			event.FirstSeen = event.Time
+		}
+
+		key := eventKey{event.PodName, event.Type, event.Reason, event.Message}
+		i, exists := index[key]
+		if !exists {
+			index[key] = len(aggregated)
+			aggregated = append(aggregated, event)
+			continue
+		}
+
+		existing := &aggregated[i]
+		existing.Count += event.Count
+		if event.Time.After(existing.Time) {
+			existing.Time = event.Time
+		}
+		if event.FirstSeen.Before(existing.FirstSeen) {
+			existing.FirstSeen = event.FirstSeen
+		}
+	}
+	return aggregated
+}
+
+// formatEventOccurrences formats the repeat count of an event (e.g. " ×47, first seen 2h ago"), or "" if it occurred once
+func (f *Formatter) formatEventOccurrences(event types.EventInfo) string {
+	if event.Count <= 1 {
+		return ""
+	}
+	if event.FirstSeen.IsZero() || !event.FirstSeen.Before(event.Time) {
+		return fmt.Sprintf(" ×%d", event.Count)
+	}
+	return fmt.Sprintf(" ×%d, first seen %s", event.Count, f.formatTime(event.FirstSeen))
+}
+
+// eventsWindow describes which events were collected, for section headings
+func (f *Formatter) eventsWindow() string {
+	if f.options.EventsWarningsOnly {
+		return "last 1h, warnings only"
+	}
+	return "last 1h"
+}
+
+// eventSeverityRank orders events for --events-sort=severity: FailedScheduling blocks the pod
+// outright, other warnings and errors come next, and Normal events last
+func eventSeverityRank(event types.EventInfo) int {
+	switch {
+	case event.Reason == "FailedScheduling":
+		return 0
+	case event.Type == "Warning" || event.Type == "Error":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// sortEvents orders events by severity then time, or by time alone, newest first. Ties are
+// broken by reason, pod and message so the same events always print in the same order.
+func sortEvents(events []types.EventInfo, mode string) []types.EventInfo {
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if types.EventSortType(mode) != types.EventSortByTime {
+			if ra, rb := eventSeverityRank(a), eventSeverityRank(b); ra != rb {
+				return ra < rb
+			}
+		}
+		if !a.Time.Equal(b.Time) {
+			return a.Time.After(b.Time)
+		}
+		if a.Reason != b.Reason {
+			return a.Reason < b.Reason
 		}
+		if a.PodName != b.PodName {
+			return a.PodName < b.PodName
+		}
+		return a.Message < b.Message
+	})
+	return events
+}
+
+// limitEvents returns at most maxEvents events (all of them when maxEvents is 0) and how many were left out
+func limitEvents(events []types.EventInfo, maxEvents int) ([]types.EventInfo, int) {
+	if maxEvents <= 0 || len(events) <= maxEvents {
+		return events, 0
 	}
-	fmt.Println()
+	return events[:maxEvents], len(events) - maxEvents
 }
 
 // Helper functions
@@ -834,6 +1744,15 @@ func (f *Formatter) getReadyCount(pod types.PodInfo) int {
 	return ready
 }
 
+// formatReadyCount formats ready/total regular containers, in red for a Running pod with none ready
+func (f *Formatter) formatReadyCount(pod types.PodInfo) string {
+	count := fmt.Sprintf("%d/%d", f.getReadyCount(pod), len(pod.Containers))
+	if f.analyzer.IsRunningWithNoContainersReady(pod) {
+		return f.getHealthColor(string(types.HealthLevelCritical)).Sprint(count)
+	}
+	return count
+}
+
 // formatDuration formats a duration in human-readable format
 func (f *Formatter) formatDuration(d time.Duration) string {
 	if d < time.Minute {
@@ -847,6 +1766,46 @@ func (f *Formatter) formatDuration(d time.Duration) string {
 	}
 }
 
+// now returns the time the shown state was observed: --from-file output describes the dump, not the present
+func (f *Formatter) now() time.Time {
+	if !f.options.ReferenceTime.IsZero() {
+		return f.options.ReferenceTime
+	}
+	return time.Now()
+}
+
+// since returns the time elapsed from t until the shown state was observed
+func (f *Formatter) since(t time.Time) time.Duration {
+	return f.now().Sub(t)
+}
+
+// formatTime formats a point in time relative to now ("5m ago"), or as an absolute
+// timestamp when --timestamps is set
+func (f *Formatter) formatTime(t time.Time) string {
+	if f.options.Timestamps {
+		return f.formatTimestamp(t)
+	}
+	return fmt.Sprintf("%s ago", f.formatDuration(f.since(t)))
+}
+
+// formatAge formats an elapsed duration ("5m"), or the absolute time it started when
+// --timestamps is set
+func (f *Formatter) formatAge(d time.Duration) string {
+	if f.options.Timestamps {
+		return f.formatTimestamp(f.now().Add(-d))
+	}
+	return f.formatDuration(d)
+}
+
+// formatTimestamp formats an absolute timestamp in the configured time zone (local by default)
+func (f *Formatter) formatTimestamp(t time.Time) string {
+	location := f.location
+	if location == nil {
+		location = time.Local
+	}
+	return t.In(location).Format(time.RFC3339)
+}
+
 // formatRestartInfo formats restart count with last restart time
 func (f *Formatter) formatRestartInfo(restartCount int32, lastRestartTime *time.Time) string {
 	if restartCount == 0 {
@@ -855,7 +1814,7 @@ func (f *Formatter) formatRestartInfo(restartCount int32, lastRestartTime *time.
 
 	restartStr := fmt.Sprintf("%d", restartCount)
 	if lastRestartTime != nil {
-		restartStr += fmt.Sprintf(" (last %s ago)", f.formatDuration(time.Since(*lastRestartTime)))
+		restartStr += fmt.Sprintf(" (last %s)", f.formatTime(*lastRestartTime))
 	}
 
 	return restartStr
@@ -863,17 +1822,54 @@ func (f *Formatter) formatRestartInfo(restartCount int32, lastRestartTime *time.
 
 // getLastRestartTime returns the most recent restart time from all containers in a pod
 func (f *Formatter) getLastRestartTime(pod types.PodInfo) *time.Time {
-	var mostRecent *time.Time
+	newest, _ := restartExtremes([]types.PodInfo{pod})
+	if newest == nil {
+		return nil
+	}
+	return &newest.Time
+}
+
+// containerRestart is when a container last restarted, and which one it was
+type containerRestart struct {
+	Time      time.Time
+	Pod       string
+	Container string
+}
 
-	for _, container := range append(pod.InitContainers, pod.Containers...) {
-		if container.LastRestartTime != nil {
-			if mostRecent == nil || container.LastRestartTime.After(*mostRecent) {
-				mostRecent = container.LastRestartTime
+// restartExtremes returns the most recent and the oldest of the containers' last restarts across the
+// pods, or nils when nothing has restarted. Kubernetes only records each container's last restart, so
+// the oldest is the container that has been stable the longest since restarting.
+func restartExtremes(pods []types.PodInfo) (newest, oldest *containerRestart) {
+	for _, pod := range pods {
+		for _, container := range append(pod.InitContainers, pod.Containers...) {
+			if container.LastRestartTime == nil {
+				continue
+			}
+			restart := &containerRestart{Time: *container.LastRestartTime, Pod: pod.Name, Container: container.Name}
+			if newest == nil || restart.Time.After(newest.Time) {
+				newest = restart
+			}
+			if oldest == nil || restart.Time.Before(oldest.Time) {
+				oldest = restart
 			}
 		}
 	}
+	return newest, oldest
+}
 
-	return mostRecent
+// formatRestartRecency describes when the workload's containers last restarted, e.g. "most recent 3m ago
+// (api-1/app), oldest tracked 2h ago (api-2/app)", which tells a crash loop in progress from restarts
+// long since settled
+func (f *Formatter) formatRestartRecency(pods []types.PodInfo) string {
+	newest, oldest := restartExtremes(pods)
+	if newest == nil {
+		return ""
+	}
+	recency := fmt.Sprintf("most recent %s (%s/%s)", f.formatTime(newest.Time), newest.Pod, newest.Container)
+	if oldest != newest {
+		recency += fmt.Sprintf(", oldest tracked %s (%s/%s)", f.formatTime(oldest.Time), oldest.Pod, oldest.Container)
+	}
+	return recency
 }
 
 // createProgressBar creates a progress bar string
@@ -882,11 +1878,14 @@ func (f *Formatter) createProgressBar(percentage float64) string {
 		return fmt.Sprintf("%.0f%%", percentage)
 	}
 
-	segments := 10
-	filled := int(percentage / 10)
+	segments := f.barWidth()
+	filled := int(percentage * float64(segments) / 100)
 	if filled > segments {
 		filled = segments
 	}
+	if filled < 0 {
+		filled = 0
+	}
 
 	bar := strings.Repeat("▓", filled) + strings.Repeat("░", segments-filled)
 	return bar
@@ -916,12 +1915,239 @@ func (f *Formatter) getResourceColor(percentage float64) *color.Color {
 		return color.New()
 	}
 
-	if percentage >= 90 {
-		return color.New(color.FgHiRed, color.Bold)
-	} else if percentage >= 70 {
-		return color.New(color.FgHiYellow, color.Bold)
+	return color.New(f.usageColor(percentage), color.Bold)
+}
+
+// Defaults for --bar-width, --warning-threshold and --critical-threshold
+const (
+	defaultBarWidth          = 10
+	defaultWarningThreshold  = 70.0
+	defaultCriticalThreshold = 90.0
+)
+
+// barWidth returns the number of segments in a resource bar
+func (f *Formatter) barWidth() int {
+	if f.options.BarWidth <= 0 {
+		return defaultBarWidth
+	}
+	return f.options.BarWidth
+}
+
+// miniBarWidth returns the number of segments in the workload summary's mini bars, four fifths of
+// a full bar so the three percentiles still fit on one line
+func (f *Formatter) miniBarWidth() int {
+	return max(4, f.barWidth()*4/5)
+}
+
+// usageThresholds returns the usage percentages at which bars and values turn yellow and red
+func (f *Formatter) usageThresholds() types.UsageThresholds {
+	thresholds := f.options.Thresholds
+	if thresholds.Warning <= 0 {
+		thresholds.Warning = defaultWarningThreshold
+	}
+	if thresholds.Critical <= 0 {
+		thresholds.Critical = defaultCriticalThreshold
+	}
+	return thresholds
+}
+
+// usageColor returns red at or above the critical threshold, yellow at or above the warning
+// threshold and green below
+func (f *Formatter) usageColor(percentage float64) color.Attribute {
+	thresholds := f.usageThresholds()
+	if percentage >= thresholds.Critical {
+		return color.FgHiRed
+	} else if percentage >= thresholds.Warning {
+		return color.FgHiYellow
 	}
-	return color.New(color.FgHiGreen, color.Bold)
+	return color.FgHiGreen
+}
+
+// rolloutStatus counts pods running the controller's current pod template against those still on an older one
+type rolloutStatus struct {
+	Current        int
+	Outdated       int
+	OutdatedImages []string // Images on outdated pods that differ from the template, sorted
+	CurrentImages  []string // Template images for those same containers, sorted
+}
+
+// templateRollout counts the pods running the workload's current pod template. A pod's template revision
+// decides when both it and the workload's are known; otherwise pods are told apart by their images. Images
+// that differ from the template are collected from the outdated pods to say what the rollout changes.
+func templateRollout(workload types.WorkloadInfo) rolloutStatus {
+	var status rolloutStatus
+	if len(workload.TemplateImages) == 0 && workload.TemplateRevision == "" {
+		return status
+	}
+
+	outdatedImages := make(map[string]bool)
+	currentImages := make(map[string]bool)
+	for _, pod := range workload.Pods {
+		// Pod image -> template image, for the containers whose image differs
+		changed := make(map[string]string)
+		for _, container := range append(pod.InitContainers, pod.Containers...) {
+			if image, ok := workload.TemplateImages[container.Name]; ok && image != container.Image {
+				changed[container.Image] = image
+			}
+		}
+		outdated := len(changed) > 0
+		if workload.TemplateRevision != "" && pod.Revision != "" {
+			outdated = pod.Revision != workload.TemplateRevision
+		}
+		if !outdated {
+			status.Current++
+			continue
+		}
+		status.Outdated++
+		for old, current := range changed {
+			outdatedImages[old] = true
+			currentImages[current] = true
+		}
+	}
+
+	status.OutdatedImages = sortedKeys(outdatedImages)
+	status.CurrentImages = sortedKeys(currentImages)
+	return status
+}
+
+// formatRolloutStatus renders a rollout that hasn't reached every pod, e.g. "3 pods running outdated
+// image web:1.2, 5 on current web:1.3", or an empty string when every pod runs the current template.
+// A rollout that changes something other than images only names the template.
+func formatRolloutStatus(status rolloutStatus) string {
+	if status.Outdated == 0 {
+		return ""
+	}
+	pods := "pods"
+	if status.Outdated == 1 {
+		pods = "pod"
+	}
+	if len(status.OutdatedImages) == 0 {
+		return fmt.Sprintf("%d %s running an outdated pod template, %d on the current one", status.Outdated, pods, status.Current)
+	}
+	return fmt.Sprintf("%d %s running outdated %s, %d on current %s",
+		status.Outdated, pods, describeImages(status.OutdatedImages),
+		status.Current, describeImages(status.CurrentImages))
+}
+
+// describeImages names one or more images, e.g. "image web:1.2" or "images web:1.2 and proxy:0.9"
+func describeImages(images []string) string {
+	if len(images) == 1 {
+		return "image " + images[0]
+	}
+	return "images " + strings.Join(images, " and ")
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// probeFailureCounts is the number of pods with at least one failing probe of each kind
+type probeFailureCounts struct {
+	Readiness int
+	Liveness  int
+	Startup   int
+}
+
+// countProbeFailures tallies the pods that have a configured probe failing in any shown container. Liveness
+// failures come from the Unhealthy events the collector attributes to each container, since a container whose
+// liveness probe fails is restarted rather than reported as failing.
+func (f *Formatter) countProbeFailures(pods []types.PodInfo) probeFailureCounts {
+	var counts probeFailureCounts
+	for _, pod := range pods {
+		var readiness, liveness, startup bool
+		for _, container := range allContainers(pod) {
+			if !f.shouldShowContainer(container) {
+				continue
+			}
+			probes := container.Probes
+			readiness = readiness || (probes.Readiness.Configured && !probes.Readiness.Passing)
+			liveness = liveness || (probes.Liveness.Configured && (!probes.Liveness.Passing || probes.Liveness.FailureCount > 0))
+			startup = startup || (probes.Startup.Configured && !probes.Startup.Passing)
+		}
+		if readiness {
+			counts.Readiness++
+		}
+		if liveness {
+			counts.Liveness++
+		}
+		if startup {
+			counts.Startup++
+		}
+	}
+	return counts
+}
+
+// formatProbeFailures renders probe failure counts, e.g. "5 pods with readiness failures, 2 with
+// liveness failures", or an empty string when every probe passes
+func formatProbeFailures(counts probeFailureCounts) string {
+	var parts []string
+	for _, probe := range []struct {
+		name  string
+		count int
+	}{
+		{"readiness", counts.Readiness},
+		{"liveness", counts.Liveness},
+		{"startup", counts.Startup},
+	} {
+		if probe.count == 0 {
+			continue
+		}
+		if len(parts) == 0 {
+			noun := "pods"
+			if probe.count == 1 {
+				noun = "pod"
+			}
+			parts = append(parts, fmt.Sprintf("%d %s with %s failures", probe.count, noun, probe.name))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d with %s failures", probe.count, probe.name))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// containerImageVariants returns the distinct images, sorted, seen for each shown container name
+func (f *Formatter) containerImageVariants(pods []types.PodInfo) map[string][]string {
+	seen := make(map[string]map[string]bool)
+	variants := make(map[string][]string)
+	for _, pod := range pods {
+		for _, container := range allContainers(pod) {
+			if !f.shouldShowContainer(container) {
+				continue
+			}
+			name := containerDisplayName(container)
+			if seen[name] == nil {
+				seen[name] = make(map[string]bool)
+			}
+			if !seen[name][container.Image] {
+				seen[name][container.Image] = true
+				variants[name] = append(variants[name], container.Image)
+			}
+		}
+	}
+	for name := range variants {
+		sort.Strings(variants[name])
+	}
+	return variants
+}
+
+// summaryContainerKey names a workload summary entry, splitting a container that runs on
+// more than one image into one entry per image so usage isn't attributed to the wrong one
+func summaryContainerKey(name, image string, variants []string) string {
+	if len(variants) < 2 {
+		return name
+	}
+	for i, variant := range variants {
+		if variant == image {
+			return fmt.Sprintf("%s (%d of %d image variants)", name, i+1, len(variants))
+		}
+	}
+	return name
 }
 
 // printWorkloadSummary prints enhanced summary for multi-pod workloads
@@ -933,20 +2159,25 @@ func (f *Formatter) printWorkloadSummary(workload types.WorkloadInfo) {
 
 	// Collect container information with aggregated data and usage stats
 	containerInfo := make(map[string]struct {
-		Image       string
-		Type        string
-		CPURequest  string
-		CPULimit    string
-		MemRequest  string
-		MemLimit    string
-		VolumeTypes map[string]bool
-		CPUUsages   []float64 // All CPU usage percentages for this container type
-		MemUsages   []float64 // All Memory usage percentages for this container type
-		CPUValues   []string  // All CPU usage values (e.g., "70m", "100m")
-		MemValues   []string  // All Memory usage values (e.g., "14Mi", "256Mi")
-		Status      string
+		Image           string
+		Type            string
+		CPURequest      string
+		CPULimit        string
+		MemRequest      string
+		MemLimit        string
+		VolumeTypes     map[string]bool
+		CPUUsages       []float64 // All CPU usage percentages for this container type
+		MemUsages       []float64 // All Memory usage percentages for this container type
+		CPUValues       []int64   // All CPU usage readings in millicores
+		MemValues       []int64   // All Memory usage readings in bytes
+		CPUEfficiencies []float64 // All CPU usage percentages of the request
+		MemEfficiencies []float64 // All Memory usage percentages of the request
+		Status          string
 	})
 
+	// A rolling update can run the same container name on two images; keep those apart
+	imageVariants := f.containerImageVariants(workload.Pods)
+
 	for _, pod := range workload.Pods {
 		switch pod.Health.Level {
 		case string(types.HealthLevelHealthy):
@@ -958,29 +2189,25 @@ func (f *Formatter) printWorkloadSummary(workload types.WorkloadInfo) {
 		}
 
 		// Collect container information
-		for _, container := range append(pod.InitContainers, pod.Containers...) {
+		for _, container := range allContainers(pod) {
 			// Skip containers that don't match the filter
-			if !f.shouldShowContainer(container.Name) {
+			if !f.shouldShowContainer(container) {
 				continue
 			}
 
 			totalRestarts += container.RestartCount
 
-			containerName := container.Name
-			if container.Type == string(types.ContainerTypeInit) {
-				containerName = fmt.Sprintf("[init] %s", container.Name)
-			}
-
 			// Use full image URL instead of just the short name
 			imageName := container.Image
 
+			displayName := containerDisplayName(container)
+			containerName := summaryContainerKey(displayName, imageName, imageVariants[displayName])
+
 			// Initialize or update container info
 			if info, exists := containerInfo[containerName]; exists {
 				// Container already exists, add usage data and update volume types
 				info.CPUUsages = append(info.CPUUsages, container.Resources.CPUPercentage)
 				info.MemUsages = append(info.MemUsages, container.Resources.MemPercentage)
-				info.CPUValues = append(info.CPUValues, container.Resources.CPUUsage)
-				info.MemValues = append(info.MemValues, container.Resources.MemUsage)
 				for _, volume := range container.Volumes {
 					info.VolumeTypes[volume.VolumeType] = true
 				}
@@ -993,18 +2220,20 @@ func (f *Formatter) printWorkloadSummary(workload types.WorkloadInfo) {
 				}
 
 				containerInfo[containerName] = struct {
-					Image       string
-					Type        string
-					CPURequest  string
-					CPULimit    string
-					MemRequest  string
-					MemLimit    string
-					VolumeTypes map[string]bool
-					CPUUsages   []float64
-					MemUsages   []float64
-					CPUValues   []string
-					MemValues   []string
-					Status      string
+					Image           string
+					Type            string
+					CPURequest      string
+					CPULimit        string
+					MemRequest      string
+					MemLimit        string
+					VolumeTypes     map[string]bool
+					CPUUsages       []float64
+					MemUsages       []float64
+					CPUValues       []int64
+					MemValues       []int64
+					CPUEfficiencies []float64
+					MemEfficiencies []float64
+					Status          string
 				}{
 					Image:       imageName,
 					Type:        container.Type,
@@ -1015,19 +2244,55 @@ func (f *Formatter) printWorkloadSummary(workload types.WorkloadInfo) {
 					VolumeTypes: volumeTypes,
 					CPUUsages:   []float64{container.Resources.CPUPercentage},
 					MemUsages:   []float64{container.Resources.MemPercentage},
-					CPUValues:   []string{container.Resources.CPUUsage},
-					MemValues:   []string{container.Resources.MemUsage},
 					Status:      container.Status,
 				}
 			}
+
+			// Containers without a usage reading have no efficiency to judge, rather than 0% of their request
+			info := containerInfo[containerName]
+			if container.Resources.HasCPUUsage {
+				info.CPUValues = append(info.CPUValues, container.Resources.CPUUsageMilli)
+				info.CPUEfficiencies = append(info.CPUEfficiencies, container.Resources.CPURequestPercentage)
+			}
+			if container.Resources.HasMemUsage {
+				info.MemValues = append(info.MemValues, container.Resources.MemUsageBytes)
+				info.MemEfficiencies = append(info.MemEfficiencies, container.Resources.MemRequestPercentage)
+			}
+			containerInfo[containerName] = info
 		}
 	}
 
-	fmt.Println("WORKLOAD SUMMARY:")
+	fmt.Fprintln(f.out, "WORKLOAD SUMMARY:")
 	if f.options.Problematic {
-		fmt.Printf("  • %d Problematic pods shown\n", len(workload.Pods))
+		fmt.Fprintf(f.out, "  • %d Problematic pods shown\n", len(workload.Pods))
 	} else {
-		fmt.Printf("  • %d Pods: %d Running, %d Warning, %d Failed\n", len(workload.Pods), running, warning, failed)
+		fmt.Fprintf(f.out, "  • %d Pods: %d Running, %d Warning, %d Failed\n", len(workload.Pods), running, warning, failed)
+	}
+	if revisions := formatRevisionDistribution(workload.Pods); revisions != "" {
+		fmt.Fprintf(f.out, "  • Revisions: %s\n", revisions)
+	}
+	if evictions := f.summarizeEvictions(workload.Pods); evictions != "" {
+		fmt.Fprintf(f.out, "  • Evicted: %s\n", f.getHealthColor(string(types.HealthLevelCritical)).Sprint(evictions))
+	}
+
+	// Gauges only make sense over every pod, not the problematic subset
+	rollout := templateRollout(workload)
+	showGauges := !f.options.Problematic && len(workload.Pods) > 0
+	if showGauges {
+		readyPods, _ := f.readyAndRestarts(workload)
+		fmt.Fprintf(f.out, "  • Readiness: %s\n", f.formatGauge(readyPods, len(workload.Pods), "ready"))
+	}
+	if details := formatRolloutStatus(rollout); showGauges && rollout.Current+rollout.Outdated > 0 {
+		gauge := f.formatGauge(rollout.Current, rollout.Current+rollout.Outdated, "updated")
+		if details != "" {
+			gauge += " (" + f.getHealthColor(string(types.HealthLevelDegraded)).Sprint(details) + ")"
+		}
+		fmt.Fprintf(f.out, "  • Rollout:   %s\n", gauge)
+	} else if details != "" {
+		fmt.Fprintf(f.out, "  • Rollout: %s\n", f.getHealthColor(string(types.HealthLevelDegraded)).Sprint(details))
+	}
+	if probes := formatProbeFailures(f.countProbeFailures(workload.Pods)); probes != "" {
+		fmt.Fprintf(f.out, "  • Probes: %s\n", f.getHealthColor(string(types.HealthLevelDegraded)).Sprint(probes))
 	}
 
 	// Sort container names for consistent output
@@ -1037,11 +2302,11 @@ func (f *Formatter) printWorkloadSummary(workload types.WorkloadInfo) {
 	}
 	sort.Strings(containerNames)
 
-	fmt.Printf("  • Containers:\n")
+	fmt.Fprintf(f.out, "  • Containers:\n")
 	for i, containerName := range containerNames {
 		info := containerInfo[containerName]
-		fmt.Printf("        %d) %s\n", i+1, containerName)
-		fmt.Printf("           Image: %s\n", info.Image)
+		fmt.Fprintf(f.out, "        %d) %s\n", i+1, containerName)
+		fmt.Fprintf(f.out, "           Image: %s\n", info.Image)
 
 		// Format resource allocation
 		resourceParts := []string{}
@@ -1070,9 +2335,9 @@ func (f *Formatter) printWorkloadSummary(workload types.WorkloadInfo) {
 		}
 
 		if len(resourceParts) > 0 {
-			fmt.Printf("           Resources: %s\n", strings.Join(resourceParts, ", "))
+			fmt.Fprintf(f.out, "           Resources: %s\n", strings.Join(resourceParts, ", "))
 		} else {
-			fmt.Printf("           Resources: No limits/requests set\n")
+			fmt.Fprintf(f.out, "           Resources: No limits/requests set\n")
 		}
 
 		// Display resource utilization statistics
@@ -1081,24 +2346,33 @@ func (f *Formatter) printWorkloadSummary(workload types.WorkloadInfo) {
 			memStats := f.calculateResourceStats(info.MemUsages)
 
 			// Calculate actual values for percentiles
-			cpuAvgValue := f.calculateAverageValue(info.CPUValues)
-			cpuP90Value := f.calculatePercentileValue(info.CPUValues, 0.9)
-			cpuP99Value := f.calculatePercentileValue(info.CPUValues, 0.99)
-
-			memAvgValue := f.calculateAverageValue(info.MemValues)
-			memP90Value := f.calculatePercentileValue(info.MemValues, 0.9)
-			memP99Value := f.calculatePercentileValue(info.MemValues, 0.99)
+			cpuAvgValue, cpuP90Value, cpuP99Value := f.formatCPUReadings(info.CPUValues)
+			memAvgValue, memP90Value, memP99Value := f.formatMemoryReadings(info.MemValues)
 
 			if info.Status == string(types.ContainerStatusRunning) {
-				fmt.Printf("           Usage: CPU %s avg:%s (%s) %s p90:%s (%s) %s p99:%s (%s)\n",
+				fmt.Fprintf(f.out, "           Usage: CPU %s avg:%s (%s) %s p90:%s (%s) %s p99:%s (%s)\n",
 					f.createMiniProgressBar(cpuStats.Average), f.formatUsageWithColor(cpuStats.Average), cpuAvgValue,
 					f.createMiniProgressBar(cpuStats.P90), f.formatUsageWithColor(cpuStats.P90), cpuP90Value,
 					f.createMiniProgressBar(cpuStats.P99), f.formatUsageWithColor(cpuStats.P99), cpuP99Value)
 
-				fmt.Printf("                  Mem %s avg:%s (%s) %s p90:%s (%s) %s p99:%s (%s)\n",
+				fmt.Fprintf(f.out, "                  Mem %s avg:%s (%s) %s p90:%s (%s) %s p99:%s (%s)\n",
 					f.createMiniProgressBar(memStats.Average), f.formatUsageWithColor(memStats.Average), memAvgValue,
 					f.createMiniProgressBar(memStats.P90), f.formatUsageWithColor(memStats.P90), memP90Value,
 					f.createMiniProgressBar(memStats.P99), f.formatUsageWithColor(memStats.P99), memP99Value)
+
+				var efficiencies []string
+				if info.CPURequest != "" && len(info.CPUEfficiencies) > 0 {
+					efficiencies = append(efficiencies, "CPU avg "+f.formatEfficiency(f.calculateResourceStats(info.CPUEfficiencies).Average))
+				}
+				if info.MemRequest != "" && len(info.MemEfficiencies) > 0 {
+					efficiencies = append(efficiencies, "Mem avg "+f.formatEfficiency(f.calculateResourceStats(info.MemEfficiencies).Average))
+				}
+				if len(efficiencies) > 0 && !workload.MetricsUnavailable {
+					fmt.Fprintf(f.out, "           Efficiency: %s\n", strings.Join(efficiencies, ", "))
+				}
+				if trend := f.formatWorkloadTrend(workload.Pods, containerName); trend != "" {
+					fmt.Fprintf(f.out, "           Trend: %s\n", trend)
+				}
 			}
 		}
 
@@ -1109,114 +2383,531 @@ func (f *Formatter) printWorkloadSummary(workload types.WorkloadInfo) {
 				volumes = append(volumes, volType)
 			}
 			sort.Strings(volumes)
-			fmt.Printf("           Volumes: %s\n", strings.Join(volumes, ", "))
+			fmt.Fprintf(f.out, "           Volumes: %s\n", strings.Join(volumes, ", "))
 		}
 
 		if i < len(containerNames)-1 {
-			fmt.Println()
+			fmt.Fprintln(f.out)
 		}
 	}
 
-	fmt.Printf("  • Total Restarts: %d\n\n", totalRestarts)
+	fmt.Fprintf(f.out, "  • Workload Total: %s\n", f.calculateWorkloadTotals(workload).String())
+	fmt.Fprintf(f.out, "  • Total Restarts: %d\n", totalRestarts)
+	if recency := f.formatRestartRecency(workload.Pods); recency != "" {
+		fmt.Fprintf(f.out, "  • Last restarts: %s\n", recency)
+	}
+	if reasons := formatRestartReasons(workload.RestartReasons); reasons != "" {
+		fmt.Fprintf(f.out, "  • Restart reasons: %s\n", reasons)
+	}
+	fmt.Fprintln(f.out)
 }
 
-// printWorkloadTable prints a table view of pods in the workload
-func (f *Formatter) printWorkloadTable(workload types.WorkloadInfo) {
-	table := tablewriter.NewWriter(os.Stdout)
-	headers := []string{"POD", "NODE", "STATUS", "READY", "RESTARTS", "CPU (cores)", "MEMORY", "IP", "AGE"}
-	table.SetHeader(headers)
-	table.SetAutoFormatHeaders(false)
-	table.SetBorder(true)
+// formatRestartReasons formats restart counts by reason, e.g. "OOMKilled ×12, Error ×3"
+func formatRestartReasons(reasons []types.RestartReasonCount) string {
+	parts := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		parts = append(parts, fmt.Sprintf("%s ×%d", reason.Reason, reason.Count))
+	}
+	return strings.Join(parts, ", ")
+}
 
-	// Configure column widths based on content and terminal size
-	f.configureWorkloadTableWidths(table, workload)
+// workloadTotals holds resource requests, limits and usage summed over every container of a workload
+type workloadTotals struct {
+	CPURequest, CPULimit, CPUUsage int64 // millicores
+	MemRequest, MemLimit, MemUsage int64 // bytes
+	Unbounded                      int   // containers missing a CPU or memory limit
+}
 
+// calculateWorkloadTotals sums requests, limits and usage across all regular containers of the workload.
+// Init containers are left out since they don't run alongside the app containers.
+func (f *Formatter) calculateWorkloadTotals(workload types.WorkloadInfo) workloadTotals {
+	var totals workloadTotals
 	for _, pod := range workload.Pods {
-		ready := f.getReadyCount(pod)
-		totalContainers := len(pod.Containers)
-		age := f.formatDuration(pod.Age)
+		for _, container := range pod.Containers {
+			if !f.shouldShowContainer(container) {
+				continue
+			}
 
-		statusIcon := f.analyzer.GetHealthIcon(pod.Health.Level)
-		status := fmt.Sprintf("%s %s", statusIcon, pod.Health.Level)
+			resources := container.Resources
+			totals.CPURequest += parseMilliCPU(resources.CPURequest)
+			totals.CPULimit += parseMilliCPU(resources.CPULimit)
+			totals.CPUUsage += resources.CPUUsageMilli
+			totals.MemRequest += parseBytes(resources.MemRequest)
+			totals.MemLimit += parseBytes(resources.MemLimit)
+			totals.MemUsage += resources.MemUsageBytes
 
-		totalRestarts := int32(0)
-		for _, container := range append(pod.InitContainers, pod.Containers...) {
-			totalRestarts += container.RestartCount
+			if resources.CPULimit == "" || resources.MemLimit == "" {
+				totals.Unbounded++
+			}
 		}
+	}
+	return totals
+}
 
-		lastRestartTime := f.getLastRestartTime(pod)
+// String formats the totals as a single capacity line
+func (t workloadTotals) String() string {
+	line := fmt.Sprintf("CPU requests %s / limits %s, usage %s; Mem requests %s / limits %s, usage %s",
+		formatMilliCPU(t.CPURequest), formatMilliCPU(t.CPULimit), formatMilliCPU(t.CPUUsage),
+		formatBytes(t.MemRequest), formatBytes(t.MemLimit), formatBytes(t.MemUsage))
+	if t.Unbounded > 0 {
+		line += fmt.Sprintf(" (%d containers unbounded)", t.Unbounded)
+	}
+	return line
+}
 
-		// Safely read metrics (pod.Metrics may be nil)
-		cpuUsage := "-"
-		memoryUsage := "-"
-		if pod.Metrics != nil {
-			if pod.Metrics.CPUUsage != "" {
-				cpuUsage = pod.Metrics.CPUUsage
-			}
-			if pod.Metrics.MemoryUsage != "" {
-				memoryUsage = pod.Metrics.MemoryUsage
-			}
+// formatMilliCPU formats millicores like kubectl top (e.g. "250m", "1.5")
+func formatMilliCPU(milliCPU int64) string {
+	if milliCPU >= 1000 {
+		cores := float64(milliCPU) / 1000.0
+		if cores >= 10 {
+			return fmt.Sprintf("%.0f", cores)
 		}
+		return fmt.Sprintf("%.1f", cores)
+	}
+	return fmt.Sprintf("%dm", milliCPU)
+}
 
-		// Use full node name - column width will be calculated dynamically
-		node := pod.NodeName
+// formatBytes formats a byte count like kubectl top (e.g. "256Mi", "1.2Gi")
+func formatBytes(bytes int64) string {
+	const (
+		Ki = 1024
+		Mi = Ki * 1024
+		Gi = Mi * 1024
+		Ti = Gi * 1024
+	)
 
-		// Get primary IP (first PodIP or fallback to PodIP field)
-		primaryIP := "-"
-		if len(pod.Network.PodIPs) > 0 {
-			primaryIP = pod.Network.PodIPs[0]
-		} else if pod.Network.PodIP != "" {
-			primaryIP = pod.Network.PodIP
-		}
+	if bytes >= Ti {
+		return fmt.Sprintf("%.1fTi", float64(bytes)/Ti)
+	} else if bytes >= Gi {
+		return fmt.Sprintf("%.1fGi", float64(bytes)/Gi)
+	} else if bytes >= Mi {
+		return fmt.Sprintf("%dMi", bytes/Mi)
+	} else if bytes >= Ki {
+		return fmt.Sprintf("%dKi", bytes/Ki)
+	}
+	return fmt.Sprintf("%d", bytes)
+}
 
-		table.Append([]string{
-			pod.Name,
-			node,
+// nearestRank returns the index of the given percentile in n sorted values using the nearest-rank
+// method: the smallest value with at least that share of values at or below it. The tolerance keeps
+// float error in e.g. 0.07*100 from pushing an exact rank up by one.
+func nearestRank(n int, percentile float64) int {
+	index := int(math.Ceil(percentile*float64(n)-1e-9)) - 1
+	if index < 0 {
+		return 0
+	}
+	if index >= n {
+		return n - 1
+	}
+	return index
+}
+
+// percentileInt64 returns the given nearest-rank percentile of the values, or 0 without any
+func percentileInt64(values []int64, percentile float64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]int64, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[nearestRank(len(sorted), percentile)]
+}
+
+// averageInt64 returns the mean of the values, rounded down, or 0 without any
+func averageInt64(values []int64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var total int64
+	for _, value := range values {
+		total += value
+	}
+	return total / int64(len(values))
+}
+
+// parseMilliCPU parses a CPU quantity into millicores, treating missing or invalid values as zero
+func parseMilliCPU(value string) int64 {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0
+	}
+	return quantity.MilliValue()
+}
+
+// parseBytes parses a memory quantity into bytes, treating missing or invalid values as zero
+func parseBytes(value string) int64 {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0
+	}
+	return quantity.Value()
+}
+
+// formatRevisionDistribution reports how many pods run each template revision, most common first,
+// so a stuck rollout shows how many pods are on the new revision versus the old ones
+func formatRevisionDistribution(pods []types.PodInfo) string {
+	counts := make(map[string]int)
+	for _, pod := range pods {
+		if pod.Revision != "" {
+			counts[pod.Revision]++
+		}
+	}
+	if len(counts) == 0 {
+		return ""
+	}
+
+	revisions := make([]string, 0, len(counts))
+	for revision := range counts {
+		revisions = append(revisions, revision)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		if counts[revisions[i]] != counts[revisions[j]] {
+			return counts[revisions[i]] > counts[revisions[j]]
+		}
+		return revisions[i] < revisions[j]
+	})
+
+	parts := make([]string, 0, len(revisions))
+	for _, revision := range revisions {
+		parts = append(parts, fmt.Sprintf("%d on %s", counts[revision], revision))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printWorkloadTable prints a table view of pods in the workload
+func (f *Formatter) printWorkloadTable(workload types.WorkloadInfo) {
+	if f.isCompact() {
+		f.printCompactWorkloadTable(workload)
+		return
+	}
+
+	// Rendered into a buffer so --hyperlinks can be added once tablewriter has measured the plain text
+	var rendered bytes.Buffer
+	var links []cellLink
+	table := tablewriter.NewWriter(&rendered)
+	headers := []string{"POD", "NODE", "STATUS", "READY", "RESTARTS", "CPU (cores)", "MEMORY (working set)", "IP", "AGE"}
+	if isIndexedJob(workload) {
+		headers = append(headers, "INDEX")
+	}
+	headers = append(headers, labelColumnHeaders(f.options.LabelColumns)...)
+	table.SetHeader(headers)
+	table.SetAutoFormatHeaders(false)
+	table.SetBorder(true)
+
+	// Configure column widths based on content and terminal size
+	f.configureWorkloadTableWidths(table, workload)
+
+	for _, pod := range workload.Pods {
+		age := f.formatAge(pod.Age)
+
+		statusIcon := f.analyzer.GetHealthIcon(pod.Health.Level)
+		status := fmt.Sprintf("%s %s", statusIcon, pod.Health.Level)
+
+		totalRestarts := int32(0)
+		for _, container := range append(pod.InitContainers, pod.Containers...) {
+			totalRestarts += container.RestartCount
+		}
+
+		lastRestartTime := f.getLastRestartTime(pod)
+
+		// Safely read metrics (pod.Metrics may be nil)
+		cpuUsage := f.missingUsage(workload.MetricsUnavailable)
+		memoryUsage := f.missingUsage(workload.MetricsUnavailable)
+		if pod.Metrics != nil {
+			if pod.Metrics.CPUUsage != "" {
+				cpuUsage = f.formatCPUValue(pod.Metrics.CPUUsage, pod.Metrics.CPUUsageMilli)
+			}
+			if pod.Metrics.MemoryUsage != "" {
+				memoryUsage = f.formatMemoryValue(pod.Metrics.MemoryUsage, pod.Metrics.MemoryUsageBytes)
+			}
+		}
+
+		// Use full node name - column width will be calculated dynamically
+		node := pod.NodeName
+
+		// Get primary IP (first PodIP or fallback to PodIP field)
+		primaryIP := "-"
+		if len(pod.Network.PodIPs) > 0 {
+			primaryIP = pod.Network.PodIPs[0]
+		} else if pod.Network.PodIP != "" {
+			primaryIP = pod.Network.PodIP
+		}
+
+		row := []string{
+			pod.Name,
+			node,
 			status,
-			fmt.Sprintf("%d/%d", ready, totalContainers),
+			f.formatReadyCount(pod),
 			f.formatRestartInfo(totalRestarts, lastRestartTime),
 			cpuUsage,
 			memoryUsage,
 			primaryIP,
 			age,
-		})
+		}
+		if isIndexedJob(workload) {
+			row = append(row, completionIndex(pod))
+		}
+		table.Append(append(row, labelColumnValues(pod, f.options.LabelColumns)...))
+		links = append(links, cellLink{Text: pod.Name, URL: f.podURL(pod)}, cellLink{Text: node, URL: f.nodeURL(node)})
 	}
 
 	table.Render()
-	fmt.Println()
+	fmt.Fprint(f.out, f.linkTableCells(rendered.String(), links))
+	fmt.Fprintln(f.out)
+}
+
+// nodeSpreadRow counts a workload's pods on one node by health level
+type nodeSpreadRow struct {
+	Node     string
+	Pods     int
+	Healthy  int
+	Degraded int
+	Critical int
+}
+
+// nodeSpread groups pods by node, nodes with the most critical and then degraded pods first, so
+// failures that cluster on a node stand out
+func nodeSpread(pods []types.PodInfo) []nodeSpreadRow {
+	rows := make(map[string]*nodeSpreadRow)
+	for _, pod := range pods {
+		node := pod.NodeName
+		if node == "" {
+			node = "<unscheduled>"
+		}
+		row, ok := rows[node]
+		if !ok {
+			row = &nodeSpreadRow{Node: node}
+			rows[node] = row
+		}
+		row.Pods++
+		switch pod.Health.Level {
+		case string(types.HealthLevelHealthy):
+			row.Healthy++
+		case string(types.HealthLevelDegraded):
+			row.Degraded++
+		case string(types.HealthLevelCritical):
+			row.Critical++
+		}
+	}
+
+	spread := make([]nodeSpreadRow, 0, len(rows))
+	for _, row := range rows {
+		spread = append(spread, *row)
+	}
+	sort.Slice(spread, func(i, j int) bool {
+		if spread[i].Critical != spread[j].Critical {
+			return spread[i].Critical > spread[j].Critical
+		}
+		if spread[i].Degraded != spread[j].Degraded {
+			return spread[i].Degraded > spread[j].Degraded
+		}
+		return spread[i].Node < spread[j].Node
+	})
+	return spread
+}
+
+// printNodeSpread prints how the workload's pods are spread across nodes, for --node-summary
+func (f *Formatter) printNodeSpread(workload types.WorkloadInfo) {
+	if len(workload.Pods) == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.out, "NODE SPREAD:")
+	table := tablewriter.NewWriter(f.out)
+	table.SetHeader([]string{"NODE", "PODS", "HEALTHY", "DEGRADED", "CRITICAL"})
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetBorder(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, row := range nodeSpread(workload.Pods) {
+		degraded := fmt.Sprintf("%d", row.Degraded)
+		if row.Degraded > 0 {
+			degraded = f.getHealthColor(string(types.HealthLevelDegraded)).Sprint(degraded)
+		}
+		critical := fmt.Sprintf("%d", row.Critical)
+		if row.Critical > 0 {
+			critical = f.getHealthColor(string(types.HealthLevelCritical)).Sprint(critical)
+		}
+		table.Append([]string{row.Node, fmt.Sprintf("%d", row.Pods), fmt.Sprintf("%d", row.Healthy), degraded, critical})
+	}
+
+	table.Render()
+	fmt.Fprintln(f.out)
+}
+
+// Compact table layout: terminals narrower than the threshold get it automatically, and pod names are
+// shortened to fit 80 columns
+const (
+	compactWidthThreshold = 100
+	compactPodNameWidth   = 30
+)
+
+// isCompact reports whether the workload table should use the narrow layout
+func (f *Formatter) isCompact() bool {
+	return f.options.Compact || f.getTerminalWidth() < compactWidthThreshold
+}
+
+// printCompactWorkloadTable prints the workload table without the NODE, IP and CPU columns so it fits 80 columns
+func (f *Formatter) printCompactWorkloadTable(workload types.WorkloadInfo) {
+	headers := []string{"POD", "S", "READY", "RESTARTS", "MEM (WS)", "AGE"}
+	if isIndexedJob(workload) {
+		headers = append(headers, "INDEX")
+	}
+
+	var rendered bytes.Buffer
+	var links []cellLink
+	table := tablewriter.NewWriter(&rendered)
+	table.SetHeader(append(headers, labelColumnHeaders(f.options.LabelColumns)...))
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetBorder(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, pod := range workload.Pods {
+		totalRestarts := int32(0)
+		for _, container := range append(pod.InitContainers, pod.Containers...) {
+			totalRestarts += container.RestartCount
+		}
+
+		memoryUsage := f.missingUsage(workload.MetricsUnavailable)
+		if pod.Metrics != nil && pod.Metrics.MemoryUsage != "" {
+			memoryUsage = f.formatMemoryValue(pod.Metrics.MemoryUsage, pod.Metrics.MemoryUsageBytes)
+		}
+
+		row := []string{
+			truncateMiddle(pod.Name, compactPodNameWidth),
+			f.getHealthColor(pod.Health.Level).Sprint(compactHealthGlyph(pod.Health.Level)),
+			f.formatReadyCount(pod),
+			fmt.Sprintf("%d", totalRestarts),
+			memoryUsage,
+			f.formatAge(pod.Age),
+		}
+		if isIndexedJob(workload) {
+			row = append(row, completionIndex(pod))
+		}
+		table.Append(append(row, labelColumnValues(pod, f.options.LabelColumns)...))
+		links = append(links, cellLink{Text: row[0], URL: f.podURL(pod)})
+	}
+
+	table.Render()
+	fmt.Fprint(f.out, f.linkTableCells(rendered.String(), links))
+	fmt.Fprintln(f.out)
+}
+
+// isIndexedJob reports whether the workload is a Job whose pods each run one completion index
+func isIndexedJob(workload types.WorkloadInfo) bool {
+	return workload.Job != nil && workload.Job.Indexed
+}
+
+// completionIndex returns the pod's completion index for the INDEX column, or - when it has none
+func completionIndex(pod types.PodInfo) string {
+	if pod.CompletionIndex == "" {
+		return "-"
+	}
+	return pod.CompletionIndex
+}
+
+// labelColumnHeaders returns the table headers for --label-columns, upper-cased like kubectl get -L
+func labelColumnHeaders(keys []string) []string {
+	headers := make([]string, 0, len(keys))
+	for _, key := range keys {
+		headers = append(headers, strings.ToUpper(key))
+	}
+	return headers
+}
+
+// labelColumnValues returns the pod's value for each --label-columns key, or <none> when it isn't set
+func labelColumnValues(pod types.PodInfo, keys []string) []string {
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, ok := pod.Labels[key]
+		if !ok {
+			value = "<none>"
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// labelColumnsWidth estimates how many terminal columns the --label-columns take, borders included
+func labelColumnsWidth(pods []types.PodInfo, keys []string) int {
+	widths := make([]int, len(keys))
+	for i, key := range keys {
+		widths[i] = len(key)
+	}
+	for _, pod := range pods {
+		for i, value := range labelColumnValues(pod, keys) {
+			widths[i] = max(widths[i], len(value))
+		}
+	}
+
+	total := 0
+	for _, width := range widths {
+		total += width + 3 // cell padding and separator
+	}
+	return total
+}
+
+// compactHealthGlyph returns a single-character marker for a health level
+func compactHealthGlyph(level string) string {
+	switch level {
+	case string(types.HealthLevelHealthy):
+		return "✓"
+	case string(types.HealthLevelDegraded):
+		return "!"
+	case string(types.HealthLevelCritical):
+		return "✗"
+	default:
+		return "?"
+	}
+}
+
+// truncateMiddle shortens a name to width runes by cutting out its middle, keeping the suffix that
+// tells generated pod names apart
+func truncateMiddle(name string, width int) string {
+	runes := []rune(name)
+	if len(runes) <= width {
+		return name
+	}
+	head := (width - 1) / 2
+	tail := width - 1 - head
+	return string(runes[:head]) + "…" + string(runes[len(runes)-tail:])
 }
 
 // printWorkloadEvents prints aggregated events for the workload
 func (f *Formatter) printWorkloadEvents(workload types.WorkloadInfo) {
-	// Collect all events from all pods
+	// Events were not collected, so there is nothing to show
+	if !f.options.ShowEvents {
+		return
+	}
+
+	// Collect all events from all pods, folding repeats into a single line
 	var allEvents []types.EventInfo
 	for _, pod := range workload.Pods {
 		allEvents = append(allEvents, pod.Events...)
 	}
-
-	// Sort events by time (newest first)
-	sort.Slice(allEvents, func(i, j int) bool {
-		return allEvents[i].Time.After(allEvents[j].Time)
-	})
+	allEvents = sortEvents(aggregateEvents(allEvents), f.options.EventsSort)
 
 	// Determine the time window message
-	timeWindow := "last 1h"
+	timeWindow := f.eventsWindow()
 
 	// Enhanced workload events section with better visual structure
 	eventsColor := color.New(color.FgHiBlue, color.Bold)
-	fmt.Printf("📋 %s (%s):\n", eventsColor.Sprint("Workload Events"), timeWindow)
+	fmt.Fprintf(f.out, "📋 %s (%s):\n", eventsColor.Sprint("Workload Events"), timeWindow)
 
-	if len(allEvents) == 0 {
-		fmt.Printf("  • ✨ No events found in %s\n", timeWindow)
+	if workload.EventsForbidden {
+		fmt.Fprintf(f.out, "  • events unavailable (forbidden)\n")
+	} else if len(allEvents) == 0 {
+		fmt.Fprintf(f.out, "  • ✨ No events found in %s\n", timeWindow)
 	} else {
-		// Show up to 10 most recent events
-		maxEvents := 10
-		if len(allEvents) > maxEvents {
-			allEvents = allEvents[:maxEvents]
-		}
-
-		for _, event := range allEvents {
-			age := time.Since(event.Time)
+		// Show only the most recent events, up to --max-events
+		shownEvents, hidden := limitEvents(allEvents, f.options.MaxEvents)
+		for _, event := range shownEvents {
+			age := f.since(event.Time)
 			eventIcon := ""
 			eventColor := color.New()
 
@@ -1234,26 +2925,21 @@ func (f *Formatter) printWorkloadEvents(workload types.WorkloadInfo) {
 				eventColor = color.New(color.FgWhite)
 			}
 
-			fmt.Printf("  • %s %s %s [%s]: %s (%s)\n",
+			fmt.Fprintf(f.out, "  • %s %s %s [%s]: %s (%s)%s\n",
 				eventIcon,
 				eventColor.Sprint(event.Type),
-				f.formatDuration(age),
+				f.formatAge(age),
 				event.PodName,
 				event.Message,
-				event.Reason)
+				event.Reason,
+				f.formatEventOccurrences(event))
 		}
 
-		if len(workload.Pods) > 0 {
-			totalEvents := 0
-			for _, pod := range workload.Pods {
-				totalEvents += len(pod.Events)
-			}
-			if totalEvents > maxEvents {
-				fmt.Printf("  💭 ... and %d more events\n", totalEvents-maxEvents)
-			}
+		if hidden > 0 {
+			fmt.Fprintf(f.out, "  💭 ... and %d more events\n", hidden)
 		}
 	}
-	fmt.Println()
+	fmt.Fprintln(f.out)
 }
 
 // calculateResourceStats calculates resource utilization statistics
@@ -1280,20 +2966,8 @@ func (f *Formatter) calculateResourceStats(usages []float64) struct {
 	average := total / float64(len(usages))
 
 	// Calculate percentiles
-	n := len(usages)
-	p90Index := int(float64(n) * 0.9)
-	p99Index := int(float64(n) * 0.99)
-
-	// Ensure indices are within bounds
-	if p90Index >= n {
-		p90Index = n - 1
-	}
-	if p99Index >= n {
-		p99Index = n - 1
-	}
-
-	p90 := usages[p90Index]
-	p99 := usages[p99Index]
+	p90 := usages[nearestRank(len(usages), 0.9)]
+	p99 := usages[nearestRank(len(usages), 0.99)]
 
 	return struct {
 		Average float64
@@ -1308,40 +2982,41 @@ func (f *Formatter) calculateResourceStats(usages []float64) struct {
 
 // createMiniProgressBar creates a mini progress bar string
 func (f *Formatter) createMiniProgressBar(percentage float64) string {
+	return f.renderMiniProgressBar(percentage, f.usageColor(percentage))
+}
+
+// createCompletionBar creates a mini progress bar for a count where fuller is better, such as
+// ready replicas: green when complete, yellow from half way, red below
+func (f *Formatter) createCompletionBar(percentage float64) string {
+	barColor := color.FgHiRed
+	if percentage >= 100 {
+		barColor = color.FgHiGreen
+	} else if percentage >= 50 {
+		barColor = color.FgHiYellow
+	}
+	return f.renderMiniProgressBar(percentage, barColor)
+}
+
+// renderMiniProgressBar draws a mini bar filled up to the percentage in the given color
+func (f *Formatter) renderMiniProgressBar(percentage float64, barColor color.Attribute) string {
 	if f.options.NoColor {
 		return fmt.Sprintf("%.0f%%", percentage)
 	}
 
-	// Create a clean gradient bar with simplified modern colors
-	segments := 8
+	segments := f.miniBarWidth()
+	segmentSize := 100 / float64(segments)
 
 	var bar strings.Builder
 
-	// Create gradient based on overall percentage for each segment
 	for i := 0; i < segments; i++ {
-		segmentThreshold := float64(i+1) * 12.5 // Each segment represents 12.5%
+		segmentThreshold := float64(i+1) * segmentSize
 
 		if percentage >= segmentThreshold {
-			// Filled segment - use simplified color scheme
-			if percentage >= 90 {
-				// Critical: Red (90%+)
-				bar.WriteString(color.New(color.FgHiRed, color.Bold).Sprint("█"))
-			} else if percentage >= 70 {
-				// Warning: Yellow (70-90%)
-				bar.WriteString(color.New(color.FgHiYellow, color.Bold).Sprint("█"))
-			} else {
-				// Healthy: Green (0-70%)
-				bar.WriteString(color.New(color.FgHiGreen, color.Bold).Sprint("█"))
-			}
-		} else if percentage >= segmentThreshold-12.5 {
-			// Partially filled segment - same color scheme
-			if percentage >= 90 {
-				bar.WriteString(color.New(color.FgHiRed).Sprint("▓"))
-			} else if percentage >= 70 {
-				bar.WriteString(color.New(color.FgHiYellow).Sprint("▓"))
-			} else {
-				bar.WriteString(color.New(color.FgHiGreen).Sprint("▓"))
-			}
+			// Filled segment
+			bar.WriteString(color.New(barColor, color.Bold).Sprint("█"))
+		} else if percentage >= segmentThreshold-segmentSize {
+			// Partially filled segment
+			bar.WriteString(color.New(barColor).Sprint("▓"))
 		} else {
 			// Empty segment - subtle gray
 			bar.WriteString(color.New(color.FgHiBlack).Sprint("░"))
@@ -1351,18 +3026,19 @@ func (f *Formatter) createMiniProgressBar(percentage float64) string {
 	return bar.String()
 }
 
+// formatGauge renders a completion bar followed by its count, e.g. "██████░░ 3/4 ready"
+func (f *Formatter) formatGauge(done, total int, label string) string {
+	percentage := float64(done) / float64(total) * 100
+	return fmt.Sprintf("%s %d/%d %s", f.createCompletionBar(percentage), done, total, label)
+}
+
 // formatUsageWithColor formats a usage percentage with appropriate color
 func (f *Formatter) formatUsageWithColor(percentage float64) string {
 	if f.options.NoColor {
 		return fmt.Sprintf("%.0f%%", percentage)
 	}
 
-	if percentage >= 90 {
-		return color.New(color.FgHiRed, color.Bold).Sprintf("%.0f%%", percentage)
-	} else if percentage >= 70 {
-		return color.New(color.FgHiYellow, color.Bold).Sprintf("%.0f%%", percentage)
-	}
-	return color.New(color.FgHiGreen, color.Bold).Sprintf("%.0f%%", percentage)
+	return color.New(f.usageColor(percentage), color.Bold).Sprintf("%.0f%%", percentage)
 }
 
 // configureWorkloadTableWidths configures optimal column widths for the workload table
@@ -1371,8 +3047,8 @@ func (f *Formatter) configureWorkloadTableWidths(table *tablewriter.Table, workl
 		return
 	}
 
-	// Get terminal width
-	terminalWidth := f.getTerminalWidth()
+	// Get terminal width, leaving room for any label columns
+	terminalWidth := f.getTerminalWidth() - labelColumnsWidth(workload.Pods, f.options.LabelColumns)
 
 	// Set table formatting options for better width handling
 	table.SetAutoWrapText(false)
@@ -1394,17 +3070,24 @@ func (f *Formatter) configureWorkloadTableWidths(table *tablewriter.Table, workl
 	}
 
 	// Set column alignments
-	table.SetColumnAlignment([]int{
+	alignments := []int{
 		tablewriter.ALIGN_LEFT,   // POD
 		tablewriter.ALIGN_LEFT,   // NODE
 		tablewriter.ALIGN_LEFT,   // STATUS
 		tablewriter.ALIGN_CENTER, // READY
 		tablewriter.ALIGN_LEFT,   // RESTARTS
 		tablewriter.ALIGN_LEFT,   // CPU (cores)
-		tablewriter.ALIGN_LEFT,   // MEMORY
+		tablewriter.ALIGN_LEFT,   // MEMORY (working set)
 		tablewriter.ALIGN_LEFT,   // IP
 		tablewriter.ALIGN_RIGHT,  // AGE
-	})
+	}
+	if isIndexedJob(workload) {
+		alignments = append(alignments, tablewriter.ALIGN_RIGHT) // INDEX
+	}
+	for range f.options.LabelColumns {
+		alignments = append(alignments, tablewriter.ALIGN_LEFT)
+	}
+	table.SetColumnAlignment(alignments)
 }
 
 // configureContainerTableWidths configures optimal column widths for the container table
@@ -1445,24 +3128,24 @@ func (f *Formatter) printLogsWarning() {
 	warningBox := "┌─ WARNING ────────────────────────────────────────────┐"
 	warningBottom := "└─────────────────────────────────────────────────────┘"
 
-	fmt.Println(separatorColor.Sprint(warningBox))
-	fmt.Printf("│ %s %s │\n",
+	fmt.Fprintln(f.out, separatorColor.Sprint(warningBox))
+	fmt.Fprintf(f.out, "│ %s %s │\n",
 		warningColor.Sprint("⚠️  SHOWING CONTAINER LOGS"),
 		strings.Repeat(" ", max(0, 24)), // Padding to align with box
 	)
-	fmt.Printf("│ %s %s │\n",
+	fmt.Fprintf(f.out, "│ %s %s │\n",
 		"Recent container logs are included below.",
 		strings.Repeat(" ", max(0, 12)), // Padding to align with box
 	)
-	fmt.Println(separatorColor.Sprint(warningBottom))
-	fmt.Println()
+	fmt.Fprintln(f.out, separatorColor.Sprint(warningBottom))
+	fmt.Fprintln(f.out)
 }
 
 // printPodMetadata prints pod metadata (labels and annotations)
 func (f *Formatter) printPodMetadata(pod types.PodInfo) {
 	// Print labels
 	if len(pod.Labels) > 0 {
-		fmt.Printf("📋 Pod Labels:\n")
+		fmt.Fprintf(f.out, "📋 Pod Labels:\n")
 		var sortedLabels []string
 		for key, value := range pod.Labels {
 			sortedLabels = append(sortedLabels, fmt.Sprintf("%s=%s", key, value))
@@ -1473,38 +3156,71 @@ func (f *Formatter) printPodMetadata(pod types.PodInfo) {
 		limit := 10
 		for i, label := range sortedLabels {
 			if i >= limit {
-				fmt.Printf("    ... and %d more\n", len(sortedLabels)-limit)
+				fmt.Fprintf(f.out, "    ... and %d more\n", len(sortedLabels)-limit)
 				break
 			}
-			fmt.Printf("    • %s\n", label)
+			fmt.Fprintf(f.out, "    • %s\n", label)
 		}
-		fmt.Println()
+		fmt.Fprintln(f.out)
 	}
 
 	// Print annotations
 	if len(pod.Annotations) > 0 {
-		fmt.Printf("📝 Pod Annotations:\n")
-		var sortedAnnotations []string
-		for key, value := range pod.Annotations {
-			// Truncate very long annotation values for readability
-			if len(value) > 100 {
-				value = value[:97] + "..."
+		var keys []string
+		hidden := 0
+		for key := range pod.Annotations {
+			if !f.options.AllAnnotations && isNoisyAnnotation(key) {
+				hidden++
+				continue
 			}
-			sortedAnnotations = append(sortedAnnotations, fmt.Sprintf("%s=%s", key, value))
+			keys = append(keys, key)
 		}
-		sort.Strings(sortedAnnotations)
+		sort.Strings(keys)
+
+		fmt.Fprintf(f.out, "📝 Pod Annotations:\n")
 
 		// Limit annotations display
 		limit := 10
-		for i, annotation := range sortedAnnotations {
+		for i, key := range keys {
 			if i >= limit {
-				fmt.Printf("    ... and %d more\n", len(sortedAnnotations)-limit)
+				fmt.Fprintf(f.out, "    ... and %d more\n", len(keys)-limit)
 				break
 			}
-			fmt.Printf("    • %s\n", annotation)
+			// Truncate very long annotation values for readability
+			value := pod.Annotations[key]
+			if len(value) > 100 {
+				value = value[:97] + "..."
+			}
+			fmt.Fprintf(f.out, "    • %s=%s\n", key, value)
+		}
+		if hidden > 0 {
+			fmt.Fprintf(f.out, "    (%d noisy annotations hidden, use --all-annotations to show)\n", hidden)
+		}
+		fmt.Fprintln(f.out)
+	}
+}
+
+// noisyAnnotationPrefixes lists annotation keys (or key prefixes) that are hidden by default
+// because they are large or rarely useful when debugging
+var noisyAnnotationPrefixes = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"checksum/",
+	"kubernetes.io/config.hash",
+	"kubernetes.io/config.mirror",
+	"kubernetes.io/config.seen",
+	"kubernetes.io/config.source",
+	"cni.projectcalico.org/",
+	"k8s.v1.cni.cncf.io/network-status",
+}
+
+// isNoisyAnnotation checks if an annotation key should be hidden by default
+func isNoisyAnnotation(key string) bool {
+	for _, prefix := range noisyAnnotationPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
 		}
-		fmt.Println()
 	}
+	return false
 }
 
 // printPodConditions prints pod conditions, especially for pending or problematic pods
@@ -1528,8 +3244,8 @@ func (f *Formatter) printPodConditions(pod types.PodInfo) {
 		return
 	}
 
-	fmt.Printf("🏷️  Conditions:\n")
-	fmt.Printf("  %-17s %-7s\n", "Type", "Status")
+	fmt.Fprintf(f.out, "🏷️  Conditions:\n")
+	fmt.Fprintf(f.out, "  %-17s %-7s\n", "Type", "Status")
 	for _, condition := range pod.Conditions {
 		// Highlight failed conditions in red
 		statusDisplay := condition.Status
@@ -1539,15 +3255,181 @@ func (f *Formatter) printPodConditions(pod types.PodInfo) {
 			statusDisplay = color.New(color.FgGreen).Sprint(condition.Status)
 		}
 
-		fmt.Printf("  %-17s %s", condition.Type, statusDisplay)
+		fmt.Fprintf(f.out, "  %-17s %s", condition.Type, statusDisplay)
 
-		// Show reason for False conditions
-		if condition.Status == "False" && condition.Reason != "" {
-			fmt.Printf(" (%s)", condition.Reason)
+		// Show reason and how long it has been failing for False conditions
+		if condition.Status == "False" {
+			if details := f.conditionDetails(condition); details != "" {
+				fmt.Fprintf(f.out, " (%s)", details)
+			}
 		}
-		fmt.Println()
+		fmt.Fprintln(f.out)
+	}
+	fmt.Fprintln(f.out)
+}
+
+// printSchedulingConstraints prints the affinity and topology spread rules that can keep a pending pod unscheduled
+func (f *Formatter) printSchedulingConstraints(pod types.PodInfo) {
+	if len(pod.SchedulingConstraints) == 0 {
+		return
+	}
+
+	fmt.Fprintf(f.out, "🧭 Scheduling constraints:\n")
+	for _, constraint := range pod.SchedulingConstraints {
+		fmt.Fprintf(f.out, "    • %s\n", constraint)
 	}
-	fmt.Println()
+	fmt.Fprintln(f.out)
+}
+
+// printNodePlacement prints the node selector, node affinity and tolerations that decide where a pod can land.
+// They are shown for pending pods, or on request with --show-node-selector and --show-tolerations.
+func (f *Formatter) printNodePlacement(pod types.PodInfo) {
+	isPending := pod.Status == "Pending"
+	showNodeSelector := (isPending || f.options.ShowNodeSelector) && (len(pod.NodeSelector) > 0 || len(pod.NodeAffinity) > 0)
+	showTolerations := (isPending || f.options.ShowTolerations) && len(pod.Tolerations) > 0
+	if !showNodeSelector && !showTolerations {
+		return
+	}
+
+	fmt.Fprintf(f.out, "📍 Node placement:\n")
+	if showNodeSelector {
+		if len(pod.NodeSelector) > 0 {
+			var selectors []string
+			for key, value := range pod.NodeSelector {
+				selectors = append(selectors, fmt.Sprintf("%s=%s", key, value))
+			}
+			sort.Strings(selectors)
+			fmt.Fprintf(f.out, "    Node selector: %s\n", strings.Join(selectors, ", "))
+		}
+		for _, affinity := range pod.NodeAffinity {
+			fmt.Fprintf(f.out, "    Node affinity: %s\n", affinity)
+		}
+	}
+	if showTolerations {
+		fmt.Fprintf(f.out, "    Tolerations:   %s\n", strings.Join(pod.Tolerations, ", "))
+	}
+	fmt.Fprintln(f.out)
+}
+
+// printNodeUsage prints the pod's usage as a share of its node's allocatable capacity (--node-context)
+func (f *Formatter) printNodeUsage(pod types.PodInfo) {
+	if pod.NodeUsage == nil {
+		return
+	}
+	fmt.Fprintf(f.out, "🖥️  Node capacity (%s):\n", pod.NodeUsage.NodeName)
+	fmt.Fprintf(f.out, "    CPU:    %s\n", f.formatNodeShare(pod.NodeUsage.HasUsage, pod.NodeUsage.CPUPercentage, pod.NodeUsage.CPUAllocatable))
+	fmt.Fprintf(f.out, "    Memory: %s\n", f.formatNodeShare(pod.NodeUsage.HasUsage, pod.NodeUsage.MemPercentage, pod.NodeUsage.MemAllocatable))
+	fmt.Fprintln(f.out)
+}
+
+// printNodeProblems warns about the unhealthy conditions of the pod's node (--node-health)
+func (f *Formatter) printNodeProblems(pod types.PodInfo) {
+	if len(pod.NodeProblems) == 0 {
+		return
+	}
+	fmt.Fprintln(f.out, f.getHealthColor(string(types.HealthLevelDegraded)).Sprintf("⚠️  node %s has %s", pod.NodeName, strings.Join(pod.NodeProblems, ", ")))
+}
+
+// printWorkloadNodeProblems lists the workload's unhealthy nodes below the pod table, each with the
+// pods running on it, e.g. "⚠️  node ip-10-0-1-5 has MemoryPressure (api-1, api-2)"
+func (f *Formatter) printWorkloadNodeProblems(workload types.WorkloadInfo) {
+	var nodes []string
+	problems := make(map[string][]string)
+	pods := make(map[string][]string)
+	for _, pod := range workload.Pods {
+		if len(pod.NodeProblems) == 0 {
+			continue
+		}
+		if _, seen := problems[pod.NodeName]; !seen {
+			nodes = append(nodes, pod.NodeName)
+			problems[pod.NodeName] = pod.NodeProblems
+		}
+		pods[pod.NodeName] = append(pods[pod.NodeName], pod.Name)
+	}
+	if len(nodes) == 0 {
+		return
+	}
+
+	sort.Strings(nodes)
+	warningColor := f.getHealthColor(string(types.HealthLevelDegraded))
+	for _, node := range nodes {
+		fmt.Fprintln(f.out, warningColor.Sprintf("⚠️  node %s has %s (%s)", node, strings.Join(problems[node], ", "), strings.Join(pods[node], ", ")))
+	}
+	fmt.Fprintln(f.out)
+}
+
+// printWorkloadWaitingMessages lists why the workload's waiting containers are stuck below the pod table,
+// one line per distinct message with the pods showing it, e.g.
+// "⚠️  app ImagePullBackOff: Back-off pulling image "app:2.0" (web-1, web-2)"
+func (f *Formatter) printWorkloadWaitingMessages(workload types.WorkloadInfo) {
+	var messages []string
+	pods := make(map[string][]string)
+	for _, pod := range workload.Pods {
+		for _, container := range allContainers(pod) {
+			if container.StatusMessage == "" || !f.shouldShowContainer(container) {
+				continue
+			}
+			message := fmt.Sprintf("%s %s: %s", container.Name, container.Status, truncateMessage(container.StatusMessage, waitingMessageWidth))
+			if _, seen := pods[message]; !seen {
+				messages = append(messages, message)
+			}
+			// A pod is listed once even if several of its containers wait for the same reason
+			if names := pods[message]; len(names) == 0 || names[len(names)-1] != pod.Name {
+				pods[message] = append(names, pod.Name)
+			}
+		}
+	}
+	if len(messages) == 0 {
+		return
+	}
+
+	warningColor := f.getHealthColor(string(types.HealthLevelDegraded))
+	for _, message := range messages {
+		fmt.Fprintln(f.out, warningColor.Sprintf("⚠️  %s (%s)", message, strings.Join(pods[message], ", ")))
+	}
+	fmt.Fprintln(f.out)
+}
+
+// formatNodeShare renders one resource of the node capacity block, e.g. "6.3% of 3.9 allocatable"
+func (f *Formatter) formatNodeShare(hasUsage bool, percentage float64, allocatable string) string {
+	if !hasUsage {
+		return fmt.Sprintf("n/a of %s allocatable", allocatable)
+	}
+	return fmt.Sprintf("%s of %s allocatable", f.getResourceColor(percentage).Sprintf("%.1f%%", percentage), allocatable)
+}
+
+// conditionDetails describes why a condition is False and for how long, e.g. "Unschedulable, False for 12m"
+func (f *Formatter) conditionDetails(condition types.PodCondition) string {
+	var details []string
+	if condition.Reason != "" {
+		details = append(details, condition.Reason)
+	}
+	if !condition.LastTransitionTime.IsZero() {
+		details = append(details, fmt.Sprintf("False for %s", f.formatDuration(f.since(condition.LastTransitionTime))))
+	}
+	return strings.Join(details, ", ")
+}
+
+// printPodFinalizers prints pod finalizers, which commonly block terminating pods from going away
+func (f *Formatter) printPodFinalizers(pod types.PodInfo) {
+	if len(pod.Finalizers) == 0 && pod.Status != "Terminating" {
+		return
+	}
+
+	header := "🧹 Finalizers"
+	if pod.Status == "Terminating" && pod.TerminatingFor > 0 {
+		header += fmt.Sprintf(" (terminating for %s, grace period %s)",
+			f.formatDuration(pod.TerminatingFor), f.formatDuration(pod.TerminationGracePeriod))
+	}
+	fmt.Fprintf(f.out, "%s:\n", header)
+
+	if len(pod.Finalizers) == 0 {
+		fmt.Fprintf(f.out, "    (none)\n")
+	}
+	for _, finalizer := range pod.Finalizers {
+		fmt.Fprintf(f.out, "    • %s\n", finalizer)
+	}
+	fmt.Fprintln(f.out)
 }
 
 // wrapSchedulingMessage formats long FailedScheduling messages for better readability
@@ -1624,64 +3506,111 @@ func (f *Formatter) printNetworkInfo(pod types.PodInfo) {
 		networkInfo += fmt.Sprintf("   HOST IP: %s", pod.Network.HostIP)
 	}
 
-	fmt.Printf("%s\n", networkInfo)
+	fmt.Fprintf(f.out, "%s\n", networkInfo)
 }
 
-// calculateAverageValue calculates the average of resource values
-func (f *Formatter) calculateAverageValue(values []string) string {
+// formatCPUReadings renders the average, p90 and p99 of CPU readings in millicores the way single
+// readings are shown, or "-" for each without any
+func (f *Formatter) formatCPUReadings(values []int64) (average, p90, p99 string) {
 	if len(values) == 0 {
-		return "-"
+		return "-", "-", "-"
 	}
-
-	// For now, just return the first value as a simple average
-	// In a more sophisticated implementation, we would parse the values
-	// and calculate the actual average, but for display purposes,
-	// showing a representative value is sufficient
-	return values[0]
+	format := func(milliCPU int64) string { return f.formatCPUValue(formatMilliCPU(milliCPU), milliCPU) }
+	return format(averageInt64(values)), format(percentileInt64(values, 0.9)), format(percentileInt64(values, 0.99))
 }
 
-// calculatePercentileValue calculates the percentile value from a slice of resource values
-func (f *Formatter) calculatePercentileValue(values []string, percentile float64) string {
+// formatMemoryReadings renders the average, p90 and p99 of memory readings in bytes the way single
+// readings are shown, or "-" for each without any
+func (f *Formatter) formatMemoryReadings(values []int64) (average, p90, p99 string) {
 	if len(values) == 0 {
-		return "-"
-	}
-
-	// Sort the values to calculate percentile
-	sortedValues := make([]string, len(values))
-	copy(sortedValues, values)
-	sort.Strings(sortedValues)
-
-	// Calculate the index for the percentile
-	n := len(sortedValues)
-	index := int(float64(n) * percentile)
-
-	// Ensure index is within bounds
-	if index >= n {
-		index = n - 1
+		return "-", "-", "-"
 	}
-
-	return sortedValues[index]
+	format := func(bytes int64) string { return f.formatMemoryValue(formatBytes(bytes), bytes) }
+	return format(averageInt64(values)), format(percentileInt64(values, 0.9)), format(percentileInt64(values, 0.99))
 }
 
-// filterContainers filters containers based on the container name option
+// filterContainers filters containers based on the container name and type options
 func (f *Formatter) filterContainers(containers []types.ContainerInfo) []types.ContainerInfo {
-	if f.options.ContainerName == "" {
+	if f.options.ContainerName == "" && !f.filtersContainerType() {
 		return containers
 	}
 
 	var filtered []types.ContainerInfo
 	for _, container := range containers {
-		if container.Name == f.options.ContainerName {
+		if f.shouldShowContainer(container) {
 			filtered = append(filtered, container)
 		}
 	}
 	return filtered
 }
 
-// shouldShowContainer checks if a container should be shown based on the filter
-func (f *Formatter) shouldShowContainer(containerName string) bool {
-	if f.options.ContainerName == "" {
-		return true
+// shouldShowContainer checks if a container should be shown based on the name and type filters
+func (f *Formatter) shouldShowContainer(container types.ContainerInfo) bool {
+	if f.options.ContainerName != "" && container.Name != f.options.ContainerName {
+		return false
+	}
+	if len(f.options.Statuses) > 0 && !matchesStatus(container, f.options.Statuses) {
+		return false
+	}
+	return !f.filtersContainerType() || container.Type == f.options.ContainerType
+}
+
+// filterContainerStatuses keeps the pods with a container in one of the statuses, and the workloads
+// that still have pods
+func filterContainerStatuses(workloads []types.WorkloadInfo, statuses []string) []types.WorkloadInfo {
+	var filtered []types.WorkloadInfo
+	for _, workload := range workloads {
+		var matchingPods []types.PodInfo
+		for _, pod := range workload.Pods {
+			for _, container := range allContainers(pod) {
+				if matchesStatus(container, statuses) {
+					matchingPods = append(matchingPods, pod)
+					break
+				}
+			}
+		}
+		if len(matchingPods) > 0 {
+			workload.Pods = matchingPods
+			filtered = append(filtered, workload)
+		}
+	}
+	return filtered
+}
+
+// matchesStatus reports whether the container's state, or the reason it terminated (e.g. OOMKilled),
+// is one of the given statuses, ignoring case
+func matchesStatus(container types.ContainerInfo, statuses []string) bool {
+	for _, status := range statuses {
+		status = strings.TrimSpace(status)
+		if strings.EqualFold(container.Status, status) ||
+			(container.TerminationReason != "" && strings.EqualFold(container.TerminationReason, status)) {
+			return true
+		}
+	}
+	return false
+}
+
+// filtersContainerType reports whether --container-type restricts the containers shown
+func (f *Formatter) filtersContainerType() bool {
+	return f.options.ContainerType != "" && f.options.ContainerType != "all"
+}
+
+// allContainers returns the init, regular and ephemeral containers of a pod, in that order
+func allContainers(pod types.PodInfo) []types.ContainerInfo {
+	containers := make([]types.ContainerInfo, 0, len(pod.InitContainers)+len(pod.Containers)+len(pod.EphemeralContainers))
+	containers = append(containers, pod.InitContainers...)
+	containers = append(containers, pod.Containers...)
+	return append(containers, pod.EphemeralContainers...)
+}
+
+// containerDisplayName prefixes init and ephemeral container names with their type
+func containerDisplayName(container types.ContainerInfo) string {
+	switch container.Type {
+	case string(types.ContainerTypeInit):
+		return fmt.Sprintf("[init] %s", container.Name)
+	case string(types.ContainerTypeEphemeral):
+		return fmt.Sprintf("[ephemeral] %s", container.Name)
+	default:
+		return container.Name
 	}
-	return containerName == f.options.ContainerName
 }
@@ -1,41 +1,75 @@
 package output
 
 import (
+	"bytes"
+	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/nareshku/kubectl-container-status/pkg/types"
 	"github.com/olekukonko/tablewriter"
 )
 
 func TestCreateProgressBar(t *testing.T) {
-	formatter := &Formatter{
-		options: &types.Options{NoColor: false},
-	}
-
 	tests := []struct {
+		width      int
 		percentage float64
 		expected   string
 	}{
-		{0.0, "░░░░░░░░░░"},
-		{10.0, "▓░░░░░░░░░"},
-		{50.0, "▓▓▓▓▓░░░░░"},
-		{100.0, "▓▓▓▓▓▓▓▓▓▓"},
-		{150.0, "▓▓▓▓▓▓▓▓▓▓"}, // Should cap at 100%
+		{0, 0.0, "░░░░░░░░░░"}, // Unset width falls back to the default of 10
+		{10, 0.0, "░░░░░░░░░░"},
+		{10, 10.0, "▓░░░░░░░░░"},
+		{10, 50.0, "▓▓▓▓▓░░░░░"},
+		{10, 100.0, "▓▓▓▓▓▓▓▓▓▓"},
+		{10, 150.0, "▓▓▓▓▓▓▓▓▓▓"}, // Should cap at 100%
+		{20, 50.0, "▓▓▓▓▓▓▓▓▓▓░░░░░░░░░░"},
+		{20, 75.0, "▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓░░░░░"},
+		{5, 59.0, "▓▓░░░"},
 	}
 
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
+			formatter := &Formatter{
+				options: &types.Options{NoColor: false, BarWidth: tt.width},
+			}
 			result := formatter.createProgressBar(tt.percentage)
 			if result != tt.expected {
-				t.Errorf("percentage %.1f: expected %s, got %s", tt.percentage, tt.expected, result)
+				t.Errorf("width %d, percentage %.1f: expected %s, got %s", tt.width, tt.percentage, tt.expected, result)
 			}
 		})
 	}
 }
 
+func TestUsageColorThresholds(t *testing.T) {
+	tests := []struct {
+		thresholds types.UsageThresholds
+		percentage float64
+		expected   color.Attribute
+	}{
+		{types.UsageThresholds{}, 69, color.FgHiGreen}, // Defaults of 70 and 90
+		{types.UsageThresholds{}, 70, color.FgHiYellow},
+		{types.UsageThresholds{}, 90, color.FgHiRed},
+		{types.UsageThresholds{Warning: 50, Critical: 80}, 60, color.FgHiYellow},
+		{types.UsageThresholds{Warning: 50, Critical: 80}, 85, color.FgHiRed},
+	}
+
+	for _, tt := range tests {
+		f := &Formatter{options: &types.Options{Thresholds: tt.thresholds}}
+		if got := f.usageColor(tt.percentage); got != tt.expected {
+			t.Errorf("thresholds %+v at %.0f%%: expected %v, got %v", tt.thresholds, tt.percentage, tt.expected, got)
+		}
+	}
+
+	f := &Formatter{options: &types.Options{BarWidth: 20}}
+	if got := f.renderMiniProgressBar(50, color.FgHiGreen); len([]rune(got)) != 16 {
+		t.Errorf("expected a 16-segment mini bar for --bar-width 20, got %q", got)
+	}
+}
+
 func TestCreateProgressBarNoColor(t *testing.T) {
 	formatter := &Formatter{
 		options: &types.Options{NoColor: true},
@@ -334,9 +368,8 @@ func TestTerminalWidthHandling(t *testing.T) {
 }
 
 func TestLogsWarning(t *testing.T) {
-	formatter := &Formatter{
-		options: &types.Options{NoColor: false, ShowLogs: true},
-	}
+	var output bytes.Buffer
+	formatter := NewWithWriter(&types.Options{NoColor: false, ShowLogs: true}, &output)
 
 	// Test that printLogsWarning doesn't panic
 	defer func() {
@@ -345,8 +378,10 @@ func TestLogsWarning(t *testing.T) {
 		}
 	}()
 
-	// This will print to stdout but shouldn't cause any errors
 	formatter.printLogsWarning()
+	if !strings.Contains(output.String(), "SHOWING CONTAINER LOGS") {
+		t.Errorf("expected the logs warning box, got:\n%s", output.String())
+	}
 }
 
 func TestServiceAccountDisplay(t *testing.T) {
@@ -393,9 +428,8 @@ func TestServiceAccountDisplay(t *testing.T) {
 }
 
 func TestPodHeaderWithServiceAccount(t *testing.T) {
-	formatter := &Formatter{
-		options: &types.Options{NoColor: false},
-	}
+	var output bytes.Buffer
+	formatter := NewWithWriter(&types.Options{NoColor: false}, &output)
 
 	pod := types.PodInfo{
 		Name:           "test-pod",
@@ -413,14 +447,15 @@ func TestPodHeaderWithServiceAccount(t *testing.T) {
 		}
 	}()
 
-	// This will print to stdout but shouldn't cause any errors
 	formatter.printPodHeader(pod)
+	if !strings.Contains(output.String(), "my-custom-sa") {
+		t.Errorf("expected the service account in the pod header, got:\n%s", output.String())
+	}
 }
 
 func TestPodConditionsDisplay(t *testing.T) {
-	formatter := &Formatter{
-		options: &types.Options{NoColor: false},
-	}
+	var output bytes.Buffer
+	formatter := NewWithWriter(&types.Options{NoColor: false}, &output)
 
 	tests := []struct {
 		name       string
@@ -472,17 +507,18 @@ func TestPodConditionsDisplay(t *testing.T) {
 				}
 			}()
 
+			output.Reset()
 			formatter.printPodConditions(tt.pod)
-			// Note: We can't easily test the output without capturing stdout,
-			// but we can verify the function doesn't panic
+			if shown := strings.Contains(output.String(), "Conditions:"); shown != tt.shouldShow {
+				t.Errorf("expected conditions shown=%v, got:\n%s", tt.shouldShow, output.String())
+			}
 		})
 	}
 }
 
 func TestFailedSchedulingEventPriority(t *testing.T) {
-	formatter := &Formatter{
-		options: &types.Options{NoColor: false},
-	}
+	var output bytes.Buffer
+	formatter := NewWithWriter(&types.Options{NoColor: false}, &output)
 
 	events := []types.EventInfo{
 		{
@@ -512,7 +548,11 @@ func TestFailedSchedulingEventPriority(t *testing.T) {
 		}
 	}()
 
-	formatter.printEvents(events)
+	formatter.printEvents(events, false)
+	printed := output.String()
+	if failed, pulling := strings.Index(printed, "FailedScheduling"), strings.Index(printed, "Pulling"); failed < 0 || failed > pulling {
+		t.Errorf("expected FailedScheduling listed before newer events, got:\n%s", printed)
+	}
 }
 
 func TestWrapSchedulingMessage(t *testing.T) {
@@ -555,9 +595,8 @@ func TestWrapSchedulingMessage(t *testing.T) {
 }
 
 func TestPodStatusDisplay(t *testing.T) {
-	formatter := &Formatter{
-		options: &types.Options{NoColor: false},
-	}
+	var output bytes.Buffer
+	formatter := NewWithWriter(&types.Options{NoColor: false}, &output)
 
 	tests := []struct {
 		name   string
@@ -587,7 +626,11 @@ func TestPodStatusDisplay(t *testing.T) {
 				}
 			}()
 
+			output.Reset()
 			formatter.printPodHeader(pod)
+			if !strings.Contains(output.String(), tt.status) {
+				t.Errorf("expected status %s in the pod header, got:\n%s", tt.status, output.String())
+			}
 		})
 	}
 }
@@ -619,3 +662,1703 @@ func TestGetPodStatusColor(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatProbeTarget(t *testing.T) {
+	formatter := &Formatter{
+		options: &types.Options{NoColor: true},
+	}
+
+	httpsProbe := types.ProbeDetails{
+		Type:    "HTTP",
+		Path:    "/healthz",
+		Port:    "8443",
+		Scheme:  "HTTPS",
+		Host:    "app.internal",
+		Headers: []string{"X-Probe: kubelet", "Authorization: ***"},
+	}
+
+	tests := []struct {
+		name     string
+		probe    types.ProbeDetails
+		wide     bool
+		expected string
+	}{
+		{
+			name:     "HTTP probe",
+			probe:    types.ProbeDetails{Type: "HTTP", Path: "/health", Port: "8080"},
+			expected: "HTTP /health on port 8080",
+		},
+		{
+			name:     "HTTPS probe",
+			probe:    httpsProbe,
+			expected: "HTTP /healthz on port 8443",
+		},
+		{
+			name:     "HTTPS probe with --wide-probes",
+			probe:    httpsProbe,
+			wide:     true,
+			expected: "HTTPS /healthz on port 8443 host app.internal [X-Probe: kubelet, Authorization: ***]",
+		},
+		{
+			name:     "TCP probe",
+			probe:    types.ProbeDetails{Type: "TCP", Port: "5432"},
+			expected: "TCP on port 5432",
+		},
+		{
+			name:     "Exec probe",
+			probe:    types.ProbeDetails{Type: "Exec"},
+			expected: "Exec",
+		},
+		{
+			name:     "gRPC probe with service",
+			probe:    types.ProbeDetails{Type: "gRPC", Port: "9090", Service: "grpc.health.v1.Health"},
+			expected: "gRPC on port 9090 (service: grpc.health.v1.Health)",
+		},
+		{
+			name:     "gRPC probe without service",
+			probe:    types.ProbeDetails{Type: "gRPC", Port: "9090"},
+			expected: "gRPC on port 9090",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter.options.WideProbes = tt.wide
+			result := formatter.formatProbeTarget(tt.probe)
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSummaryRow(t *testing.T) {
+	formatter := New(&types.Options{NoColor: true})
+
+	workload := types.WorkloadInfo{
+		Name:   "api",
+		Kind:   "Deployment",
+		Health: types.HealthStatus{Level: string(types.HealthLevelDegraded)},
+		Pods: []types.PodInfo{
+			{
+				Name:       "api-1",
+				Containers: []types.ContainerInfo{{Ready: true, RestartCount: 2}},
+				Metrics:    &types.PodMetrics{CPUUsage: "250m", MemoryUsage: "128Mi", CPUUsageMilli: 250, MemoryUsageBytes: 128 * 1024 * 1024},
+			},
+			{
+				Name:           "api-2",
+				InitContainers: []types.ContainerInfo{{RestartCount: 1}},
+				Containers:     []types.ContainerInfo{{Ready: false}},
+				Metrics:        &types.PodMetrics{CPUUsage: "750m", MemoryUsage: "384Mi", CPUUsageMilli: 750, MemoryUsageBytes: 384 * 1024 * 1024},
+			},
+		},
+	}
+
+	row := formatter.summaryRow(workload)
+	expected := []string{"deployment/api", "1/2", "🟡 Degraded", "3", "1.0", "512Mi"}
+	if len(row) != len(expected) {
+		t.Fatalf("expected %d columns, got %d: %v", len(expected), len(row), row)
+	}
+	for i := range expected {
+		if row[i] != expected[i] {
+			t.Errorf("column %d: expected %q, got %q", i, expected[i], row[i])
+		}
+	}
+
+	// The exact readings are summed, not the rounded strings
+	formatter.options.RawMetrics = true
+	workload.Pods[1].Metrics = &types.PodMetrics{CPUUsage: "1.2", MemoryUsage: "384Mi", CPUUsageMilli: 1234, MemoryUsageBytes: 384*1024*1024 + 512}
+	if row := formatter.summaryRow(workload); row[4] != "1484m" || row[5] != "536871424" {
+		t.Errorf("expected exact usage 1484m/536871424, got %q/%q", row[4], row[5])
+	}
+}
+
+func TestIsNoisyAnnotation(t *testing.T) {
+	tests := []struct {
+		key   string
+		noisy bool
+	}{
+		{"kubectl.kubernetes.io/last-applied-configuration", true},
+		{"checksum/config", true},
+		{"checksum/secret", true},
+		{"kubernetes.io/config.hash", true},
+		{"prometheus.io/scrape", false},
+		{"kubectl.kubernetes.io/restartedAt", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := isNoisyAnnotation(tt.key); got != tt.noisy {
+				t.Errorf("expected isNoisyAnnotation(%q) to be %v, got %v", tt.key, tt.noisy, got)
+			}
+		})
+	}
+}
+
+func TestFormatVolumeMount(t *testing.T) {
+	formatter := &Formatter{
+		options: &types.Options{NoColor: true},
+	}
+
+	tests := []struct {
+		name     string
+		volume   types.VolumeInfo
+		expected string
+	}{
+		{
+			name:     "read-write mount",
+			volume:   types.VolumeInfo{MountPath: "/data", Details: "pvc/foo", VolumeType: "PVC"},
+			expected: "/data → pvc/foo (PVC)",
+		},
+		{
+			name:     "read-only mount with subPath",
+			volume:   types.VolumeInfo{MountPath: "/etc/app", Details: "configmap/app", VolumeType: "ConfigMap", ReadOnly: true, SubPath: "conf"},
+			expected: "/etc/app → configmap/app (ConfigMap) [ro, subPath=conf]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatter.formatVolumeMount(tt.volume); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPodFinalizersDisplay(t *testing.T) {
+	var output bytes.Buffer
+	formatter := NewWithWriter(&types.Options{NoColor: true}, &output)
+
+	pods := []types.PodInfo{
+		{Name: "running-no-finalizers", Status: "Running"},
+		{Name: "running-with-finalizers", Status: "Running", Finalizers: []string{"example.com/protect"}},
+		{
+			Name:                   "terminating",
+			Status:                 "Terminating",
+			TerminatingFor:         5 * time.Minute,
+			TerminationGracePeriod: 30 * time.Second,
+			Finalizers:             []string{"kubernetes.io/pvc-protection"},
+		},
+	}
+
+	for _, pod := range pods {
+		t.Run(pod.Name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("printPodFinalizers panicked: %v", r)
+				}
+			}()
+
+			output.Reset()
+			formatter.printPodFinalizers(pod)
+			for _, finalizer := range pod.Finalizers {
+				if !strings.Contains(output.String(), finalizer) {
+					t.Errorf("expected finalizer %s to be listed, got:\n%s", finalizer, output.String())
+				}
+			}
+		})
+	}
+}
+
+func TestFormatTimeAndAge(t *testing.T) {
+	fixed := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	relative := &Formatter{options: &types.Options{}}
+	if got := relative.formatTime(time.Now().Add(-5 * time.Minute)); got != "5m ago" {
+		t.Errorf("expected relative time '5m ago', got %q", got)
+	}
+	if got := relative.formatAge(2 * time.Hour); got != "2h" {
+		t.Errorf("expected relative age '2h', got %q", got)
+	}
+
+	absolute := &Formatter{options: &types.Options{Timestamps: true}}
+	if got := absolute.formatTime(fixed); got != fixed.Local().Format(time.RFC3339) {
+		t.Errorf("expected absolute timestamp %q, got %q", fixed.Local().Format(time.RFC3339), got)
+	}
+	if got := absolute.formatAge(time.Hour); strings.HasSuffix(got, "ago") || !strings.Contains(got, "T") {
+		t.Errorf("expected absolute timestamp for age, got %q", got)
+	}
+}
+
+func TestFormatTimestampTimezone(t *testing.T) {
+	fixed := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	utc := New(&types.Options{Timestamps: true, UTC: true})
+	if got := utc.formatTime(fixed); got != "2024-03-01T12:30:00Z" {
+		t.Errorf("expected UTC timestamp, got %q", got)
+	}
+
+	tokyo := New(&types.Options{Timestamps: true, Timezone: "Asia/Tokyo"})
+	if got := tokyo.formatTime(fixed); got != "2024-03-01T21:30:00+09:00" {
+		t.Errorf("expected Asia/Tokyo timestamp, got %q", got)
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	if got := truncateMessage("manifest unknown", 60); got != "manifest unknown" {
+		t.Errorf("short message should be unchanged, got %q", got)
+	}
+	if got := truncateMessage("line one\n  line two", 60); got != "line one line two" {
+		t.Errorf("expected message collapsed onto one line, got %q", got)
+	}
+
+	long := "pull access denied for myrepo/app, repository does not exist or may require 'docker login'"
+	got := truncateMessage(long, 40)
+	if len([]rune(got)) != 40 || !strings.HasSuffix(got, "...") {
+		t.Errorf("expected 40-rune truncated message ending in ..., got %q", got)
+	}
+}
+
+func TestFormatPriority(t *testing.T) {
+	high := int32(1000000)
+	zero := int32(0)
+
+	tests := []struct {
+		name     string
+		pod      types.PodInfo
+		expected string
+	}{
+		{"no priority", types.PodInfo{}, ""},
+		{"default zero priority", types.PodInfo{Priority: &zero}, ""},
+		{"class and value", types.PodInfo{PriorityClassName: "high-priority", Priority: &high}, "high-priority (1000000)"},
+		{"class only", types.PodInfo{PriorityClassName: "system-node-critical"}, "system-node-critical"},
+		{"value only", types.PodInfo{Priority: &high}, "1000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatPriority(tt.pod); got != tt.expected {
+				t.Errorf("formatPriority() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatRevisionDistribution(t *testing.T) {
+	if got := formatRevisionDistribution([]types.PodInfo{{Name: "standalone"}}); got != "" {
+		t.Errorf("expected no revisions for unlabeled pods, got %q", got)
+	}
+
+	pods := []types.PodInfo{
+		{Name: "web-1", Revision: "def456"},
+		{Name: "web-2", Revision: "abc123"},
+		{Name: "web-3", Revision: "abc123"},
+		{Name: "web-4", Revision: "abc123"},
+		{Name: "web-5", Revision: "def456"},
+		{Name: "web-6", Revision: "0ff1ce"},
+	}
+	expected := "3 on abc123, 2 on def456, 1 on 0ff1ce"
+	if got := formatRevisionDistribution(pods); got != expected {
+		t.Errorf("formatRevisionDistribution() = %q, want %q", got, expected)
+	}
+}
+
+func TestLimitEvents(t *testing.T) {
+	events := make([]types.EventInfo, 15)
+
+	tests := []struct {
+		name          string
+		maxEvents     int
+		expectedShown int
+		expectedMore  int
+	}{
+		{"default cap", 10, 10, 5},
+		{"cap above total", 20, 15, 0},
+		{"cap equal to total", 15, 15, 0},
+		{"unlimited", 0, 15, 0},
+		{"single event", 1, 1, 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shown, hidden := limitEvents(events, tt.maxEvents)
+			if len(shown) != tt.expectedShown {
+				t.Errorf("expected %d events shown, got %d", tt.expectedShown, len(shown))
+			}
+			if hidden != tt.expectedMore {
+				t.Errorf("expected %d more events, got %d", tt.expectedMore, hidden)
+			}
+			if len(shown)+hidden != len(events) {
+				t.Errorf("shown (%d) + hidden (%d) should equal total (%d)", len(shown), hidden, len(events))
+			}
+		})
+	}
+}
+
+func TestAggregateEvents(t *testing.T) {
+	now := time.Now()
+	backOff := "Back-off restarting failed container app in pod web-1"
+
+	events := []types.EventInfo{
+		{Time: now.Add(-10 * time.Minute), FirstSeen: now.Add(-1 * time.Hour), Count: 40, Type: "Warning", Reason: "BackOff", Message: backOff, PodName: "web-1"},
+		{Time: now.Add(-2 * time.Minute), Count: 7, Type: "Warning", Reason: "BackOff", Message: backOff, PodName: "web-1"},
+		{Time: now.Add(-5 * time.Minute), Type: "Normal", Reason: "Pulled", Message: "Container image pulled", PodName: "web-1"},
+		{Time: now.Add(-3 * time.Minute), Type: "Warning", Reason: "BackOff", Message: backOff, PodName: "web-2"},
+	}
+
+	aggregated := aggregateEvents(events)
+	if len(aggregated) != 3 {
+		t.Fatalf("expected 3 aggregated events, got %d", len(aggregated))
+	}
+
+	backOffEvent := aggregated[0]
+	if backOffEvent.Count != 47 {
+		t.Errorf("expected count 47, got %d", backOffEvent.Count)
+	}
+	if !backOffEvent.Time.Equal(now.Add(-2 * time.Minute)) {
+		t.Errorf("expected last seen to be the most recent occurrence, got %v", backOffEvent.Time)
+	}
+	if !backOffEvent.FirstSeen.Equal(now.Add(-1 * time.Hour)) {
+		t.Errorf("expected first seen to be the earliest occurrence, got %v", backOffEvent.FirstSeen)
+	}
+
+	if aggregated[1].Count != 1 || aggregated[2].Count != 1 {
+		t.Errorf("expected single events to have count 1, got %d and %d", aggregated[1].Count, aggregated[2].Count)
+	}
+
+	f := &Formatter{options: &types.Options{}}
+	if got := f.formatEventOccurrences(backOffEvent); got != " ×47, first seen 1h ago" {
+		t.Errorf("unexpected occurrence text %q", got)
+	}
+	if got := f.formatEventOccurrences(aggregated[1]); got != "" {
+		t.Errorf("expected no occurrence text for a single event, got %q", got)
+	}
+}
+
+func TestMissingUsagePlaceholder(t *testing.T) {
+	options := &types.Options{NoColor: true}
+	f := New(options)
+	workload := types.WorkloadInfo{Kind: "Deployment", Name: "web", Pods: []types.PodInfo{{Name: "web-1"}}}
+
+	if row := f.summaryRow(workload); row[4] != "-" || row[5] != "-" {
+		t.Errorf("expected '-' usage without metrics, got %q/%q", row[4], row[5])
+	}
+
+	workload.MetricsUnavailable = true
+	if row := f.summaryRow(workload); row[4] != "n/a" || row[5] != "n/a" {
+		t.Errorf("expected 'n/a' usage when the metrics API is unavailable, got %q/%q", row[4], row[5])
+	}
+}
+
+func TestCalculateWorkloadTotals(t *testing.T) {
+	f := &Formatter{options: &types.Options{}}
+
+	const Mi = 1024 * 1024
+	container := func(cpuReq, cpuLim string, cpuUse int64, memReq, memLim string, memUse int64) types.ContainerInfo {
+		return types.ContainerInfo{
+			Name: "app",
+			Resources: types.ResourceInfo{
+				CPURequest: cpuReq, CPULimit: cpuLim, CPUUsage: formatMilliCPU(cpuUse), CPUUsageMilli: cpuUse,
+				MemRequest: memReq, MemLimit: memLim, MemUsage: formatBytes(memUse), MemUsageBytes: memUse,
+			},
+		}
+	}
+
+	workload := types.WorkloadInfo{
+		Pods: []types.PodInfo{
+			{Containers: []types.ContainerInfo{container("500m", "1", 300, "1Gi", "2Gi", 512*Mi)}},
+			// Displayed as "1.2", but the exact reading is what's summed
+			{Containers: []types.ContainerInfo{container("1.5", "2", 1249, "1Gi", "2Gi", 1536*Mi)}},
+			{Containers: []types.ContainerInfo{container("250m", "", 100, "256Mi", "", 128*Mi)}},
+		},
+	}
+
+	totals := f.calculateWorkloadTotals(workload)
+	if totals.CPURequest != 2250 || totals.CPULimit != 3000 || totals.CPUUsage != 1649 {
+		t.Errorf("unexpected CPU totals: %+v", totals)
+	}
+	if totals.Unbounded != 1 {
+		t.Errorf("expected 1 unbounded container, got %d", totals.Unbounded)
+	}
+
+	expected := "CPU requests 2.2 / limits 3.0, usage 1.6; Mem requests 2.2Gi / limits 4.0Gi, usage 2.1Gi (1 containers unbounded)"
+	if got := totals.String(); got != expected {
+		t.Errorf("String() = %q, want %q", got, expected)
+	}
+}
+
+func TestFormatEfficiency(t *testing.T) {
+	f := New(&types.Options{NoColor: true})
+
+	tests := []struct {
+		percentage float64
+		expected   string
+	}{
+		{5, "5% of request (over-provisioned)"},
+		{10, "10% of request"},
+		{100, "100% of request"},
+		{150, "150% of request (under-provisioned)"},
+	}
+
+	for _, tt := range tests {
+		if got := f.formatEfficiency(tt.percentage); got != tt.expected {
+			t.Errorf("formatEfficiency(%v) = %q, want %q", tt.percentage, got, tt.expected)
+		}
+	}
+}
+
+func TestEfficiencyWithoutUsage(t *testing.T) {
+	requested := types.ResourceInfo{CPURequest: "100m", MemRequest: "128Mi", CPUUsage: "0m", MemUsage: "0Mi"}
+	measured := requested
+	measured.HasCPUUsage, measured.HasMemUsage = true, true
+	measured.CPURequestPercentage, measured.MemRequestPercentage = 5, 50
+
+	pod := func(name string, resources types.ResourceInfo) types.PodInfo {
+		return types.PodInfo{Name: name, Status: "Running", Health: types.HealthStatus{Level: string(types.HealthLevelHealthy)},
+			Containers: []types.ContainerInfo{{Name: "app", Type: string(types.ContainerTypeStandard), Status: "Running", Ready: true, Resources: resources}}}
+	}
+	tests := []struct {
+		name      string
+		pods      []types.PodInfo
+		expected  string // Expected efficiency text, empty when there should be none
+		container string // Expected per-container efficiency line, empty when there should be none
+	}{
+		{"no metrics", []types.PodInfo{pod("web-1", requested), pod("web-2", requested)}, "", ""},
+		// Pods without a reading yet don't drag the average down
+		{"one pod measured", []types.PodInfo{pod("web-1", measured), pod("web-2", requested)}, "CPU avg 5% of request (over-provisioned), Mem avg 50% of request", "CPU 5% of request (over-provisioned), Mem 50% of request"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			f := NewWithWriter(&types.Options{NoColor: true}, &output)
+			f.printWorkloadSummary(types.WorkloadInfo{Name: "web", Kind: "Deployment", Pods: tt.pods})
+			if tt.expected == "" && strings.Contains(output.String(), "Efficiency") {
+				t.Errorf("expected no efficiency without usage, got:\n%s", output.String())
+			}
+			if tt.expected != "" && !strings.Contains(output.String(), "Efficiency: "+tt.expected) {
+				t.Errorf("expected efficiency %q, got:\n%s", tt.expected, output.String())
+			}
+
+			output.Reset()
+			f.printEfficiency(tt.pods[0].Containers[0].Resources, false)
+			if tt.container == "" && output.Len() > 0 {
+				t.Errorf("expected no container efficiency without usage, got %q", output.String())
+			}
+			if tt.container != "" && !strings.Contains(output.String(), tt.container) {
+				t.Errorf("expected container efficiency %q, got %q", tt.container, output.String())
+			}
+		})
+	}
+}
+
+func TestReadyAndRestarts(t *testing.T) {
+	f := New(&types.Options{NoColor: true})
+	workload := types.WorkloadInfo{
+		Pods: []types.PodInfo{
+			{Name: "api-1", Containers: []types.ContainerInfo{{Name: "app", Ready: true, RestartCount: 1}}},
+			{Name: "api-2", Containers: []types.ContainerInfo{{Name: "app", Ready: false, RestartCount: 2}}},
+		},
+	}
+
+	ready, restarts := f.readyAndRestarts(workload)
+	if ready != 1 || restarts != 3 {
+		t.Errorf("expected 1 ready pod and 3 restarts, got %d and %d", ready, restarts)
+	}
+}
+
+func TestTruncateMiddle(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    int
+		expected string
+	}{
+		{"web-1", 30, "web-1"},
+		{"checkout-service-canary-7d9f8c6b5d-x2k4q", 20, "checkout-…6b5d-x2k4q"},
+		{"ab", 1, "…"},
+	}
+
+	for _, tt := range tests {
+		got := truncateMiddle(tt.name, tt.width)
+		if got != tt.expected {
+			t.Errorf("truncateMiddle(%q, %d) = %q, want %q", tt.name, tt.width, got, tt.expected)
+		}
+		if len([]rune(got)) > tt.width {
+			t.Errorf("truncateMiddle(%q, %d) = %q is wider than %d", tt.name, tt.width, got, tt.width)
+		}
+	}
+}
+
+func TestCompactWorkloadTableLongNames(t *testing.T) {
+	f := New(&types.Options{NoColor: true, Compact: true})
+	if !f.isCompact() {
+		t.Fatal("expected --compact to force the compact table")
+	}
+
+	longName := strings.Repeat("very-long-statefulset-name-", 10) + "0"
+	workload := types.WorkloadInfo{
+		Kind: "StatefulSet",
+		Name: longName,
+		Pods: []types.PodInfo{
+			{Name: longName, NodeName: strings.Repeat("node-", 40), Health: types.HealthStatus{Level: string(types.HealthLevelCritical)}},
+			{Name: "", Containers: []types.ContainerInfo{{Name: "app", Ready: true}}},
+		},
+	}
+
+	// Must not panic on very long or empty names
+	f.printWorkloadTable(workload)
+}
+
+func TestFormatJobInfo(t *testing.T) {
+	f := New(&types.Options{NoColor: true})
+	deadline := int64(600)
+
+	tests := []struct {
+		name     string
+		job      types.JobInfo
+		expected string
+	}{
+		{
+			name:     "retrying job with deadline",
+			job:      types.JobInfo{Completions: 3, Parallelism: 2, Succeeded: 1, Failed: 2, BackoffLimit: 6, RestartPolicy: "Never", ActiveDeadlineSeconds: &deadline},
+			expected: "completions 1/3, parallelism 2, failed 2, backoffLimit 6 (4 retries left), restartPolicy Never, activeDeadline 10m",
+		},
+		{
+			name:     "backoff limit exhausted",
+			job:      types.JobInfo{Completions: 1, Parallelism: 1, Failed: 4, BackoffLimit: 3, RestartPolicy: "OnFailure"},
+			expected: "completions 0/1, parallelism 1, failed 4, backoffLimit 3 (no retries left), restartPolicy OnFailure",
+		},
+		{
+			name:     "indexed job",
+			job:      types.JobInfo{Completions: 1000, Parallelism: 50, Succeeded: 990, Failed: 2, BackoffLimit: 6, RestartPolicy: "Never", Indexed: true},
+			expected: "completions 990/1000, parallelism 50, failed 2, backoffLimit 6 (4 retries left), completionMode Indexed, restartPolicy Never",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.formatJobInfo(tt.job); got != tt.expected {
+				t.Errorf("formatJobInfo() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConditionDetails(t *testing.T) {
+	f := New(&types.Options{NoColor: true})
+
+	tests := []struct {
+		name      string
+		condition types.PodCondition
+		expected  string
+	}{
+		{"reason and duration", types.PodCondition{Type: "Ready", Status: "False", Reason: "ContainersNotReady", LastTransitionTime: time.Now().Add(-12 * time.Minute)}, "ContainersNotReady, False for 12m"},
+		{"duration only", types.PodCondition{Type: "Ready", Status: "False", LastTransitionTime: time.Now().Add(-2 * time.Hour)}, "False for 2h"},
+		{"reason only", types.PodCondition{Type: "PodScheduled", Status: "False", Reason: "Unschedulable"}, "Unschedulable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.conditionDetails(tt.condition); got != tt.expected {
+				t.Errorf("conditionDetails() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestContainerTypeFilter(t *testing.T) {
+	pod := types.PodInfo{
+		Name:                "web-1",
+		InitContainers:      []types.ContainerInfo{{Name: "migrate", Type: string(types.ContainerTypeInit)}},
+		Containers:          []types.ContainerInfo{{Name: "app", Type: string(types.ContainerTypeStandard)}, {Name: "proxy", Type: string(types.ContainerTypeStandard)}},
+		EphemeralContainers: []types.ContainerInfo{{Name: "debugger", Type: string(types.ContainerTypeEphemeral)}},
+	}
+
+	tests := []struct {
+		containerType string
+		containerName string
+		expected      []string
+	}{
+		{"all", "", []string{"migrate", "app", "proxy", "debugger"}},
+		{"", "", []string{"migrate", "app", "proxy", "debugger"}},
+		{"init", "", []string{"migrate"}},
+		{"standard", "", []string{"app", "proxy"}},
+		{"ephemeral", "", []string{"debugger"}},
+		{"standard", "proxy", []string{"proxy"}},
+		{"init", "app", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.containerType+"/"+tt.containerName, func(t *testing.T) {
+			f := New(&types.Options{NoColor: true, ContainerType: tt.containerType, ContainerName: tt.containerName})
+
+			var names []string
+			for _, container := range f.filterContainers(allContainers(pod)) {
+				names = append(names, container.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected containers %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestContainerDisplayName(t *testing.T) {
+	tests := []struct {
+		container types.ContainerInfo
+		expected  string
+	}{
+		{types.ContainerInfo{Name: "migrate", Type: string(types.ContainerTypeInit)}, "[init] migrate"},
+		{types.ContainerInfo{Name: "app", Type: string(types.ContainerTypeStandard)}, "app"},
+		{types.ContainerInfo{Name: "debugger", Type: string(types.ContainerTypeEphemeral)}, "[ephemeral] debugger"},
+	}
+
+	for _, tt := range tests {
+		if got := containerDisplayName(tt.container); got != tt.expected {
+			t.Errorf("containerDisplayName(%q) = %q, want %q", tt.container.Name, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatPDB(t *testing.T) {
+	f := New(&types.Options{NoColor: true})
+
+	if got := f.formatPDB(types.PDBInfo{Name: "web", DisruptionsAllowed: 0, CurrentHealthy: 3, ExpectedPods: 3}); got != "web 0 disruptions allowed (3/3 healthy)" {
+		t.Errorf("unexpected PDB line: %q", got)
+	}
+	if got := f.formatPDB(types.PDBInfo{Name: "web", DisruptionsAllowed: 1, CurrentHealthy: 3, ExpectedPods: 3}); got != "web 1 disruption allowed (3/3 healthy)" {
+		t.Errorf("unexpected PDB line: %q", got)
+	}
+}
+
+func TestRawMetricsValues(t *testing.T) {
+	rounded := &Formatter{options: &types.Options{}}
+	if got := rounded.formatCPUValue("0.25", 251); got != "0.25" {
+		t.Errorf("expected rounded CPU '0.25', got %q", got)
+	}
+	if got := rounded.formatMemoryValue("128Mi", 134217729); got != "128Mi" {
+		t.Errorf("expected rounded memory '128Mi', got %q", got)
+	}
+
+	raw := &Formatter{options: &types.Options{RawMetrics: true}}
+	if got := raw.formatCPUValue("0.25", 251); got != "251m" {
+		t.Errorf("expected raw CPU '251m', got %q", got)
+	}
+	if got := raw.formatMemoryValue("128Mi", 134217729); got != "134217729" {
+		t.Errorf("expected raw memory '134217729', got %q", got)
+	}
+}
+
+func TestFormatContainerHealth(t *testing.T) {
+	f := New(&types.Options{NoColor: true})
+
+	if got := f.formatContainerHealth(types.HealthStatus{}); got != "" {
+		t.Errorf("expected no health line before analysis, got %q", got)
+	}
+	got := f.formatContainerHealth(types.HealthStatus{Level: "Degraded", Reason: "high memory usage", Score: 60})
+	if !strings.Contains(got, "Degraded - high memory usage") {
+		t.Errorf("expected level and reason, got %q", got)
+	}
+	if got := f.formatContainerHealth(types.HealthStatus{Level: "Healthy", Score: 100}); strings.Contains(got, " - ") {
+		t.Errorf("expected no reason for a healthy container, got %q", got)
+	}
+}
+
+func TestSortEvents(t *testing.T) {
+	now := time.Now()
+	events := func() []types.EventInfo {
+		return []types.EventInfo{
+			{Type: "Normal", Reason: "Pulled", PodName: "web-2", Time: now},
+			{Type: "Warning", Reason: "BackOff", PodName: "web-2", Time: now.Add(-time.Minute)},
+			{Type: "Normal", Reason: "Created", PodName: "web-1", Time: now},
+			{Type: "Warning", Reason: "FailedScheduling", PodName: "web-3", Time: now.Add(-time.Hour)},
+			{Type: "Warning", Reason: "BackOff", PodName: "web-1", Time: now.Add(-time.Minute)},
+		}
+	}
+	order := func(events []types.EventInfo) []string {
+		var result []string
+		for _, event := range events {
+			result = append(result, event.Reason+"/"+event.PodName)
+		}
+		return result
+	}
+
+	tests := []struct {
+		mode     string
+		expected []string
+	}{
+		{"severity", []string{"FailedScheduling/web-3", "BackOff/web-1", "BackOff/web-2", "Created/web-1", "Pulled/web-2"}},
+		{"", []string{"FailedScheduling/web-3", "BackOff/web-1", "BackOff/web-2", "Created/web-1", "Pulled/web-2"}},
+		{"time", []string{"Created/web-1", "Pulled/web-2", "BackOff/web-1", "BackOff/web-2", "FailedScheduling/web-3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			// Every input order must produce the same result when timestamps tie
+			forward := order(sortEvents(events(), tt.mode))
+			reversed := events()
+			for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+				reversed[i], reversed[j] = reversed[j], reversed[i]
+			}
+			backward := order(sortEvents(reversed, tt.mode))
+
+			if strings.Join(forward, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, forward)
+			}
+			if strings.Join(backward, ",") != strings.Join(forward, ",") {
+				t.Errorf("ordering depends on input order: %v vs %v", forward, backward)
+			}
+		})
+	}
+}
+
+func TestContainerImageVariants(t *testing.T) {
+	f := New(&types.Options{})
+	pods := []types.PodInfo{
+		{Name: "web-old", Containers: []types.ContainerInfo{{Name: "app", Image: "web:1"}, {Name: "proxy", Image: "envoy:1"}}},
+		{Name: "web-new", Containers: []types.ContainerInfo{{Name: "app", Image: "web:2"}, {Name: "proxy", Image: "envoy:1"}}},
+		{Name: "web-new-2", Containers: []types.ContainerInfo{{Name: "app", Image: "web:2"}}},
+	}
+
+	variants := f.containerImageVariants(pods)
+	if len(variants["app"]) != 2 || len(variants["proxy"]) != 1 {
+		t.Fatalf("unexpected image variants: %v", variants)
+	}
+
+	tests := []struct {
+		name     string
+		image    string
+		expected string
+	}{
+		{"app", "web:1", "app (1 of 2 image variants)"},
+		{"app", "web:2", "app (2 of 2 image variants)"},
+		{"proxy", "envoy:1", "proxy"},
+	}
+	for _, tt := range tests {
+		if got := summaryContainerKey(tt.name, tt.image, variants[tt.name]); got != tt.expected {
+			t.Errorf("summaryContainerKey(%q, %q) = %q, expected %q", tt.name, tt.image, got, tt.expected)
+		}
+	}
+}
+
+func TestLabelColumns(t *testing.T) {
+	keys := []string{"version", "tier"}
+	pods := []types.PodInfo{
+		{Name: "api-1", Labels: map[string]string{"version": "v2-canary", "tier": "backend"}},
+		{Name: "api-2", Labels: map[string]string{"version": "v1"}},
+	}
+
+	headers := labelColumnHeaders(keys)
+	if strings.Join(headers, ",") != "VERSION,TIER" {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+	if got := labelColumnValues(pods[0], keys); strings.Join(got, ",") != "v2-canary,backend" {
+		t.Errorf("unexpected values: %v", got)
+	}
+	if got := labelColumnValues(pods[1], keys); strings.Join(got, ",") != "v1,<none>" {
+		t.Errorf("expected <none> for a missing label, got %v", got)
+	}
+
+	// "v2-canary" and "backend" are the widest cells, plus padding for each column
+	if got := labelColumnsWidth(pods, keys); got != len("v2-canary")+3+len("backend")+3 {
+		t.Errorf("unexpected label columns width: %d", got)
+	}
+	if got := labelColumnsWidth(pods, nil); got != 0 {
+		t.Errorf("expected no extra width without label columns, got %d", got)
+	}
+}
+
+func TestFormatSecurity(t *testing.T) {
+	f := New(&types.Options{NoColor: true})
+	uid := func(u int64) *int64 { return &u }
+	nonRoot := true
+
+	tests := []struct {
+		name     string
+		security types.SecurityInfo
+		expected string
+	}{
+		{
+			name:     "hardened",
+			security: types.SecurityInfo{RunAsUser: uid(1000), RunAsNonRoot: &nonRoot, ReadOnlyRootFilesystem: true, CapabilitiesDropped: []string{"ALL"}},
+			expected: "runAsUser: 1000, runAsNonRoot: true, readOnlyRootFilesystem: true, capabilities: -ALL",
+		},
+		{
+			name:     "privileged root",
+			security: types.SecurityInfo{RunAsUser: uid(0), Privileged: true, CapabilitiesAdded: []string{"SYS_ADMIN"}},
+			expected: "runAsUser: 0 (root), privileged, readOnlyRootFilesystem: false, capabilities: +SYS_ADMIN",
+		},
+		{
+			name:     "unset",
+			security: types.SecurityInfo{},
+			expected: "runAsUser: image default, readOnlyRootFilesystem: false",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.formatSecurity(tt.security); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestProbeFailureCounts(t *testing.T) {
+	f := New(&types.Options{})
+	passing := types.ProbeDetails{Configured: true, Passing: true}
+	failing := types.ProbeDetails{Configured: true, Passing: false}
+	// Liveness failures are only known from events, the probe itself is never reported as failing
+	livenessFailed := types.ProbeDetails{Configured: true, Passing: true, FailureCount: 2}
+	pod := func(name string, probes ...types.ProbeInfo) types.PodInfo {
+		pod := types.PodInfo{Name: name}
+		for _, p := range probes {
+			pod.Containers = append(pod.Containers, types.ContainerInfo{Name: "app", Probes: p})
+		}
+		return pod
+	}
+
+	pods := []types.PodInfo{
+		pod("healthy", types.ProbeInfo{Readiness: passing, Liveness: passing}),
+		pod("not-ready", types.ProbeInfo{Readiness: failing, Liveness: passing}),
+		// Two failing containers in one pod still count the pod once
+		pod("both", types.ProbeInfo{Readiness: failing, Liveness: livenessFailed}, types.ProbeInfo{Readiness: failing}),
+		pod("starting", types.ProbeInfo{Startup: failing}),
+		pod("no-probes", types.ProbeInfo{}),
+	}
+
+	counts := f.countProbeFailures(pods)
+	if counts != (probeFailureCounts{Readiness: 2, Liveness: 1, Startup: 1}) {
+		t.Errorf("unexpected probe failure counts: %+v", counts)
+	}
+
+	tests := []struct {
+		counts   probeFailureCounts
+		expected string
+	}{
+		{counts, "2 pods with readiness failures, 1 with liveness failures, 1 with startup failures"},
+		{probeFailureCounts{Liveness: 1}, "1 pod with liveness failures"},
+		{probeFailureCounts{}, ""},
+	}
+	for _, tt := range tests {
+		if got := formatProbeFailures(tt.counts); got != tt.expected {
+			t.Errorf("formatProbeFailures(%+v) = %q, expected %q", tt.counts, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatExitCode(t *testing.T) {
+	tests := []struct {
+		exitCode int32
+		signal   int32
+		expected string
+	}{
+		{0, 0, "0"},
+		{1, 0, "1"},
+		{128, 0, "128"},
+		{137, 0, "137 (SIGKILL)"},
+		{143, 0, "143 (SIGTERM)"},
+		{139, 0, "139 (SIGSEGV)"},
+		{170, 0, "170 (signal 42)"},
+		{192, 0, "192 (signal 64)"},
+		{193, 0, "193"},
+		{255, 0, "255"},
+		{0, 9, "0 (SIGKILL)"},
+		{2, 15, "2 (SIGTERM)"},
+	}
+
+	for _, tt := range tests {
+		if got := formatExitCode(tt.exitCode, tt.signal); got != tt.expected {
+			t.Errorf("formatExitCode(%d, %d) = %q, expected %q", tt.exitCode, tt.signal, got, tt.expected)
+		}
+	}
+}
+
+func TestLastExitLine(t *testing.T) {
+	exitCode := func(code int32) *int32 { return &code }
+	tests := []struct {
+		name      string
+		container types.ContainerInfo
+		expected  string
+	}{
+		{"with reason", types.ContainerInfo{Name: "app", Status: "Terminated", TerminationReason: "OOMKilled", ExitCode: exitCode(137)}, "• Last Exit:   OOMKilled, exit 137 (SIGKILL)\n"},
+		{"without reason", types.ContainerInfo{Name: "app", Status: "Terminated", ExitCode: exitCode(255)}, "• Last Exit:   exit 255\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			NewWithWriter(&types.Options{NoColor: true}, &output).printContainerDetails(tt.container, false)
+			if !strings.Contains(output.String(), tt.expected) {
+				t.Errorf("expected %q, got:\n%s", tt.expected, output.String())
+			}
+		})
+	}
+}
+
+func TestPodNames(t *testing.T) {
+	workloads := []types.WorkloadInfo{
+		{Name: "api", Pods: []types.PodInfo{{Name: "api-1", Namespace: "prod"}, {Name: "api-2", Namespace: "prod"}}},
+		{Name: "worker", Pods: []types.PodInfo{{Name: "worker-1", Namespace: "jobs"}}},
+		{Name: "empty"},
+	}
+
+	if got := strings.Join(podNames(workloads, false), "\n"); got != "pod/api-1\npod/api-2\npod/worker-1" {
+		t.Errorf("unexpected names: %q", got)
+	}
+	if got := strings.Join(podNames(workloads, true), "\n"); got != "prod/api-1\nprod/api-2\njobs/worker-1" {
+		t.Errorf("unexpected names across namespaces: %q", got)
+	}
+}
+
+func TestFormatGauge(t *testing.T) {
+	formatter := &Formatter{options: &types.Options{}}
+
+	tests := []struct {
+		done, total int
+		expected    string
+	}{
+		{0, 4, "▓░░░░░░░ 0/4 ready"},
+		{3, 4, "██████▓░ 3/4 ready"},
+		{4, 4, "████████ 4/4 ready"},
+	}
+
+	for _, tt := range tests {
+		if result := formatter.formatGauge(tt.done, tt.total, "ready"); result != tt.expected {
+			t.Errorf("%d/%d: expected %q, got %q", tt.done, tt.total, tt.expected, result)
+		}
+	}
+
+	formatter.options.NoColor = true
+	if result := formatter.formatGauge(3, 4, "updated"); result != "75% 3/4 updated" {
+		t.Errorf("expected the percentage without a bar when color is off, got %q", result)
+	}
+}
+
+func TestTemplateRollout(t *testing.T) {
+	pod := func(name, app, proxy string) types.PodInfo {
+		return types.PodInfo{Name: name, Containers: []types.ContainerInfo{{Name: "app", Image: app}, {Name: "proxy", Image: proxy}}}
+	}
+	workload := types.WorkloadInfo{
+		TemplateImages: map[string]string{"app": "api:1.3", "proxy": "envoy:1.28"},
+		Pods: []types.PodInfo{
+			pod("api-old-1", "api:1.2", "envoy:1.28"),
+			pod("api-old-2", "api:1.2", "envoy:1.28"),
+			pod("api-new-1", "api:1.3", "envoy:1.28"),
+		},
+	}
+
+	status := templateRollout(workload)
+	if status.Current != 1 || status.Outdated != 2 {
+		t.Fatalf("expected 1 current and 2 outdated pods, got %+v", status)
+	}
+	if got := formatRolloutStatus(status); got != "2 pods running outdated image api:1.2, 1 on current image api:1.3" {
+		t.Errorf("unexpected rollout line: %q", got)
+	}
+
+	workload.Pods = []types.PodInfo{pod("api-mixed", "api:1.2", "envoy:1.27"), pod("api-new", "api:1.3", "envoy:1.28")}
+	if got := formatRolloutStatus(templateRollout(workload)); got != "1 pod running outdated images api:1.2 and envoy:1.27, 1 on current images api:1.3 and envoy:1.28" {
+		t.Errorf("unexpected rollout line for several containers: %q", got)
+	}
+
+	workload.Pods = []types.PodInfo{pod("api-new", "api:1.3", "envoy:1.28")}
+	if got := formatRolloutStatus(templateRollout(workload)); got != "" {
+		t.Errorf("expected no rollout line when every pod is current, got %q", got)
+	}
+
+	// With revisions known they decide, even when the images match or only look different
+	workload.TemplateRevision = "7d9f"
+	revision := func(p types.PodInfo, revision string) types.PodInfo {
+		p.Revision = revision
+		return p
+	}
+	workload.Pods = []types.PodInfo{
+		revision(pod("api-env-change", "api:1.3", "envoy:1.28"), "5c8b"),
+		revision(pod("api-new", "docker.io/library/api:1.3", "envoy:1.28"), "7d9f"),
+	}
+	status = templateRollout(workload)
+	if status.Current != 1 || status.Outdated != 1 {
+		t.Fatalf("expected 1 current and 1 outdated pod by revision, got %+v", status)
+	}
+	if got := formatRolloutStatus(status); got != "1 pod running an outdated pod template, 1 on the current one" {
+		t.Errorf("unexpected rollout line for a template change without new images: %q", got)
+	}
+	workload.TemplateRevision = ""
+
+	// Workloads without a known template, like label selector groups, are never reported
+	if got := formatRolloutStatus(templateRollout(types.WorkloadInfo{Pods: []types.PodInfo{pod("p", "a", "b")}})); got != "" {
+		t.Errorf("expected no rollout line without a template, got %q", got)
+	}
+}
+
+func TestPrintWarnings(t *testing.T) {
+	f := New(&types.Options{NoColor: true})
+	workloads := []types.WorkloadInfo{
+		{Name: "api", Warnings: []string{"Could not create metrics client: no config", "Failed to collect logs for container app: timeout"}},
+		{Name: "worker", Warnings: []string{"Could not create metrics client: no config"}},
+		{Name: "quiet"},
+	}
+
+	if got := collectedWarnings(workloads); len(got) != 2 {
+		t.Errorf("expected 2 distinct warnings, got %v", got)
+	}
+
+	var buf bytes.Buffer
+	f.printWarnings(&buf, workloads)
+	expected := "⚠ Warnings:\n  • Could not create metrics client: no config\n  • Failed to collect logs for container app: timeout\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	f.printWarnings(&buf, []types.WorkloadInfo{{Name: "api"}})
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing without warnings, got %q", buf.String())
+	}
+}
+
+func TestShouldShowContainerStatus(t *testing.T) {
+	f := New(&types.Options{Statuses: []string{"CrashLoopBackOff", " oomkilled"}})
+
+	tests := []struct {
+		container types.ContainerInfo
+		expected  bool
+	}{
+		{types.ContainerInfo{Name: "app", Status: "CrashLoopBackOff"}, true},
+		{types.ContainerInfo{Name: "app", Status: "Terminated", TerminationReason: "OOMKilled"}, true},
+		{types.ContainerInfo{Name: "app", Status: "Running"}, false},
+		{types.ContainerInfo{Name: "app", Status: "Terminated", TerminationReason: "Error"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := f.shouldShowContainer(tt.container); got != tt.expected {
+			t.Errorf("status %s/%s: expected %v, got %v", tt.container.Status, tt.container.TerminationReason, tt.expected, got)
+		}
+	}
+}
+
+func TestFilterContainerStatuses(t *testing.T) {
+	pod := func(name string, statuses ...string) types.PodInfo {
+		info := types.PodInfo{Name: name}
+		for _, status := range statuses {
+			info.Containers = append(info.Containers, types.ContainerInfo{Name: "c", Status: status})
+		}
+		return info
+	}
+
+	workloads := []types.WorkloadInfo{
+		{Name: "api", Pods: []types.PodInfo{
+			pod("api-1", "Running", "CrashLoopBackOff"),
+			pod("api-2", "Running", "Running"),
+			pod("api-3", "ImagePullBackOff"),
+		}},
+		{Name: "web", Pods: []types.PodInfo{pod("web-1", "Running")}},
+	}
+
+	filtered := filterContainerStatuses(workloads, []string{"crashloopbackoff", "ImagePullBackOff"})
+	if len(filtered) != 1 || filtered[0].Name != "api" {
+		t.Fatalf("expected only the api workload to remain, got %+v", filtered)
+	}
+	var names []string
+	for _, p := range filtered[0].Pods {
+		names = append(names, p.Name)
+	}
+	if strings.Join(names, ",") != "api-1,api-3" {
+		t.Errorf("expected pods api-1 and api-3, got %v", names)
+	}
+}
+
+func TestOutputWithoutStatusMatches(t *testing.T) {
+	var out bytes.Buffer
+	f := NewWithWriter(&types.Options{OutputFormat: "table", NoColor: true, Statuses: []string{"OOMKilled"}}, &out)
+	workloads := []types.WorkloadInfo{{
+		Name:     "api",
+		Pods:     []types.PodInfo{{Name: "api-1", Containers: []types.ContainerInfo{{Name: "app", Status: "Running"}}}},
+		Warnings: []string{"metrics API not available"},
+	}}
+
+	if err := f.Output(workloads); err != nil {
+		t.Fatalf("Output() failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "No containers with status OOMKilled found") {
+		t.Errorf("expected the no-match message, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "metrics API not available") {
+		t.Errorf("expected the warnings after the no-match message, got %q", out.String())
+	}
+}
+
+func TestNodeSpread(t *testing.T) {
+	pod := func(node, level string) types.PodInfo {
+		return types.PodInfo{NodeName: node, Health: types.HealthStatus{Level: level}}
+	}
+	healthy := string(types.HealthLevelHealthy)
+	degraded := string(types.HealthLevelDegraded)
+	critical := string(types.HealthLevelCritical)
+
+	spread := nodeSpread([]types.PodInfo{
+		pod("node-a", healthy),
+		pod("node-a", healthy),
+		pod("node-b", critical),
+		pod("node-b", critical),
+		pod("node-b", healthy),
+		pod("node-c", degraded),
+		pod("", degraded),
+	})
+
+	expected := []nodeSpreadRow{
+		{Node: "node-b", Pods: 3, Healthy: 1, Critical: 2},
+		{Node: "<unscheduled>", Pods: 1, Degraded: 1},
+		{Node: "node-c", Pods: 1, Degraded: 1},
+		{Node: "node-a", Pods: 2, Healthy: 2},
+	}
+	if len(spread) != len(expected) {
+		t.Fatalf("expected %d nodes, got %+v", len(expected), spread)
+	}
+	for i := range expected {
+		if spread[i] != expected[i] {
+			t.Errorf("row %d: expected %+v, got %+v", i, expected[i], spread[i])
+		}
+	}
+}
+
+func TestFormatPreviousInstance(t *testing.T) {
+	f := New(&types.Options{NoColor: true})
+	started := time.Now().Add(-10 * time.Minute)
+	finished := started.Add(45 * time.Second)
+	exitCode := int32(1)
+
+	container := types.ContainerInfo{LastStartedAt: &started, LastFinishedAt: &finished, LastExitCode: &exitCode}
+	if got := f.formatPreviousInstance(container); got != "ran 45s (started 10m ago, died 9m ago), exit 1" {
+		t.Errorf("unexpected previous instance %q", got)
+	}
+
+	if got := f.formatPreviousInstance(types.ContainerInfo{LastExitCode: &exitCode}); got != "" {
+		t.Errorf("expected nothing without the previous instance's timing, got %q", got)
+	}
+}
+
+func TestOutputNoColor(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+
+	workloads := []types.WorkloadInfo{{
+		Kind:      "Deployment",
+		Name:      "api",
+		Namespace: "default",
+		Replicas:  "1/2",
+		Health:    types.HealthStatus{Level: string(types.HealthLevelDegraded), Reason: "1 pod restarting"},
+		Pods: []types.PodInfo{
+			{
+				Name:       "api-1",
+				Namespace:  "default",
+				Status:     "Running",
+				Health:     types.HealthStatus{Level: string(types.HealthLevelHealthy)},
+				Containers: []types.ContainerInfo{{Name: "app", Status: "Running", Ready: true}},
+			},
+			{
+				Name:       "api-2",
+				Namespace:  "default",
+				Status:     "Running",
+				Health:     types.HealthStatus{Level: string(types.HealthLevelCritical), Reason: "CrashLoopBackOff"},
+				Containers: []types.ContainerInfo{{Name: "app", Status: "CrashLoopBackOff", RestartCount: 7}},
+			},
+		},
+	}}
+
+	for _, noColor := range []bool{false, true} {
+		// The command sets the global switch alongside --no-color, so both are set here too
+		color.NoColor = noColor
+		var output bytes.Buffer
+		if err := NewWithWriter(&types.Options{NoColor: noColor, OutputFormat: "table"}, &output).Output(workloads); err != nil {
+			t.Fatalf("Output() failed: %v", err)
+		}
+
+		printed := output.String()
+		if !strings.Contains(printed, "api-2") {
+			t.Errorf("expected the pod table in the output, got:\n%s", printed)
+		}
+		if hasANSI := strings.Contains(printed, "\x1b["); hasANSI == noColor {
+			t.Errorf("NoColor=%v: expected ANSI escape codes %v, got:\n%q", noColor, !noColor, printed)
+		}
+	}
+}
+
+func TestPodHeaderEvicted(t *testing.T) {
+	var output bytes.Buffer
+	formatter := NewWithWriter(&types.Options{NoColor: true}, &output)
+
+	formatter.printPodHeader(types.PodInfo{
+		Name:          "api-1",
+		Status:        "Failed",
+		StatusReason:  "Evicted",
+		StatusMessage: "The node was low on resource: memory. Threshold quantity: 100Mi, available: 52Mi.",
+		Health:        types.HealthStatus{Level: string(types.HealthLevelCritical), Reason: "Evicted: The node was low on resource: memory"},
+	})
+
+	printed := output.String()
+	for _, expected := range []string{"STATUS: Failed (Evicted)", "Evicted: The node was low on resource: memory. Threshold quantity: 100Mi"} {
+		if !strings.Contains(printed, expected) {
+			t.Errorf("expected %q in the pod header, got:\n%s", expected, printed)
+		}
+	}
+}
+
+func TestSummarizeEvictions(t *testing.T) {
+	evicted := func(message string) types.PodInfo {
+		return types.PodInfo{Name: "api", Status: "Failed", StatusReason: "Evicted", StatusMessage: message}
+	}
+	pressure := evicted("The node was low on resource: memory. Threshold quantity: 100Mi, available: 52Mi.")
+	storage := evicted("Container app exceeded its local ephemeral storage limit \"500Mi\". ")
+
+	tests := []struct {
+		name     string
+		pods     []types.PodInfo
+		expected string
+	}{
+		{"none", []types.PodInfo{{Name: "api", Status: "Running"}}, ""},
+		{"node pressure", []types.PodInfo{pressure, pressure}, "2 pods left behind by node pressure"},
+		{"ephemeral-storage limit", []types.PodInfo{storage}, "1 pod left behind by an ephemeral-storage limit"},
+		{"mixed", []types.PodInfo{storage, pressure, pressure}, "3 pods left behind (2 by node pressure, 1 by an ephemeral-storage limit)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(&types.Options{}).summarizeEvictions(tt.pods); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCollapseHealthyContainers(t *testing.T) {
+	sidecarPod := func(count int) types.PodInfo {
+		pod := types.PodInfo{Name: "api-1", Status: "Running"}
+		for i := 0; i < count; i++ {
+			pod.Containers = append(pod.Containers, types.ContainerInfo{
+				Name:   fmt.Sprintf("sidecar-%d", i),
+				Type:   string(types.ContainerTypeStandard),
+				Status: string(types.ContainerStatusRunning),
+				Ready:  true,
+				Health: types.HealthStatus{Level: string(types.HealthLevelHealthy)},
+			})
+		}
+		pod.Containers[0].Name = "app"
+		pod.Containers[0].Status = "CrashLoopBackOff"
+		pod.Containers[0].Health = types.HealthStatus{Level: string(types.HealthLevelCritical)}
+		return pod
+	}
+
+	tests := []struct {
+		name         string
+		options      types.Options
+		pod          types.PodInfo
+		workloadView bool
+		detailed     int
+		shown        string // Container whose details must be shown, "app" when empty
+		hidden       string
+	}{
+		{name: "few containers", pod: sidecarPod(3), detailed: 3},
+		{name: "many containers", pod: sidecarPod(8), detailed: 1, hidden: "7 healthy containers hidden (use --all)"},
+		{name: "many containers with --all", options: types.Options{AllContainers: true}, pod: sidecarPod(8), detailed: 8},
+		{name: "--containers-summary-only", options: types.Options{ContainersSummaryOnly: true}, pod: sidecarPod(2), detailed: 1, hidden: "1 healthy container hidden (use --all)"},
+		{name: "healthy container asked for with -c", options: types.Options{ContainerName: "sidecar-3"}, pod: sidecarPod(8), detailed: 1, shown: "sidecar-3"},
+		{name: "many containers in a workload view", pod: sidecarPod(8), workloadView: true, detailed: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.NoColor = true
+			var output bytes.Buffer
+			if err := NewWithWriter(&tt.options, &output).formatPodWithContext(tt.pod, !tt.workloadView, false); err != nil {
+				t.Fatalf("formatPodWithContext() failed: %v", err)
+			}
+
+			printed := output.String()
+			if detailed := strings.Count(printed, "Container: "); detailed != tt.detailed {
+				t.Errorf("expected %d container details, got %d:\n%s", tt.detailed, detailed, printed)
+			}
+			if tt.shown == "" {
+				tt.shown = "app"
+			}
+			if !strings.Contains(printed, "Container: "+tt.shown) {
+				t.Errorf("expected the details of %s, got:\n%s", tt.shown, printed)
+			}
+			if tt.hidden != "" && !strings.Contains(printed, tt.hidden) {
+				t.Errorf("expected %q, got:\n%s", tt.hidden, printed)
+			}
+			if tt.hidden == "" && strings.Contains(printed, "hidden (use --all)") {
+				t.Errorf("expected no hidden containers, got:\n%s", printed)
+			}
+		})
+	}
+}
+
+func TestFormatHPA(t *testing.T) {
+	f := New(&types.Options{NoColor: true})
+
+	scaling := types.HPAInfo{
+		Name:            "api",
+		CurrentReplicas: 3,
+		DesiredReplicas: 5,
+		MinReplicas:     2,
+		MaxReplicas:     10,
+		Metrics:         []types.HPAMetric{{Name: "cpu", Current: "85%", Target: "70%"}},
+	}
+	if got := f.formatHPA(scaling); got != "api 3→5 replicas (cpu 85%/70% target, range 2-10)" {
+		t.Errorf("unexpected HPA line: %q", got)
+	}
+
+	maxedOut := types.HPAInfo{Name: "api", CurrentReplicas: 10, DesiredReplicas: 10, MinReplicas: 2, MaxReplicas: 10}
+	if got := f.formatHPA(maxedOut); got != "api 10 replicas (at max 10)" {
+		t.Errorf("unexpected HPA line: %q", got)
+	}
+}
+
+func TestFormatScheduling(t *testing.T) {
+	f := New(&types.Options{NoColor: true})
+	latency := 2 * time.Second
+
+	tests := []struct {
+		name     string
+		pod      types.PodInfo
+		expected string
+	}{
+		{
+			name:     "scheduled",
+			pod:      types.PodInfo{Status: "Running", SchedulerName: "default-scheduler", SchedulingLatency: &latency},
+			expected: "scheduled by default-scheduler after 2s",
+		},
+		{
+			name:     "pending",
+			pod:      types.PodInfo{Status: "Pending", SchedulerName: "default-scheduler", Age: 5 * time.Minute},
+			expected: "not yet scheduled (pending 5m)",
+		},
+		{
+			name:     "pending on a custom scheduler",
+			pod:      types.PodInfo{Status: "Pending", SchedulerName: "volcano", Age: 5 * time.Minute},
+			expected: "not yet scheduled by volcano (pending 5m)",
+		},
+		{
+			name: "never scheduled but not pending",
+			pod:  types.PodInfo{Status: "Failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.formatScheduling(tt.pod); got != tt.expected {
+				t.Errorf("formatScheduling() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatRestartReasons(t *testing.T) {
+	reasons := []types.RestartReasonCount{{Reason: "OOMKilled", Count: 12}, {Reason: "Error", Count: 3}}
+	if got := formatRestartReasons(reasons); got != "OOMKilled ×12, Error ×3" {
+		t.Errorf("unexpected restart reasons %q", got)
+	}
+	if got := formatRestartReasons(nil); got != "" {
+		t.Errorf("expected nothing without restarts, got %q", got)
+	}
+}
+
+func TestPrintLogsHighlightsMatches(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	color.NoColor = false
+
+	var output bytes.Buffer
+	f := NewWithWriter(&types.Options{LogGrep: "time(out)?", LogPattern: regexp.MustCompile("time(out)?")}, &output)
+	f.printLogs([]string{"upstream timeout after 30s", "retry in time"})
+
+	highlight := color.New(color.FgRed, color.Bold)
+	for _, want := range []string{
+		`Recent Logs matching "time(out)?":`,
+		"    upstream " + highlight.Sprint("timeout") + " after 30s\n",
+		"    retry in " + highlight.Sprint("time") + "\n",
+	} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, output.String())
+		}
+	}
+
+	output.Reset()
+	f.options.LogPattern = nil
+	f.printLogs([]string{"upstream timeout after 30s"})
+	if strings.Contains(output.String(), "\x1b[") {
+		t.Errorf("expected no highlighting without --log-grep, got %q", output.String())
+	}
+}
+
+func TestPrintWrappedLogLineHighlightsWholeLine(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	color.NoColor = false
+	highlight := color.New(color.FgRed, color.Bold)
+
+	tests := []struct {
+		name     string
+		pattern  string
+		expected string
+	}{
+		// The continuation starts with ERROR, but the line itself doesn't
+		{"anchor", "^ERROR", "    ok status\n      ERROR he\n      re\n"},
+		{"match across the wrap", "status ERR", "    ok " + highlight.Sprint("status") + "\n      " + highlight.Sprint("ERR") + "OR he\n      re\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			f := NewWithWriter(&types.Options{LogGrep: tt.pattern, LogPattern: regexp.MustCompile(tt.pattern)}, &output)
+			f.printWrappedLogLine("ok status ERROR here", 10, 4)
+			if output.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, output.String())
+			}
+		})
+	}
+}
+
+func TestPrintWorkloadNodeProblems(t *testing.T) {
+	var output bytes.Buffer
+	f := NewWithWriter(&types.Options{NoColor: true}, &output)
+	f.printWorkloadNodeProblems(types.WorkloadInfo{Pods: []types.PodInfo{
+		{Name: "api-1", NodeName: "node-b", NodeProblems: []string{"NotReady"}},
+		{Name: "api-2", NodeName: "node-a", NodeProblems: []string{"MemoryPressure", "DiskPressure"}},
+		{Name: "api-3", NodeName: "node-c"},
+		{Name: "api-4", NodeName: "node-a", NodeProblems: []string{"MemoryPressure", "DiskPressure"}},
+	}})
+
+	expected := "⚠️  node node-a has MemoryPressure, DiskPressure (api-2, api-4)\n⚠️  node node-b has NotReady (api-1)\n\n"
+	if output.String() != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, output.String())
+	}
+
+	output.Reset()
+	f.printWorkloadNodeProblems(types.WorkloadInfo{Pods: []types.PodInfo{{Name: "api-3", NodeName: "node-c"}}})
+	if output.Len() != 0 {
+		t.Errorf("expected nothing when every node is healthy, got %q", output.String())
+	}
+}
+
+func TestPrintCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		container types.ContainerInfo
+		expected  string
+	}{
+		{
+			name:      "image default",
+			container: types.ContainerInfo{Type: string(types.ContainerTypeStandard)},
+			expected:  "  • Command:     (using image default entrypoint)\n",
+		},
+		{
+			name:      "args only",
+			container: types.ContainerInfo{Type: string(types.ContainerTypeStandard), Args: []string{"--port=8080"}},
+			expected:  "  • Command:     \n    - Entrypoint: (image default)\n    - Args:       --port=8080\n",
+		},
+		{
+			name:      "debug container",
+			container: types.ContainerInfo{Type: string(types.ContainerTypeEphemeral), Command: []string{"sh", "-c"}, Args: []string{"curl -s localhost:8080/healthz"}},
+			expected:  "  • Command:     sh -c 'curl -s localhost:8080/healthz'\n",
+		},
+		{
+			name:      "debug container with image default",
+			container: types.ContainerInfo{Type: string(types.ContainerTypeEphemeral)},
+			expected:  "  • Command:     (using image default entrypoint)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			f := NewWithWriter(&types.Options{}, &output)
+			f.printCommand(tt.container)
+			if output.String() != tt.expected {
+				t.Errorf("expected:\n%q\ngot:\n%q", tt.expected, output.String())
+			}
+		})
+	}
+}
+
+func TestFormatRestartRecency(t *testing.T) {
+	f := New(&types.Options{})
+	restartedAgo := func(ago time.Duration) *time.Time {
+		restarted := time.Now().Add(-ago)
+		return &restarted
+	}
+
+	pods := []types.PodInfo{
+		{Name: "api-1", Containers: []types.ContainerInfo{
+			{Name: "app", RestartCount: 1, LastRestartTime: restartedAgo(2 * time.Hour)},
+			{Name: "proxy"},
+		}},
+		{Name: "api-2", Containers: []types.ContainerInfo{
+			{Name: "app", RestartCount: 9, LastRestartTime: restartedAgo(3 * time.Minute)},
+			{Name: "proxy", RestartCount: 1, LastRestartTime: restartedAgo(40 * time.Minute)},
+		}},
+	}
+	if got := f.formatRestartRecency(pods); got != "most recent 3m ago (api-2/app), oldest tracked 2h ago (api-1/app)" {
+		t.Errorf("unexpected restart recency %q", got)
+	}
+	if got := f.formatRestartRecency(pods[:1]); got != "most recent 2h ago (api-1/app)" {
+		t.Errorf("expected only the most recent restart for a single restart, got %q", got)
+	}
+	if got := f.formatRestartRecency([]types.PodInfo{{Name: "api-3", Containers: []types.ContainerInfo{{Name: "app"}}}}); got != "" {
+		t.Errorf("expected nothing without restarts, got %q", got)
+	}
+}
+
+func TestFormatReadyCount(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	color.NoColor = false
+	f := New(&types.Options{})
+
+	notReady := types.PodInfo{Status: "Running", Age: time.Hour, Containers: []types.ContainerInfo{{Name: "app"}, {Name: "proxy"}}}
+	if got := f.formatReadyCount(notReady); got != f.getHealthColor(string(types.HealthLevelCritical)).Sprint("0/2") {
+		t.Errorf("expected a red 0/2, got %q", got)
+	}
+
+	notReady.Containers[0].Ready = true
+	if got := f.formatReadyCount(notReady); got != "1/2" {
+		t.Errorf("expected a plain 1/2, got %q", got)
+	}
+
+	pending := types.PodInfo{Status: "Pending", Age: time.Hour, Containers: []types.ContainerInfo{{Name: "app"}}}
+	if got := f.formatReadyCount(pending); got != "0/1" {
+		t.Errorf("expected a plain 0/1 for a pending pod, got %q", got)
+	}
+}
+
+func TestContainerDetailsStatusMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		container types.ContainerInfo
+		expected  string
+	}{
+		{
+			name: "waiting with message",
+			container: types.ContainerInfo{
+				Name:          "app",
+				Status:        "ImagePullBackOff",
+				StatusMessage: `Back-off pulling image "registry.example.com/app:2.0"`,
+			},
+			expected: ` ImagePullBackOff: Back-off pulling image "registry.example.com/app:2.0"` + "\n",
+		},
+		{
+			name:      "running without message",
+			container: types.ContainerInfo{Name: "app", Status: "Running"},
+			expected:  " Running\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			NewWithWriter(&types.Options{NoColor: true}, &output).printContainerDetails(tt.container, false)
+			var statusLine string
+			for _, line := range strings.SplitAfter(output.String(), "\n") {
+				if strings.Contains(line, "• Status:") {
+					statusLine = line
+				}
+			}
+			// The status icon comes first, then the status and any message
+			if !strings.HasSuffix(statusLine, tt.expected) {
+				t.Errorf("expected status line ending in %q, got %q", tt.expected, statusLine)
+			}
+		})
+	}
+}
+
+func TestWorkloadWaitingMessages(t *testing.T) {
+	pullBackOff := `Back-off pulling image "registry.example.com/app:2.0"`
+	pod := func(name string, containers ...types.ContainerInfo) types.PodInfo {
+		return types.PodInfo{Name: name, Status: "Pending", Containers: containers, Health: types.HealthStatus{Level: string(types.HealthLevelCritical)}}
+	}
+	waiting := func(name, status, message string) types.ContainerInfo {
+		return types.ContainerInfo{Name: name, Type: string(types.ContainerTypeStandard), Status: status, StatusMessage: message}
+	}
+	workload := types.WorkloadInfo{
+		Name: "web",
+		Kind: "Deployment",
+		Pods: []types.PodInfo{
+			pod("web-1", waiting("app", "ImagePullBackOff", pullBackOff), waiting("proxy", "Running", "")),
+			pod("web-2", waiting("app", "ImagePullBackOff", pullBackOff)),
+			pod("web-3", waiting("app", "CreateContainerConfigError", `secret "db" not found`)),
+		},
+	}
+
+	var output bytes.Buffer
+	if err := NewWithWriter(&types.Options{NoColor: true}, &output).formatWorkload(workload); err != nil {
+		t.Fatalf("formatWorkload() failed: %v", err)
+	}
+	printed := output.String()
+	for _, expected := range []string{
+		`⚠️  app ImagePullBackOff: Back-off pulling image "registry.example.com/app:2.0" (web-1, web-2)`,
+		`⚠️  app CreateContainerConfigError: secret "db" not found (web-3)`,
+	} {
+		if !strings.Contains(printed, expected) {
+			t.Errorf("expected %q below the workload table, got:\n%s", expected, printed)
+		}
+	}
+
+	// -c leaves out other containers' messages
+	output.Reset()
+	if err := NewWithWriter(&types.Options{NoColor: true, ContainerName: "proxy"}, &output).formatWorkload(workload); err != nil {
+		t.Fatalf("formatWorkload() failed: %v", err)
+	}
+	if strings.Contains(output.String(), "Back-off pulling image") {
+		t.Errorf("expected no waiting messages for other containers, got:\n%s", output.String())
+	}
+}
+
+func TestFormatBytesAndMilliCPU(t *testing.T) {
+	if got := formatBytes(256 * 1024 * 1024); got != "256Mi" {
+		t.Errorf("expected 256Mi, got %s", got)
+	}
+	if got := formatBytes(3 * 1024 * 1024 * 1024 / 2); got != "1.5Gi" {
+		t.Errorf("expected 1.5Gi, got %s", got)
+	}
+	if got := formatMilliCPU(250); got != "250m" {
+		t.Errorf("expected 250m, got %s", got)
+	}
+	if got := formatMilliCPU(1500); got != "1.5" {
+		t.Errorf("expected 1.5, got %s", got)
+	}
+}
+
+func TestPercentileInt64(t *testing.T) {
+	hundred := make([]int64, 100)
+	for i := range hundred {
+		hundred[i] = int64(i + 1)
+	}
+
+	tests := []struct {
+		name       string
+		values     []int64
+		percentile float64
+		expected   int64
+	}{
+		{"empty", nil, 0.5, 0},
+		{"one value p50", []int64{7}, 0.5, 7},
+		{"one value p95", []int64{7}, 0.95, 7},
+		{"two values p50", []int64{20, 10}, 0.5, 10},
+		{"two values p95", []int64{20, 10}, 0.95, 20},
+		{"ten values p50", []int64{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, 0.5, 5},
+		{"ten values p90", []int64{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, 0.9, 9},
+		{"zero percentile", []int64{3, 1, 2}, 0, 1},
+		{"exact rank despite float error", hundred, 0.07, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentileInt64(tt.values, tt.percentile); got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWorkloadSummaryUsageReadings(t *testing.T) {
+	pod := func(name string, milliCPU int64, cpu string) types.PodInfo {
+		return types.PodInfo{Name: name, Containers: []types.ContainerInfo{{
+			Name: "app", Type: string(types.ContainerTypeStandard), Status: "Running", Ready: true,
+			Resources: types.ResourceInfo{CPUUsage: cpu, CPUUsageMilli: milliCPU, HasCPUUsage: true, MemUsage: "64Mi", MemUsageBytes: 64 * 1024 * 1024, HasMemUsage: true},
+		}}}
+	}
+
+	var output bytes.Buffer
+	f := NewWithWriter(&types.Options{NoColor: true}, &output)
+	// Sorted as strings "900m" would come after "1.2"
+	f.printWorkloadSummary(types.WorkloadInfo{Name: "web", Kind: "Deployment", Pods: []types.PodInfo{pod("web-1", 1200, "1.2"), pod("web-2", 900, "900m")}})
+
+	for _, pattern := range []string{`avg:\S+ \(1\.1\)`, `p90:\S+ \(1\.2\)`, `p99:\S+ \(1\.2\)`, `Mem .*avg:\S+ \(64Mi\)`} {
+		if !regexp.MustCompile(pattern).MatchString(output.String()) {
+			t.Errorf("expected %s in:\n%s", pattern, output.String())
+		}
+	}
+}
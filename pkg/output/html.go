@@ -0,0 +1,223 @@
+package output
+
+import (
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// htmlTemplate renders workloads as a self-contained HTML page. html/template escapes all
+// values, so pod names, annotations and event messages cannot inject markup.
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Container Status Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.4em; }
+h2 { font-size: 1.2em; margin-top: 2em; border-bottom: 1px solid #ddd; padding-bottom: 0.3em; }
+h3 { font-size: 1em; margin-top: 1.5em; }
+table { border-collapse: collapse; margin: 0.5em 0 1em 0; }
+th, td { border: 1px solid #ddd; padding: 4px 8px; text-align: left; font-size: 0.9em; }
+th { background: #f5f5f5; }
+.meta { color: #666; font-size: 0.9em; }
+.badge { display: inline-block; padding: 2px 8px; border-radius: 10px; color: #fff; font-size: 0.85em; }
+.Healthy { background: #2e9e44; }
+.Degraded { background: #d49b00; }
+.Critical { background: #d0312d; }
+.Unknown { background: #888; }
+.Warning { color: #b07800; font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>Container Status Report</h1>
+<p class="meta">Generated {{.Generated}}</p>
+{{range .Workloads}}
+<h2>{{.Kind}}: {{.Name}}</h2>
+<p class="meta">Namespace: {{.Namespace}} &middot; Replicas: {{.Replicas}}</p>
+<p><span class="badge {{.HealthClass}}">{{.Health.Level}}</span> {{.Health.Reason}}</p>
+<table>
+<tr><th>POD</th><th>NODE</th><th>STATUS</th><th>HEALTH</th><th>READY</th><th>RESTARTS</th><th>CPU</th><th>MEMORY (working set)</th><th>AGE</th></tr>
+{{range .Pods}}<tr><td>{{.Name}}</td><td>{{.Node}}</td><td>{{.Status}}</td><td><span class="badge {{.HealthClass}}">{{.Health.Level}}</span> {{.Health.Reason}}</td><td>{{.Ready}}</td><td>{{.Restarts}}</td><td>{{.CPU}}</td><td>{{.Memory}}</td><td>{{.Age}}</td></tr>
+{{end}}</table>
+{{range .Pods}}
+<h3>Pod {{.Name}}</h3>
+<table>
+<tr><th>CONTAINER</th><th>STATUS</th><th>RESTARTS</th><th>LAST STATE</th><th>EXIT CODE</th><th>IMAGE</th></tr>
+{{range .Containers}}<tr><td>{{.Name}}</td><td>{{.Status}}</td><td>{{.Restarts}}</td><td>{{.LastState}}</td><td>{{.ExitCode}}</td><td>{{.Image}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if .Events}}
+<h3>Events</h3>
+<table>
+<tr><th>AGE</th><th>TYPE</th><th>REASON</th><th>POD</th><th>MESSAGE</th></tr>
+{{range .Events}}<tr><td>{{.Age}}</td><td class="{{.Type}}">{{.Type}}</td><td>{{.Reason}}</td><td>{{.PodName}}</td><td>{{.Message}}</td></tr>
+{{end}}</table>
+{{end}}
+{{end}}
+</body>
+</html>
+`))
+
+// htmlReport is the view model rendered by htmlTemplate
+type htmlReport struct {
+	Generated string
+	Workloads []htmlWorkload
+}
+
+type htmlWorkload struct {
+	Kind        string
+	Name        string
+	Namespace   string
+	Replicas    string
+	Health      types.HealthStatus
+	HealthClass string
+	Pods        []htmlPod
+	Events      []htmlEvent
+}
+
+type htmlPod struct {
+	Name        string
+	Node        string
+	Status      string
+	Health      types.HealthStatus
+	HealthClass string
+	Ready       string
+	Restarts    int32
+	CPU         string
+	Memory      string
+	Age         string
+	Containers  []htmlContainer
+}
+
+type htmlContainer struct {
+	Name      string
+	Status    string
+	Restarts  int32
+	LastState string
+	ExitCode  string
+	Image     string
+}
+
+type htmlEvent struct {
+	Age     string
+	Type    string
+	Reason  string
+	PodName string
+	Message string
+}
+
+// outputHTML outputs workloads as a self-contained HTML page
+func (f *Formatter) outputHTML(workloads []types.WorkloadInfo) error {
+	if err := htmlTemplate.Execute(f.out, f.buildHTMLReport(workloads)); err != nil {
+		return fmt.Errorf("failed to render HTML: %w", err)
+	}
+	return nil
+}
+
+// buildHTMLReport converts workloads into the HTML view model
+func (f *Formatter) buildHTMLReport(workloads []types.WorkloadInfo) htmlReport {
+	report := htmlReport{
+		Generated: f.formatTimestamp(time.Now()),
+	}
+
+	for _, workload := range workloads {
+		f.sortPods(workload.Pods)
+
+		hw := htmlWorkload{
+			Kind:        workload.Kind,
+			Name:        workload.Name,
+			Namespace:   workload.Namespace,
+			Replicas:    workload.Replicas,
+			Health:      workload.Health,
+			HealthClass: healthClass(workload.Health.Level),
+		}
+
+		var events []types.EventInfo
+		for _, pod := range workload.Pods {
+			hw.Pods = append(hw.Pods, f.buildHTMLPod(pod, workload.MetricsUnavailable))
+			events = append(events, pod.Events...)
+		}
+
+		for _, event := range sortEvents(events, f.options.EventsSort) {
+			hw.Events = append(hw.Events, htmlEvent{
+				Age:     f.formatAge(f.since(event.Time)),
+				Type:    event.Type,
+				Reason:  event.Reason,
+				PodName: event.PodName,
+				Message: event.Message,
+			})
+		}
+
+		report.Workloads = append(report.Workloads, hw)
+	}
+
+	return report
+}
+
+// buildHTMLPod converts a pod into the HTML view model
+func (f *Formatter) buildHTMLPod(pod types.PodInfo, metricsUnavailable bool) htmlPod {
+	hp := htmlPod{
+		Name:        pod.Name,
+		Node:        pod.NodeName,
+		Status:      pod.Status,
+		Health:      pod.Health,
+		HealthClass: healthClass(pod.Health.Level),
+		Ready:       fmt.Sprintf("%d/%d", f.getReadyCount(pod), len(pod.Containers)),
+		CPU:         f.missingUsage(metricsUnavailable),
+		Memory:      f.missingUsage(metricsUnavailable),
+		Age:         f.formatAge(pod.Age),
+	}
+
+	if pod.Metrics != nil {
+		if pod.Metrics.CPUUsage != "" {
+			hp.CPU = f.formatCPUValue(pod.Metrics.CPUUsage, pod.Metrics.CPUUsageMilli)
+		}
+		if pod.Metrics.MemoryUsage != "" {
+			hp.Memory = f.formatMemoryValue(pod.Metrics.MemoryUsage, pod.Metrics.MemoryUsageBytes)
+		}
+	}
+
+	for _, container := range allContainers(pod) {
+		if !f.shouldShowContainer(container) {
+			continue
+		}
+		hp.Restarts += container.RestartCount
+
+		name := containerDisplayName(container)
+
+		lastState := container.LastState
+		if container.LastStateReason != "" && container.LastState != "None" {
+			lastState = fmt.Sprintf("%s (%s)", container.LastState, container.LastStateReason)
+		}
+
+		exitCode := "-"
+		if container.ExitCode != nil {
+			exitCode = formatExitCode(*container.ExitCode, container.Signal)
+		}
+
+		hp.Containers = append(hp.Containers, htmlContainer{
+			Name:      name,
+			Status:    container.Status,
+			Restarts:  container.RestartCount,
+			LastState: lastState,
+			ExitCode:  exitCode,
+			Image:     container.Image,
+		})
+	}
+
+	return hp
+}
+
+// healthClass maps a health level to its CSS badge class
+func healthClass(level string) string {
+	switch level {
+	case string(types.HealthLevelHealthy), string(types.HealthLevelDegraded), string(types.HealthLevelCritical):
+		return level
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestHTMLReportEscapesValues(t *testing.T) {
+	formatter := New(&types.Options{OutputFormat: "html", SortBy: "name"})
+
+	workloads := []types.WorkloadInfo{
+		{
+			Name:      "api",
+			Kind:      "Deployment",
+			Namespace: "default",
+			Replicas:  "1/1",
+			Health:    types.HealthStatus{Level: string(types.HealthLevelCritical), Reason: "1 pod has critical issues"},
+			Pods: []types.PodInfo{
+				{
+					Name:   "api-1",
+					Status: "Running",
+					Health: types.HealthStatus{Level: string(types.HealthLevelCritical), Reason: "container in CrashLoopBackOff"},
+					Containers: []types.ContainerInfo{
+						{Name: "app", Status: "CrashLoopBackOff", RestartCount: 4, Image: "example.com/app:v1"},
+					},
+					Events: []types.EventInfo{
+						{Time: time.Now(), Type: "Warning", Reason: "BackOff", Message: "<script>alert(1)</script>", PodName: "api-1"},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, formatter.buildHTMLReport(workloads)); err != nil {
+		t.Fatalf("failed to render HTML: %v", err)
+	}
+	out := buf.String()
+
+	if strings.Contains(out, "<script>alert(1)</script>") {
+		t.Errorf("expected event message to be escaped")
+	}
+	if !strings.Contains(out, "&lt;script&gt;") {
+		t.Errorf("expected escaped event message in output")
+	}
+	if !strings.Contains(out, `class="badge Critical"`) {
+		t.Errorf("expected critical health badge in output")
+	}
+	if !strings.Contains(out, "CrashLoopBackOff") {
+		t.Errorf("expected container status in output")
+	}
+}
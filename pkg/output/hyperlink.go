@@ -0,0 +1,82 @@
+package output
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// cellLink is a table cell's text and the URL to link it to once the table is rendered
+type cellLink struct {
+	Text string
+	URL  string
+}
+
+// hyperlink wraps text in an OSC 8 escape sequence so terminals that support it make it clickable,
+// or returns the text unchanged when hyperlinks are off or there is no URL
+func (f *Formatter) hyperlink(text, target string) string {
+	if !f.options.Hyperlinks || target == "" {
+		return text
+	}
+	return "\x1b]8;;" + target + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// podURL fills in the --pod-url template for a pod, or returns "" when no template is set
+func (f *Formatter) podURL(pod types.PodInfo) string {
+	return expandURLTemplate(f.options.PodURL, map[string]string{
+		"namespace": pod.Namespace,
+		"pod":       pod.Name,
+		"node":      pod.NodeName,
+	})
+}
+
+// nodeURL fills in the --node-url template for a node, or returns "" when no template or node is set
+func (f *Formatter) nodeURL(node string) string {
+	if node == "" {
+		return ""
+	}
+	return expandURLTemplate(f.options.NodeURL, map[string]string{"node": node})
+}
+
+// expandURLTemplate replaces {name} placeholders with escaped values
+func expandURLTemplate(template string, values map[string]string) string {
+	if template == "" {
+		return ""
+	}
+	replacements := make([]string, 0, 2*len(values))
+	for name, value := range values {
+		replacements = append(replacements, "{"+name+"}", url.PathEscape(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// linkTableCells adds hyperlinks to a rendered table. tablewriter counts escape sequences as visible
+// width, so the links go in after rendering: each link's text is looked up, in order, as a whole
+// "| text " cell on the current row or the rows after it.
+func (f *Formatter) linkTableCells(rendered string, links []cellLink) string {
+	if !f.options.Hyperlinks || len(links) == 0 {
+		return rendered
+	}
+
+	lines := strings.SplitAfter(rendered, "\n")
+	next := 0
+	for i, line := range lines {
+		offset := 0
+		for next < len(links) {
+			link := links[next]
+			cell := "| " + link.Text + " "
+			at := strings.Index(line[offset:], cell)
+			if at < 0 {
+				break
+			}
+			start := offset + at + 2
+			linked := f.hyperlink(link.Text, link.URL)
+			line = line[:start] + linked + line[start+len(link.Text):]
+			offset = start + len(linked)
+			next++
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,105 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestPodURL(t *testing.T) {
+	f := New(&types.Options{
+		PodURL:  "https://grafana.example.com/d/pods?var-namespace={namespace}&var-pod={pod}",
+		NodeURL: "https://grafana.example.com/d/nodes?var-node={node}",
+	})
+	pod := types.PodInfo{Name: "api-7d9f-x2k4q", Namespace: "payments", NodeName: "node-1"}
+
+	if got := f.podURL(pod); got != "https://grafana.example.com/d/pods?var-namespace=payments&var-pod=api-7d9f-x2k4q" {
+		t.Errorf("unexpected pod URL %q", got)
+	}
+	if got := f.nodeURL("node-1"); got != "https://grafana.example.com/d/nodes?var-node=node-1" {
+		t.Errorf("unexpected node URL %q", got)
+	}
+	if got := f.nodeURL(""); got != "" {
+		t.Errorf("expected no URL for an unscheduled pod, got %q", got)
+	}
+}
+
+func TestHyperlink(t *testing.T) {
+	f := New(&types.Options{})
+	if got := f.hyperlink("api-1", "https://example.com"); got != "api-1" {
+		t.Errorf("expected plain text with hyperlinks off, got %q", got)
+	}
+
+	f.options.Hyperlinks = true
+	if got := f.hyperlink("api-1", "https://example.com"); got != "\x1b]8;;https://example.com\x1b\\api-1\x1b]8;;\x1b\\" {
+		t.Errorf("unexpected OSC 8 sequence %q", got)
+	}
+	if got := f.hyperlink("api-1", ""); got != "api-1" {
+		t.Errorf("expected plain text without a URL, got %q", got)
+	}
+}
+
+func TestLinkTableCells(t *testing.T) {
+	f := New(&types.Options{Hyperlinks: true})
+
+	var rendered bytes.Buffer
+	table := tablewriter.NewWriter(&rendered)
+	table.SetHeader([]string{"POD", "NODE"})
+	table.Append([]string{"web-10", "node-1"})
+	table.Append([]string{"web-1", "node-10"})
+	table.Render()
+
+	links := []cellLink{
+		{Text: "web-10", URL: "https://example.com/web-10"},
+		{Text: "node-1", URL: "https://example.com/node-1"},
+		{Text: "web-1", URL: "https://example.com/web-1"},
+		{Text: "node-10", URL: "https://example.com/node-10"},
+	}
+	linked := f.linkTableCells(rendered.String(), links)
+
+	for _, link := range links {
+		if !strings.Contains(linked, f.hyperlink(link.Text, link.URL)+" ") {
+			t.Errorf("expected %s to be linked as a whole cell in:\n%s", link.Text, linked)
+		}
+	}
+	if strings.Count(linked, "\x1b]8;;https") != len(links) {
+		t.Errorf("expected exactly %d links in:\n%s", len(links), linked)
+	}
+
+	f.options.Hyperlinks = false
+	if got := f.linkTableCells(rendered.String(), links); got != rendered.String() {
+		t.Errorf("expected the table unchanged with hyperlinks off")
+	}
+}
+
+func TestWorkloadHeaderLinks(t *testing.T) {
+	options := &types.Options{NoColor: true, Hyperlinks: true, PodURL: "https://example.com/{namespace}/{pod}"}
+	pod := types.PodInfo{Name: "api-7d9f-x2k4q", Namespace: "payments"}
+
+	tests := []struct {
+		name     string
+		workload types.WorkloadInfo
+		linked   bool
+	}{
+		{"single pod", types.WorkloadInfo{Kind: "Pod", Name: pod.Name, Namespace: "payments", Pods: []types.PodInfo{pod}}, true},
+		{"deployment with one pod", types.WorkloadInfo{Kind: "Deployment", Name: "api", Namespace: "payments", Pods: []types.PodInfo{pod}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			f := NewWithWriter(options, &output)
+			f.printWorkloadHeader(tt.workload)
+			link := f.hyperlink(pod.Name, "https://example.com/payments/api-7d9f-x2k4q")
+			if got := strings.Contains(output.String(), link); got != tt.linked {
+				t.Errorf("expected pod link %v, got:\n%q", tt.linked, output.String())
+			}
+			if !tt.linked && strings.Contains(output.String(), "\x1b]8;;") {
+				t.Errorf("expected no links in a workload header, got:\n%q", output.String())
+			}
+		})
+	}
+}
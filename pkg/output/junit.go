@@ -0,0 +1,110 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// junitTestSuites is the root of a JUnit XML report: one suite per workload, one test case per pod.
+// encoding/xml escapes every attribute and text value, so names and reasons can't break the document.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+// junitProblem is a failure or error element: the health reason as the message and the unhealthy
+// containers as the body
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Details string `xml:",chardata"`
+}
+
+// outputJUnit outputs workloads as a JUnit XML report for CI test reporting. Critical pods are
+// failures and Degraded pods are errors, so dashboards can tell the two apart; Healthy pods pass.
+func (f *Formatter) outputJUnit(workloads []types.WorkloadInfo) error {
+	data, err := xml.MarshalIndent(f.buildJUnitReport(workloads, time.Now()), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JUnit XML: %w", err)
+	}
+	fmt.Fprint(f.out, xml.Header)
+	fmt.Fprintln(f.out, string(data))
+	return nil
+}
+
+// buildJUnitReport converts workloads into the JUnit report
+func (f *Formatter) buildJUnitReport(workloads []types.WorkloadInfo, now time.Time) junitTestSuites {
+	report := junitTestSuites{Name: "kubectl-container-status"}
+	for _, workload := range workloads {
+		f.sortPods(workload.Pods)
+
+		suite := junitTestSuite{
+			Name:      fmt.Sprintf("%s/%s/%s", workload.Namespace, strings.ToLower(workload.Kind), workload.Name),
+			Timestamp: f.formatTimestamp(now),
+		}
+		for _, pod := range workload.Pods {
+			testCase := junitTestCase{Name: pod.Name, ClassName: suite.Name}
+			switch types.HealthLevel(pod.Health.Level) {
+			case types.HealthLevelCritical:
+				testCase.Failure = f.junitProblem(pod)
+				suite.Failures++
+			case types.HealthLevelDegraded:
+				testCase.Error = f.junitProblem(pod)
+				suite.Errors++
+			}
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		suite.Tests = len(suite.Cases)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Suites = append(report.Suites, suite)
+	}
+	return report
+}
+
+// junitProblem describes an unhealthy pod: its health reason, then its status and each container that
+// isn't healthy, one per line
+func (f *Formatter) junitProblem(pod types.PodInfo) *junitProblem {
+	details := []string{fmt.Sprintf("pod %s is %s", pod.Name, pod.Status)}
+	for _, container := range allContainers(pod) {
+		if container.Health.Level == "" || container.Health.Level == string(types.HealthLevelHealthy) {
+			continue
+		}
+		line := fmt.Sprintf("container %s: %s", containerDisplayName(container), container.Health.Level)
+		if container.Health.Reason != "" {
+			line += " - " + container.Health.Reason
+		}
+		details = append(details, line)
+	}
+	return &junitProblem{
+		Message: pod.Health.Reason,
+		Type:    pod.Health.Level,
+		Details: strings.Join(details, "\n"),
+	}
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestOutputJUnit(t *testing.T) {
+	pod := func(name, level, reason string, containers ...types.ContainerInfo) types.PodInfo {
+		return types.PodInfo{
+			Name:       name,
+			Status:     "Running",
+			Health:     types.HealthStatus{Level: level, Reason: reason},
+			Containers: containers,
+		}
+	}
+	workloads := []types.WorkloadInfo{
+		{
+			Name:      "api",
+			Kind:      "Deployment",
+			Namespace: "staging",
+			Pods: []types.PodInfo{
+				pod("api-1", string(types.HealthLevelHealthy), "all containers healthy"),
+				pod("api-2", string(types.HealthLevelCritical), `container in CrashLoopBackOff & "failing"`,
+					types.ContainerInfo{Name: "app", Health: types.HealthStatus{Level: string(types.HealthLevelCritical), Reason: "exit <137>"}},
+					types.ContainerInfo{Name: "proxy", Health: types.HealthStatus{Level: string(types.HealthLevelHealthy)}}),
+			},
+		},
+		{
+			Name:      "worker",
+			Kind:      "StatefulSet",
+			Namespace: "staging",
+			Pods: []types.PodInfo{
+				pod("worker-0", string(types.HealthLevelDegraded), "high restart count"),
+			},
+		},
+	}
+
+	var output bytes.Buffer
+	formatter := NewWithWriter(&types.Options{OutputFormat: "junit", SortBy: "name"}, &output)
+	if err := formatter.Output(workloads); err != nil {
+		t.Fatalf("Output failed: %v", err)
+	}
+
+	if !strings.HasPrefix(output.String(), xml.Header) {
+		t.Errorf("expected an XML header, got:\n%s", output.String())
+	}
+	if !strings.Contains(output.String(), `message="container in CrashLoopBackOff &amp; &#34;failing&#34;"`) {
+		t.Errorf("expected the failure message to be escaped, got:\n%s", output.String())
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(output.Bytes(), &report); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, output.String())
+	}
+	if report.Tests != 3 || report.Failures != 1 || report.Errors != 1 {
+		t.Errorf("expected 3 tests, 1 failure and 1 error, got %d, %d and %d", report.Tests, report.Failures, report.Errors)
+	}
+	if len(report.Suites) != 2 || report.Suites[0].Name != "staging/deployment/api" || report.Suites[1].Name != "staging/statefulset/worker" {
+		t.Fatalf("unexpected suites: %+v", report.Suites)
+	}
+
+	cases := report.Suites[0].Cases
+	if len(cases) != 2 || cases[0].Failure != nil || cases[0].Error != nil {
+		t.Fatalf("expected the healthy pod to pass, got %+v", cases)
+	}
+	failure := cases[1].Failure
+	if failure == nil || failure.Type != "Critical" {
+		t.Fatalf("expected the critical pod to fail, got %+v", cases[1])
+	}
+	if expected := "pod api-2 is Running\ncontainer app: Critical - exit <137>"; failure.Details != expected {
+		t.Errorf("expected details %q, got %q", expected, failure.Details)
+	}
+	if worker := report.Suites[1].Cases[0]; worker.Error == nil || worker.Error.Message != "high restart count" {
+		t.Errorf("expected the degraded pod to be an error, got %+v", worker)
+	}
+}
@@ -0,0 +1,148 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// topRow is one container's resource usage in the --top table
+type topRow struct {
+	Namespace  string
+	Pod        string
+	Container  string
+	PodAge     time.Duration
+	Restarts   int32
+	HasMetrics bool
+	Resources  types.ResourceInfo
+}
+
+// topRows flattens the workloads' app containers into --top rows, ordered by the --sort key:
+// cpu and memory put the heaviest users first, restarts the most restarted, age the oldest pods,
+// and name (the default) sorts by pod and container
+func topRows(workloads []types.WorkloadInfo, sortBy string) []topRow {
+	var rows []topRow
+	for _, workload := range workloads {
+		for _, pod := range workload.Pods {
+			for _, container := range pod.Containers {
+				rows = append(rows, topRow{
+					Namespace:  pod.Namespace,
+					Pod:        pod.Name,
+					Container:  container.Name,
+					PodAge:     pod.Age,
+					Restarts:   container.RestartCount,
+					HasMetrics: pod.Metrics != nil,
+					Resources:  container.Resources,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		switch types.SortType(sortBy) {
+		case types.SortByCPU:
+			if rows[i].Resources.CPUUsageMilli != rows[j].Resources.CPUUsageMilli {
+				return rows[i].Resources.CPUUsageMilli > rows[j].Resources.CPUUsageMilli
+			}
+		case types.SortByMemory:
+			if rows[i].Resources.MemUsageBytes != rows[j].Resources.MemUsageBytes {
+				return rows[i].Resources.MemUsageBytes > rows[j].Resources.MemUsageBytes
+			}
+		case types.SortByRestarts:
+			if rows[i].Restarts != rows[j].Restarts {
+				return rows[i].Restarts > rows[j].Restarts
+			}
+		case types.SortByAge:
+			if rows[i].PodAge != rows[j].PodAge {
+				return rows[i].PodAge > rows[j].PodAge
+			}
+		}
+		if rows[i].Namespace != rows[j].Namespace {
+			return rows[i].Namespace < rows[j].Namespace
+		}
+		if rows[i].Pod != rows[j].Pod {
+			return rows[i].Pod < rows[j].Pod
+		}
+		return rows[i].Container < rows[j].Container
+	})
+	return rows
+}
+
+// outputTop prints only a resource usage table, one row per container, with the totals in the footer
+func (f *Formatter) outputTop(workloads []types.WorkloadInfo) error {
+	rows := topRows(workloads, f.options.SortBy)
+
+	table := tablewriter.NewWriter(f.out)
+	headers := []string{"POD", "CONTAINER", "CPU", "CPU % LIMIT", "MEMORY", "MEM % LIMIT"}
+	sampled := f.options.Samples > 1
+	if sampled {
+		headers = append(headers, "CPU TREND", "MEM TREND")
+	}
+	if f.options.AllNamespaces {
+		headers = append([]string{"NAMESPACE"}, headers...)
+	}
+	table.SetHeader(headers)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetBorder(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	missing := f.missingUsage(metricsUnavailable(workloads))
+	var totalCPU, totalMemory int64
+	hasMetrics := false
+	for _, row := range rows {
+		cpu, cpuLimit := missing, missing
+		memory, memoryLimit := missing, missing
+		if row.HasMetrics {
+			hasMetrics = true
+			totalCPU += row.Resources.CPUUsageMilli
+			totalMemory += row.Resources.MemUsageBytes
+			cpu = f.formatCPUValue(formatMilliCPU(row.Resources.CPUUsageMilli), row.Resources.CPUUsageMilli)
+			memory = f.formatMemoryValue(formatBytes(row.Resources.MemUsageBytes), row.Resources.MemUsageBytes)
+			cpuLimit = formatLimitPercentage(row.Resources.CPUPercentage, row.Resources.CPULimit)
+			memoryLimit = formatLimitPercentage(row.Resources.MemPercentage, row.Resources.MemLimit)
+		}
+
+		cells := []string{row.Pod, row.Container, cpu, cpuLimit, memory, memoryLimit}
+		if sampled {
+			cells = append(cells, f.formatTrendCell(row.Resources.Samples, false), f.formatTrendCell(row.Resources.Samples, true))
+		}
+		if f.options.AllNamespaces {
+			cells = append([]string{row.Namespace}, cells...)
+		}
+		table.Append(cells)
+	}
+
+	// tablewriter leaves the borders out around empty footer cells, so the percentage columns get a dash
+	footer := []string{"TOTAL", countNoun(len(rows), "container"), missing, "-", missing, "-"}
+	if hasMetrics {
+		footer[2] = f.formatCPUValue(formatMilliCPU(totalCPU), totalCPU)
+		footer[4] = f.formatMemoryValue(formatBytes(totalMemory), totalMemory)
+	}
+	if sampled {
+		footer = append(footer, "-", "-")
+	}
+	if f.options.AllNamespaces {
+		footer = append([]string{"-"}, footer...)
+	}
+	table.SetFooter(footer)
+	table.SetFooterAlignment(tablewriter.ALIGN_LEFT)
+
+	table.Render()
+	f.printMetricsUnavailableNote(workloads)
+	f.printWarnings(f.out, workloads)
+	return nil
+}
+
+// formatLimitPercentage renders usage as a share of the limit, or "-" for containers without one
+func formatLimitPercentage(percentage float64, limit string) string {
+	if limit == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", percentage)
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestOutputTop(t *testing.T) {
+	container := func(name string, milliCPU, memBytes int64, cpuLimit string, cpuPercentage float64) types.ContainerInfo {
+		return types.ContainerInfo{Name: name, Resources: types.ResourceInfo{
+			CPUUsageMilli: milliCPU,
+			MemUsageBytes: memBytes,
+			CPULimit:      cpuLimit,
+			CPUPercentage: cpuPercentage,
+		}}
+	}
+	workloads := []types.WorkloadInfo{{
+		Kind: "Deployment",
+		Name: "api",
+		Pods: []types.PodInfo{
+			{Name: "api-1", Metrics: &types.PodMetrics{}, Containers: []types.ContainerInfo{
+				container("app", 250, 128*1024*1024, "500m", 50),
+				container("proxy", 20, 300*1024*1024, "", 0),
+			}},
+			{Name: "api-2", Metrics: &types.PodMetrics{}, Containers: []types.ContainerInfo{
+				container("app", 900, 64*1024*1024, "1", 90),
+			}},
+		},
+	}}
+
+	tests := []struct {
+		sortBy string
+		order  []string // pod/container in the expected row order
+	}{
+		{sortBy: "cpu", order: []string{"api-2 | app", "api-1 | app", "api-1 | proxy"}},
+		{sortBy: "memory", order: []string{"api-1 | proxy", "api-1 | app", "api-2 | app"}},
+		{sortBy: "name", order: []string{"api-1 | app", "api-1 | proxy", "api-2 | app"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			var output bytes.Buffer
+			f := NewWithWriter(&types.Options{OutputFormat: "table", Top: true, SortBy: tt.sortBy}, &output)
+			if err := f.Output(workloads); err != nil {
+				t.Fatalf("Output() failed: %v", err)
+			}
+			rendered := output.String()
+
+			last := -1
+			for _, row := range tt.order {
+				at := strings.Index(rendered, "| "+row+" ")
+				if at < 0 || at < last {
+					t.Errorf("expected %q after the previous row in:\n%s", row, rendered)
+				}
+				last = at
+			}
+			for _, want := range []string{"| 50%", "| 90%", "| -", "TOTAL", "3 containers", "1.2", "492Mi"} {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("expected %q in:\n%s", want, rendered)
+				}
+			}
+			if strings.Contains(rendered, "HEALTH") || strings.Contains(rendered, "EVENTS") {
+				t.Errorf("expected only the usage table in:\n%s", rendered)
+			}
+		})
+	}
+}
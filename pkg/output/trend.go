@@ -0,0 +1,161 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// trendThreshold is how much usage has to change over the sampled window, relative to its mean, to
+// count as a trend rather than noise
+const trendThreshold = 0.05
+
+type trendDirection string
+
+const (
+	trendIncreasing trendDirection = "increasing"
+	trendDecreasing trendDirection = "decreasing"
+	trendStable     trendDirection = "stable"
+)
+
+// usageTrend summarizes one resource over a --samples series
+type usageTrend struct {
+	Min, Max  int64
+	Direction trendDirection
+}
+
+// trendOf fits a line through the readings and calls it a trend when the fitted change over the window
+// is at least trendThreshold of the mean. A fit weighs every reading, so a noisy first or last reading
+// matters less than it would comparing the two.
+func trendOf(values []int64) usageTrend {
+	trend := usageTrend{Direction: trendStable}
+	if len(values) == 0 {
+		return trend
+	}
+
+	trend.Min, trend.Max = values[0], values[0]
+	var sumX, sumY, sumXY, sumXX float64
+	for i, value := range values {
+		if value < trend.Min {
+			trend.Min = value
+		}
+		if value > trend.Max {
+			trend.Max = value
+		}
+		x, y := float64(i), float64(value)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	n := float64(len(values))
+	mean := sumY / n
+	denominator := n*sumXX - sumX*sumX
+	if len(values) < 2 || mean == 0 || denominator == 0 {
+		return trend
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+	change := slope * (n - 1) / mean
+	switch {
+	case change >= trendThreshold:
+		trend.Direction = trendIncreasing
+	case change <= -trendThreshold:
+		trend.Direction = trendDecreasing
+	}
+	return trend
+}
+
+// sampleTrends returns the CPU and memory trends of a container's samples
+func sampleTrends(samples []types.MetricSample) (cpu, memory usageTrend) {
+	cpuValues := make([]int64, len(samples))
+	memoryValues := make([]int64, len(samples))
+	for i, sample := range samples {
+		cpuValues[i] = sample.CPUMilli
+		memoryValues[i] = sample.MemoryBytes
+	}
+	return trendOf(cpuValues), trendOf(memoryValues)
+}
+
+// formatTrendDirection renders a direction with an arrow. Growing memory is what leaks look like, so it
+// gets the degraded color; CPU moves around too much for a color to mean anything.
+func (f *Formatter) formatTrendDirection(direction trendDirection, memory bool) string {
+	arrow := "→"
+	switch direction {
+	case trendIncreasing:
+		arrow = "↗"
+	case trendDecreasing:
+		arrow = "↘"
+	}
+	text := fmt.Sprintf("%s %s", arrow, direction)
+	if memory && direction == trendIncreasing {
+		return f.getHealthColor(string(types.HealthLevelDegraded)).Sprint(text)
+	}
+	return text
+}
+
+// printUsageTrend prints min, max and direction of a container's usage over its --samples readings
+func (f *Formatter) printUsageTrend(samples []types.MetricSample) {
+	if len(samples) < 2 {
+		return
+	}
+	cpu, memory := sampleTrends(samples)
+	window := samples[len(samples)-1].Time.Sub(samples[0].Time)
+	fmt.Fprintf(f.out, "  • Trend:       %d samples over %s\n", len(samples), f.formatDuration(window))
+	fmt.Fprintf(f.out, "                 CPU: %s, min %s, max %s\n", f.formatTrendDirection(cpu.Direction, false),
+		f.formatCPUValue(formatMilliCPU(cpu.Min), cpu.Min), f.formatCPUValue(formatMilliCPU(cpu.Max), cpu.Max))
+	fmt.Fprintf(f.out, "                 Mem: %s, min %s, max %s\n", f.formatTrendDirection(memory.Direction, true),
+		f.formatMemoryValue(formatBytes(memory.Min), memory.Min), f.formatMemoryValue(formatBytes(memory.Max), memory.Max))
+}
+
+// formatTrendCell renders a trend as a --top cell, e.g. "↗ 200Mi-260Mi", or "-" without samples
+func (f *Formatter) formatTrendCell(samples []types.MetricSample, memory bool) string {
+	if len(samples) < 2 {
+		return "-"
+	}
+	cpu, memoryTrend := sampleTrends(samples)
+	if memory {
+		return fmt.Sprintf("%s %s-%s", f.formatTrendDirection(memoryTrend.Direction, true),
+			f.formatMemoryValue(formatBytes(memoryTrend.Min), memoryTrend.Min), f.formatMemoryValue(formatBytes(memoryTrend.Max), memoryTrend.Max))
+	}
+	return fmt.Sprintf("%s %s-%s", f.formatTrendDirection(cpu.Direction, false),
+		f.formatCPUValue(formatMilliCPU(cpu.Min), cpu.Min), f.formatCPUValue(formatMilliCPU(cpu.Max), cpu.Max))
+}
+
+// formatWorkloadTrend summarizes how one container's usage moved across the workload's sampled pods,
+// e.g. "Mem ↗ increasing in 2 of 3 pods, CPU → stable". It is empty when nothing was sampled.
+func (f *Formatter) formatWorkloadTrend(pods []types.PodInfo, containerName string) string {
+	cpuCounts := make(map[trendDirection]int)
+	memoryCounts := make(map[trendDirection]int)
+	sampled := 0
+	for _, pod := range pods {
+		for _, container := range pod.Containers {
+			if container.Name != containerName || len(container.Resources.Samples) < 2 {
+				continue
+			}
+			cpu, memory := sampleTrends(container.Resources.Samples)
+			cpuCounts[cpu.Direction]++
+			memoryCounts[memory.Direction]++
+			sampled++
+		}
+	}
+	if sampled == 0 {
+		return ""
+	}
+	return f.formatTrendCounts("Mem", memoryCounts, sampled, true) + ", " + f.formatTrendCounts("CPU", cpuCounts, sampled, false)
+}
+
+// formatTrendCounts lists how many pods moved in each direction, leaving stable pods implied
+func (f *Formatter) formatTrendCounts(label string, counts map[trendDirection]int, sampled int, memory bool) string {
+	if counts[trendStable] == sampled {
+		return fmt.Sprintf("%s %s", label, f.formatTrendDirection(trendStable, memory))
+	}
+	var parts []string
+	for _, direction := range []trendDirection{trendIncreasing, trendDecreasing} {
+		if counts[direction] > 0 {
+			parts = append(parts, fmt.Sprintf("%s in %d of %s", f.formatTrendDirection(direction, memory), counts[direction], countNoun(sampled, "pod")))
+		}
+	}
+	return label + " " + strings.Join(parts, ", ")
+}
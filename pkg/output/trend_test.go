@@ -0,0 +1,77 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestTrendOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []int64
+		expected usageTrend
+	}{
+		{name: "climbing", values: []int64{100, 110, 120, 130}, expected: usageTrend{Min: 100, Max: 130, Direction: trendIncreasing}},
+		{name: "falling", values: []int64{130, 120, 110, 100}, expected: usageTrend{Min: 100, Max: 130, Direction: trendDecreasing}},
+		{name: "flat near the limit", values: []int64{500, 502, 499, 501}, expected: usageTrend{Min: 499, Max: 502, Direction: trendStable}},
+		{name: "one spike in the middle", values: []int64{100, 100, 160, 100, 100}, expected: usageTrend{Min: 100, Max: 160, Direction: trendStable}},
+		{name: "single reading", values: []int64{100}, expected: usageTrend{Min: 100, Max: 100, Direction: trendStable}},
+		{name: "idle", values: []int64{0, 0, 0}, expected: usageTrend{Direction: trendStable}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if trend := trendOf(tt.values); trend != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, trend)
+			}
+		})
+	}
+}
+
+func TestPrintUsageTrend(t *testing.T) {
+	start := time.Now()
+	sample := func(offset time.Duration, milliCPU, memoryMi int64) types.MetricSample {
+		return types.MetricSample{Time: start.Add(offset), CPUMilli: milliCPU, MemoryBytes: memoryMi * 1024 * 1024}
+	}
+	samples := []types.MetricSample{
+		sample(0, 120, 200),
+		sample(15*time.Second, 118, 215),
+		sample(30*time.Second, 121, 230),
+		sample(45*time.Second, 119, 245),
+	}
+
+	var output bytes.Buffer
+	formatter := NewWithWriter(&types.Options{NoColor: true}, &output)
+	formatter.printUsageTrend(samples)
+	for _, expected := range []string{
+		"Trend:       4 samples over 45s",
+		"CPU: → stable, min 118m, max 121m",
+		"Mem: ↗ increasing, min 200Mi, max 245Mi",
+	} {
+		if !strings.Contains(output.String(), expected) {
+			t.Errorf("expected %q in:\n%s", expected, output.String())
+		}
+	}
+
+	output.Reset()
+	formatter.printUsageTrend(samples[:1])
+	if output.Len() != 0 {
+		t.Errorf("expected nothing for a single sample, got:\n%s", output.String())
+	}
+
+	pods := []types.PodInfo{
+		{Name: "api-1", Containers: []types.ContainerInfo{{Name: "app", Resources: types.ResourceInfo{Samples: samples}}}},
+		{Name: "api-2", Containers: []types.ContainerInfo{{Name: "app", Resources: types.ResourceInfo{Samples: []types.MetricSample{
+			sample(0, 100, 300), sample(15*time.Second, 100, 300),
+		}}}}},
+	}
+	if trend, expected := formatter.formatWorkloadTrend(pods, "app"), "Mem ↗ increasing in 1 of 2 pods, CPU → stable"; trend != expected {
+		t.Errorf("expected %q, got %q", expected, trend)
+	}
+	if trend := formatter.formatWorkloadTrend(pods, "proxy"); trend != "" {
+		t.Errorf("expected no trend for an unsampled container, got %q", trend)
+	}
+}
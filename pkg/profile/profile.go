@@ -0,0 +1,57 @@
+// Package profile times the phases of a run for --profile
+package profile
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Profile accumulates how long each phase of a run took, for --profile. A nil Profile tracks nothing,
+// so callers don't need to check whether profiling is on.
+type Profile struct {
+	start time.Time
+
+	mu        sync.Mutex
+	phases    []string // Phase names in the order they were first tracked
+	durations map[string]time.Duration
+}
+
+// New starts a profile; the total printed at the end is measured from now
+func New() *Profile {
+	return &Profile{start: time.Now(), durations: make(map[string]time.Duration)}
+}
+
+// Track starts timing a phase and returns the function that stops it. A phase tracked more than once,
+// e.g. pod listing for several workloads, adds up.
+func (p *Profile) Track(phase string) func() {
+	if p == nil {
+		return func() {}
+	}
+	started := time.Now()
+	return func() {
+		elapsed := time.Since(started)
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if _, seen := p.durations[phase]; !seen {
+			p.phases = append(p.phases, phase)
+		}
+		p.durations[phase] += elapsed
+	}
+}
+
+// Print writes the time spent in each phase and the total wall-clock time
+func (p *Profile) Print(w io.Writer) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fmt.Fprintln(w, "Profile:")
+	for _, phase := range p.phases {
+		fmt.Fprintf(w, "  %-12s %8s\n", phase, p.durations[phase].Round(time.Millisecond))
+	}
+	fmt.Fprintf(w, "  %-12s %8s\n", "total", time.Since(p.start).Round(time.Millisecond))
+}
@@ -0,0 +1,46 @@
+package profile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfile(t *testing.T) {
+	profile := New()
+
+	stop := profile.Track("resolve")
+	time.Sleep(2 * time.Millisecond)
+	stop()
+	for i := 0; i < 2; i++ {
+		stop := profile.Track("metrics")
+		time.Sleep(2 * time.Millisecond)
+		stop()
+	}
+
+	if profile.durations["metrics"] < 4*time.Millisecond {
+		t.Errorf("expected repeated phases to add up, got %s", profile.durations["metrics"])
+	}
+
+	var out bytes.Buffer
+	profile.Print(&out)
+	printed := out.String()
+	resolveAt := strings.Index(printed, "resolve")
+	metricsAt := strings.Index(printed, "metrics")
+	totalAt := strings.Index(printed, "total")
+	if resolveAt < 0 || metricsAt < resolveAt || totalAt < metricsAt {
+		t.Errorf("expected phases in the order they ran, then the total:\n%s", printed)
+	}
+}
+
+func TestNilProfile(t *testing.T) {
+	var profile *Profile
+	profile.Track("resolve")()
+
+	var out bytes.Buffer
+	profile.Print(&out)
+	if out.Len() != 0 {
+		t.Errorf("expected a nil profile to print nothing, got %q", out.String())
+	}
+}
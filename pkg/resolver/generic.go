@@ -0,0 +1,101 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/nareshku/kubectl-container-status/pkg/collector"
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// UseDynamic lets the resolver handle resource/name arguments for any type the API server serves,
+// not just the built-in workload kinds. The mapper translates names like "rs" or "rollouts.argoproj.io"
+// into resources.
+func (r *Resolver) UseDynamic(dynamicClient dynamic.Interface, mapper meta.RESTMapper) {
+	r.dynamicClient = dynamicClient
+	r.mapper = mapper
+}
+
+// resolveGeneric resolves a resource/name argument of any registered type. The object's pod selector
+// is used when it has one; otherwise its pods are found through their owner references.
+func (r *Resolver) resolveGeneric(ctx context.Context, options *types.Options) ([]types.WorkloadInfo, error) {
+	gvr, err := r.mapper.ResourceFor(schema.ParseGroupResource(options.ResourceType).WithVersion(""))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported resource type %s: %w", options.ResourceType, err)
+	}
+	gvk, err := r.mapper.KindFor(gvr)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported resource type %s: %w", options.ResourceType, err)
+	}
+	mapping, err := r.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported resource type %s: %w", options.ResourceType, err)
+	}
+
+	var resourceClient dynamic.ResourceInterface = r.dynamicClient.Resource(gvr)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		resourceClient = r.dynamicClient.Resource(gvr).Namespace(options.Namespace)
+	}
+	obj, err := resourceClient.Get(ctx, options.ResourceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", gvr.Resource, err)
+	}
+
+	selector := podSelector(obj)
+	if len(selector) == 0 {
+		// Owners without a plain label selector (e.g. only matchExpressions) are matched by owner reference
+		ownerOptions := *options
+		ownerOptions.OwnerKind = gvk.Kind
+		return r.resolveByOwner(ctx, &ownerOptions)
+	}
+
+	pods, err := collector.ListPods(ctx, r.clientset.CoreV1().Pods(options.Namespace), metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(selector).String(),
+	}, options.ChunkSize)
+	if err != nil {
+		return nil, collector.PodListError(err, options.Namespace)
+	}
+
+	workload := types.WorkloadInfo{
+		Name:      obj.GetName(),
+		Kind:      gvk.Kind,
+		Namespace: options.Namespace,
+		Replicas:  readyReplicas(pods),
+		Labels:    obj.GetLabels(),
+		Selector:  selector,
+	}
+	return []types.WorkloadInfo{workload}, nil
+}
+
+// podSelector reads spec.selector from an arbitrary object, accepting both label selectors
+// (matchLabels) and the plain label maps used by ReplicationControllers. Selectors that use
+// matchExpressions can't be expressed as a label map, so they yield nil.
+func podSelector(obj *unstructured.Unstructured) map[string]string {
+	selector, found, err := unstructured.NestedMap(obj.Object, "spec", "selector")
+	if err != nil || !found {
+		return nil
+	}
+	if _, hasExpressions := selector["matchExpressions"]; hasExpressions {
+		return nil
+	}
+	if matchLabels, ok := selector["matchLabels"].(map[string]interface{}); ok {
+		selector = matchLabels
+	}
+
+	result := make(map[string]string, len(selector))
+	for key, value := range selector {
+		text, ok := value.(string)
+		if !ok {
+			return nil
+		}
+		result[key] = text
+	}
+	return result
+}
@@ -0,0 +1,145 @@
+package resolver
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestPodSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     map[string]interface{}
+		expected map[string]string
+	}{
+		{
+			name:     "label selector",
+			spec:     map[string]interface{}{"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web"}}},
+			expected: map[string]string{"app": "web"},
+		},
+		{
+			name:     "plain label map",
+			spec:     map[string]interface{}{"selector": map[string]interface{}{"app": "web", "tier": "frontend"}},
+			expected: map[string]string{"app": "web", "tier": "frontend"},
+		},
+		{
+			name: "match expressions",
+			spec: map[string]interface{}{"selector": map[string]interface{}{
+				"matchLabels":      map[string]interface{}{"app": "web"},
+				"matchExpressions": []interface{}{map[string]interface{}{"key": "tier", "operator": "Exists"}},
+			}},
+			expected: nil,
+		},
+		{
+			name:     "no selector",
+			spec:     map[string]interface{}{"schedule": "*/5 * * * *"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": tt.spec}}
+			if got := podSelector(obj); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("podSelector() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveGeneric(t *testing.T) {
+	rolloutKind := schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}
+	poolKind := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "WorkerPool"}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{rolloutKind.GroupVersion(), poolKind.GroupVersion()})
+	mapper.Add(rolloutKind, meta.RESTScopeNamespace)
+	mapper.Add(poolKind, meta.RESTScopeRoot)
+
+	object := func(gvk schema.GroupVersionKind, namespace, name string, selector map[string]interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{"selector": selector}}}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		object(rolloutKind, "default", "web", map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web"}}),
+		object(rolloutKind, "default", "canary", map[string]interface{}{
+			"matchExpressions": []interface{}{map[string]interface{}{"key": "track", "operator": "Exists"}},
+		}),
+		object(poolKind, "", "workers", map[string]interface{}{"matchLabels": map[string]interface{}{"pool": "workers"}}),
+	)
+
+	controller := true
+	canaryReplicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "canary-5c8d",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Rollout", Name: "canary", Controller: &controller}},
+		},
+	}
+	pod := func(name string, labels map[string]string, owner string) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+		if owner != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner, Controller: &controller}}
+		}
+		return pod
+	}
+	clientset := fake.NewSimpleClientset(canaryReplicaSet,
+		pod("web-1", map[string]string{"app": "web"}, ""),
+		pod("worker-1", map[string]string{"pool": "workers"}, ""),
+		pod("canary-5c8d-a", map[string]string{"track": "a"}, "canary-5c8d"),
+		pod("canary-5c8d-b", map[string]string{"track": "b"}, "canary-5c8d"),
+		pod("db-0", map[string]string{"app": "db"}, ""),
+	)
+
+	tests := []struct {
+		name         string
+		resourceType string
+		resourceName string
+		expectedKind string
+		expectedPods []string
+	}{
+		{name: "namespaced with label selector", resourceType: "rollouts", resourceName: "web", expectedKind: "Rollout", expectedPods: []string{"web-1"}},
+		{name: "cluster scoped", resourceType: "workerpools.example.com", resourceName: "workers", expectedKind: "WorkerPool", expectedPods: []string{"worker-1"}},
+		{name: "match expressions fall back to owner references", resourceType: "rollout", resourceName: "canary", expectedKind: "Rollout", expectedPods: []string{"canary-5c8d-a", "canary-5c8d-b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(clientset)
+			r.UseDynamic(dynamicClient, mapper)
+			options := &types.Options{Namespace: "default", ResourceType: tt.resourceType, ResourceName: tt.resourceName}
+
+			workloads, err := r.Resolve(context.Background(), options)
+			if err != nil {
+				t.Fatalf("resolve failed: %v", err)
+			}
+			if len(workloads) != 1 || workloads[0].Kind != tt.expectedKind || workloads[0].Name != tt.resourceName {
+				t.Fatalf("expected %s/%s, got %+v", tt.expectedKind, tt.resourceName, workloads)
+			}
+			if got := collectedPodNames(t, clientset, workloads, options); !reflect.DeepEqual(got, tt.expectedPods) {
+				t.Errorf("expected pods %v, got %v", tt.expectedPods, got)
+			}
+		})
+	}
+
+	r := New(clientset)
+	r.UseDynamic(dynamicClient, mapper)
+	_, err := r.Resolve(context.Background(), &types.Options{Namespace: "default", ResourceType: "widgets", ResourceName: "web"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported resource type widgets") {
+		t.Errorf("expected an unsupported resource type error, got %v", err)
+	}
+}
@@ -6,18 +6,29 @@ import (
 	"strings"
 
 	multierror "github.com/hashicorp/go-multierror"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/nareshku/kubectl-container-status/pkg/collector"
 	"github.com/nareshku/kubectl-container-status/pkg/types"
 )
 
 // Resolver handles resource resolution and auto-detection
 type Resolver struct {
 	clientset kubernetes.Interface
+
+	// Optional, for resolving resource types that aren't special-cased (see UseDynamic)
+	dynamicClient dynamic.Interface
+	mapper        meta.RESTMapper
+
+	// ReplicaSets looked up while mapping pods to their Deployments, by namespace/name, nil when unreadable
+	replicaSets map[string]*appsv1.ReplicaSet
 }
 
 // New creates a new resolver instance
@@ -29,7 +40,11 @@ func New(clientset kubernetes.Interface) *Resolver {
 
 // Resolve resolves the resource specification to workload information
 func (r *Resolver) Resolve(ctx context.Context, options *types.Options) ([]types.WorkloadInfo, error) {
-	if options.Selector != "" {
+	// Owners may have changed since the last run, e.g. between watch refreshes
+	r.replicaSets = nil
+
+	// A summary or field selector without an explicit target covers every pod in scope
+	if options.Selector != "" || (options.ResourceName == "" && (options.Summary || options.FieldSelector != "")) {
 		return r.resolveBySelector(ctx, options)
 	}
 
@@ -37,6 +52,10 @@ func (r *Resolver) Resolve(ctx context.Context, options *types.Options) ([]types
 		return nil, fmt.Errorf("resource name is required")
 	}
 
+	if options.OwnerKind != "" {
+		return r.resolveByOwner(ctx, options)
+	}
+
 	if options.ResourceType == "" {
 		// Auto-detect resource type
 		return r.autoDetectAndResolve(ctx, options)
@@ -48,9 +67,9 @@ func (r *Resolver) Resolve(ctx context.Context, options *types.Options) ([]types
 
 // resolveBySelector resolves resources using label selector
 func (r *Resolver) resolveBySelector(ctx context.Context, options *types.Options) ([]types.WorkloadInfo, error) {
-	selector, err := labels.Parse(options.Selector)
+	selector, err := ParseSelector(options.Selector)
 	if err != nil {
-		return nil, fmt.Errorf("invalid selector: %w", err)
+		return nil, err
 	}
 
 	namespace := options.Namespace
@@ -59,22 +78,30 @@ func (r *Resolver) resolveBySelector(ctx context.Context, options *types.Options
 	}
 
 	// Get pods matching the selector
-	pods, err := r.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+	pods, err := collector.ListPods(ctx, r.clientset.CoreV1().Pods(namespace), metav1.ListOptions{
 		LabelSelector: selector.String(),
-	})
+		FieldSelector: options.FieldSelector,
+	}, options.ChunkSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+		if options.FieldSelector != "" && apierrors.IsBadRequest(err) {
+			return nil, fmt.Errorf("invalid field selector %q: %w", options.FieldSelector, err)
+		}
+		return nil, collector.PodListError(err, namespace)
 	}
 
-	if len(pods.Items) == 0 {
+	if len(pods) == 0 {
+		if options.FieldSelector != "" {
+			return nil, fmt.Errorf("no pods found matching selector %s and field selector %s", options.Selector, options.FieldSelector)
+		}
 		return nil, fmt.Errorf("no pods found matching selector %s", options.Selector)
 	}
 
 	// Group pods by owner
 	workloadMap := make(map[string]*types.WorkloadInfo)
+	workloadPods := make(map[string][]corev1.Pod)
 
-	for _, pod := range pods.Items {
-		workload := r.getWorkloadFromPod(&pod)
+	for _, pod := range pods {
+		workload := r.getWorkloadFromPod(ctx, &pod)
 		if workload == nil {
 			// Standalone pod
 			key := fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name)
@@ -92,36 +119,48 @@ func (r *Resolver) resolveBySelector(ctx context.Context, options *types.Options
 			} else {
 				workloadMap[key] = workload
 			}
+			workloadPods[key] = append(workloadPods[key], pod)
 		}
 	}
 
-	// If we have multiple workloads, return them as is
-	if len(workloadMap) > 1 {
-		var workloads []types.WorkloadInfo
-		for _, workload := range workloadMap {
-			workloads = append(workloads, *workload)
+	// --owner-kind narrows the matches down to workloads of one kind
+	if options.OwnerKind != "" {
+		for key, workload := range workloadMap {
+			if !strings.EqualFold(workload.Kind, options.OwnerKind) {
+				delete(workloadMap, key)
+			}
+		}
+		if len(workloadMap) == 0 {
+			return nil, fmt.Errorf("none of the matching pods are owned by a %s", options.OwnerKind)
 		}
-		return workloads, nil
 	}
 
-	// Create a new workload that represents the selector
-	// Parse the selector string to extract key-value pairs
-	selectorMap := make(map[string]string)
-	if requirements, selectable := selector.Requirements(); selectable {
-		for _, req := range requirements {
-			if req.Operator() == selection.Equals || req.Operator() == selection.In {
-				selectorMap[req.Key()] = req.Values().List()[0]
+	// If we have multiple workloads, or filtered by kind, return them as is. Each owner keeps the
+	// user's selector and only its matched pods, so "track=canary" doesn't pull in the stable pods.
+	if len(workloadMap) > 1 || options.OwnerKind != "" {
+		var workloads []types.WorkloadInfo
+		for key, workload := range workloadMap {
+			resolved := *workload
+			if resolved.Kind != "Pod" {
+				resolved.LabelSelector = selector.String()
+				resolved.PodNames = podNames(workloadPods[key])
+				resolved.Replicas = readyReplicas(workloadPods[key])
 			}
+			workloads = append(workloads, resolved)
 		}
+		return workloads, nil
 	}
 
+	// Create a new workload that represents the selector, keeping set-based requirements such as
+	// "tier in (web,api)" or "tier!=db" so that collection lists the same pods as above
 	selectorWorkload := &types.WorkloadInfo{
 		Name:      fmt.Sprintf("selector:%s", options.Selector),
 		Kind:      "Selector",
 		Namespace: namespace,
-		Replicas:  fmt.Sprintf("%d/%d", len(pods.Items), len(pods.Items)),
+		Replicas:  fmt.Sprintf("%d/%d", len(pods), len(pods)),
 		Labels:    make(map[string]string),
-		Selector:  selectorMap,
+
+		LabelSelector: selector.String(),
 	}
 
 	return []types.WorkloadInfo{*selectorWorkload}, nil
@@ -158,6 +197,9 @@ func (r *Resolver) autoDetectAndResolve(ctx context.Context, options *types.Opti
 			Replicas:  fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, deployment.Status.Replicas),
 			Labels:    deployment.Labels,
 			Selector:  deployment.Spec.Selector.MatchLabels,
+
+			TemplateImages:   templateImages(deployment.Spec.Template),
+			TemplateRevision: r.deploymentRevision(ctx, deployment),
 		}
 		return []types.WorkloadInfo{*workload}, nil
 	} else {
@@ -173,6 +215,9 @@ func (r *Resolver) autoDetectAndResolve(ctx context.Context, options *types.Opti
 			Replicas:  fmt.Sprintf("%d/%d", statefulset.Status.ReadyReplicas, statefulset.Status.Replicas),
 			Labels:    statefulset.Labels,
 			Selector:  statefulset.Spec.Selector.MatchLabels,
+
+			TemplateImages:   templateImages(statefulset.Spec.Template),
+			TemplateRevision: statefulset.Status.UpdateRevision,
 		}
 		return []types.WorkloadInfo{*workload}, nil
 	} else {
@@ -188,6 +233,9 @@ func (r *Resolver) autoDetectAndResolve(ctx context.Context, options *types.Opti
 			Replicas:  fmt.Sprintf("%d/%d", daemonset.Status.NumberReady, daemonset.Status.DesiredNumberScheduled),
 			Labels:    daemonset.Labels,
 			Selector:  daemonset.Spec.Selector.MatchLabels,
+
+			TemplateImages:   templateImages(daemonset.Spec.Template),
+			TemplateRevision: r.daemonSetRevision(ctx, daemonset),
 		}
 		return []types.WorkloadInfo{*workload}, nil
 	} else {
@@ -196,20 +244,30 @@ func (r *Resolver) autoDetectAndResolve(ctx context.Context, options *types.Opti
 
 	// Try Job
 	if job, err := r.clientset.BatchV1().Jobs(namespace).Get(ctx, resourceName, metav1.GetOptions{}); err == nil {
+		// Work-queue Jobs leave completions unset, which jobInfo defaults
+		info := jobInfo(job)
 		workload := &types.WorkloadInfo{
 			Name:      job.Name,
 			Kind:      "Job",
 			Namespace: job.Namespace,
-			Replicas:  fmt.Sprintf("%d/%d", job.Status.Succeeded, *job.Spec.Completions),
+			Replicas:  fmt.Sprintf("%d/%d", job.Status.Succeeded, info.Completions),
 			Labels:    job.Labels,
 			Selector:  job.Spec.Selector.MatchLabels,
+			Job:       info,
 		}
 		return []types.WorkloadInfo{*workload}, nil
 	} else {
 		errs = multierror.Append(errs, err)
 	}
 
-	return nil, fmt.Errorf("resource '%s' not found as Pod, Deployment, StatefulSet, DaemonSet, or Job: %w", resourceName, errs)
+	// Try Service last, since a Service commonly shares its name with the workload behind it
+	if service, err := r.clientset.CoreV1().Services(namespace).Get(ctx, resourceName, metav1.GetOptions{}); err == nil {
+		return r.serviceWorkloads(ctx, service, options)
+	} else {
+		errs = multierror.Append(errs, err)
+	}
+
+	return nil, fmt.Errorf("resource '%s' not found as Pod, Deployment, StatefulSet, DaemonSet, Job, or Service: %w", resourceName, errs)
 }
 
 // resolveByType resolves resource by explicit type
@@ -246,6 +304,9 @@ func (r *Resolver) resolveByType(ctx context.Context, options *types.Options) ([
 			Replicas:  fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, deployment.Status.Replicas),
 			Labels:    deployment.Labels,
 			Selector:  deployment.Spec.Selector.MatchLabels,
+
+			TemplateImages:   templateImages(deployment.Spec.Template),
+			TemplateRevision: r.deploymentRevision(ctx, deployment),
 		}
 		return []types.WorkloadInfo{*workload}, nil
 
@@ -261,6 +322,9 @@ func (r *Resolver) resolveByType(ctx context.Context, options *types.Options) ([
 			Replicas:  fmt.Sprintf("%d/%d", statefulset.Status.ReadyReplicas, statefulset.Status.Replicas),
 			Labels:    statefulset.Labels,
 			Selector:  statefulset.Spec.Selector.MatchLabels,
+
+			TemplateImages:   templateImages(statefulset.Spec.Template),
+			TemplateRevision: statefulset.Status.UpdateRevision,
 		}
 		return []types.WorkloadInfo{*workload}, nil
 
@@ -276,6 +340,9 @@ func (r *Resolver) resolveByType(ctx context.Context, options *types.Options) ([
 			Replicas:  fmt.Sprintf("%d/%d", daemonset.Status.NumberReady, daemonset.Status.DesiredNumberScheduled),
 			Labels:    daemonset.Labels,
 			Selector:  daemonset.Spec.Selector.MatchLabels,
+
+			TemplateImages:   templateImages(daemonset.Spec.Template),
+			TemplateRevision: r.daemonSetRevision(ctx, daemonset),
 		}
 		return []types.WorkloadInfo{*workload}, nil
 
@@ -284,32 +351,236 @@ func (r *Resolver) resolveByType(ctx context.Context, options *types.Options) ([
 		if err != nil {
 			return nil, fmt.Errorf("failed to get job: %w", err)
 		}
-		completions := int32(1)
-		if job.Spec.Completions != nil {
-			completions = *job.Spec.Completions
-		}
+		info := jobInfo(job)
 		workload := &types.WorkloadInfo{
 			Name:      job.Name,
 			Kind:      "Job",
 			Namespace: job.Namespace,
-			Replicas:  fmt.Sprintf("%d/%d", job.Status.Succeeded, completions),
+			Replicas:  fmt.Sprintf("%d/%d", job.Status.Succeeded, info.Completions),
 			Labels:    job.Labels,
 			Selector:  job.Spec.Selector.MatchLabels,
+			Job:       info,
 		}
 		return []types.WorkloadInfo{*workload}, nil
 
+	case "service", "services", "svc":
+		return r.resolveService(ctx, options)
+
 	default:
-		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+		if r.mapper == nil {
+			return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+		}
+		return r.resolveGeneric(ctx, options)
 	}
 }
 
+// templateImages maps each container in a controller's pod template to its image, so pods still
+// running an older template can be told apart during a rollout
+func templateImages(template corev1.PodTemplateSpec) map[string]string {
+	images := make(map[string]string)
+	for _, container := range template.Spec.InitContainers {
+		images[container.Name] = container.Image
+	}
+	for _, container := range template.Spec.Containers {
+		images[container.Name] = container.Image
+	}
+	return images
+}
+
+// deploymentRevisionAnnotation numbers a Deployment's rollouts; its current ReplicaSet carries the same number
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// deploymentRevision returns the pod-template-hash of the Deployment's current ReplicaSet, the one its pods
+// are labeled with once they run the latest template. It is best effort and returns "" when the ReplicaSets
+// can't be listed or the current one isn't found.
+func (r *Resolver) deploymentRevision(ctx context.Context, deployment *appsv1.Deployment) string {
+	revision := deployment.Annotations[deploymentRevisionAnnotation]
+	if revision == "" || deployment.Spec.Selector == nil {
+		return ""
+	}
+	replicaSets, err := r.clientset.AppsV1().ReplicaSets(deployment.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+	})
+	if err != nil {
+		return ""
+	}
+	for _, rs := range replicaSets.Items {
+		if controlledBy(&rs, "Deployment", deployment.Name) && rs.Annotations[deploymentRevisionAnnotation] == revision {
+			return rs.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+		}
+	}
+	return ""
+}
+
+// daemonSetRevision returns the controller-revision-hash of the DaemonSet's newest ControllerRevision, the
+// one its pods are labeled with once they run the latest template. Like deploymentRevision it is best effort.
+func (r *Resolver) daemonSetRevision(ctx context.Context, daemonset *appsv1.DaemonSet) string {
+	if daemonset.Spec.Selector == nil {
+		return ""
+	}
+	revisions, err := r.clientset.AppsV1().ControllerRevisions(daemonset.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(daemonset.Spec.Selector),
+	})
+	if err != nil {
+		return ""
+	}
+	var newest *appsv1.ControllerRevision
+	for i := range revisions.Items {
+		revision := &revisions.Items[i]
+		if controlledBy(revision, "DaemonSet", daemonset.Name) && (newest == nil || revision.Revision > newest.Revision) {
+			newest = revision
+		}
+	}
+	if newest == nil {
+		return ""
+	}
+	return newest.Labels[appsv1.DefaultDaemonSetUniqueLabelKey]
+}
+
+// controlledBy reports whether the object's controller is the kind and name given
+func controlledBy(object metav1.Object, kind, name string) bool {
+	controller := metav1.GetControllerOf(object)
+	return controller != nil && controller.Kind == kind && controller.Name == name
+}
+
+// jobInfo collects the Job settings that decide how it retries and when it gives up, filling in API defaults
+func jobInfo(job *batchv1.Job) *types.JobInfo {
+	info := &types.JobInfo{
+		Completions:           1,
+		Parallelism:           1,
+		Succeeded:             job.Status.Succeeded,
+		Failed:                job.Status.Failed,
+		BackoffLimit:          6,
+		ActiveDeadlineSeconds: job.Spec.ActiveDeadlineSeconds,
+		RestartPolicy:         string(job.Spec.Template.Spec.RestartPolicy),
+		Indexed:               job.Spec.CompletionMode != nil && *job.Spec.CompletionMode == batchv1.IndexedCompletion,
+	}
+	if job.Spec.Completions != nil {
+		info.Completions = *job.Spec.Completions
+	}
+	if job.Spec.Parallelism != nil {
+		info.Parallelism = *job.Spec.Parallelism
+	}
+	if job.Spec.BackoffLimit != nil {
+		info.BackoffLimit = *job.Spec.BackoffLimit
+	}
+	return info
+}
+
+// resolveByOwner resolves the pods whose owning workload has the kind given by --owner-kind and the
+// requested name. This covers custom resource workloads (e.g. Argo Rollouts) that can't be fetched with
+// the built-in clients, and disambiguates names shared by several kinds. The owned pods are carried by
+// name, since the labels they share may match other pods too, or be none at all.
+func (r *Resolver) resolveByOwner(ctx context.Context, options *types.Options) ([]types.WorkloadInfo, error) {
+	namespace := options.Namespace
+
+	pods, err := collector.ListPods(ctx, r.clientset.CoreV1().Pods(namespace), metav1.ListOptions{
+		FieldSelector: options.FieldSelector,
+	}, options.ChunkSize)
+	if err != nil {
+		return nil, collector.PodListError(err, namespace)
+	}
+
+	var owned []corev1.Pod
+	var workload *types.WorkloadInfo
+	for _, pod := range pods {
+		owner := r.getWorkloadFromPod(ctx, &pod)
+		if owner == nil || !strings.EqualFold(owner.Kind, options.OwnerKind) || owner.Name != options.ResourceName {
+			continue
+		}
+		if workload == nil {
+			workload = owner
+		}
+		owned = append(owned, pod)
+	}
+
+	if workload == nil {
+		return nil, fmt.Errorf("no pods found owned by %s '%s'", options.OwnerKind, options.ResourceName)
+	}
+
+	workload.Labels = nil
+	workload.Selector = commonPodLabels(owned)
+	workload.PodNames = podNames(owned)
+	workload.Replicas = readyReplicas(owned)
+	return []types.WorkloadInfo{*workload}, nil
+}
+
+// commonPodLabels returns the labels shared by all pods, leaving out per-revision hashes so that pods
+// from every revision of the owner are selected. It is nil when the pods share no labels, rather than an
+// empty selector that would match every pod.
+func commonPodLabels(pods []corev1.Pod) map[string]string {
+	if len(pods) == 0 {
+		return nil
+	}
+
+	common := make(map[string]string)
+	for key, value := range pods[0].Labels {
+		if key == appsv1.DefaultDeploymentUniqueLabelKey || key == appsv1.ControllerRevisionHashLabelKey || key == "rollouts-pod-template-hash" {
+			continue
+		}
+		common[key] = value
+	}
+	for _, pod := range pods[1:] {
+		for key, value := range common {
+			if pod.Labels[key] != value {
+				delete(common, key)
+			}
+		}
+	}
+	if len(common) == 0 {
+		return nil
+	}
+	return common
+}
+
+// podNames returns the names of the pods
+func podNames(pods []corev1.Pod) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+// readyReplicas formats the number of ready pods out of the total, like the built-in workload replica counts
+func readyReplicas(pods []corev1.Pod) string {
+	ready := 0
+	for _, pod := range pods {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				ready++
+				break
+			}
+		}
+	}
+	return fmt.Sprintf("%d/%d", ready, len(pods))
+}
+
+// replicaSet gets a ReplicaSet, or nil when it can't be read. Every pod of a Deployment revision is owned
+// by the same ReplicaSet, so lookups are cached for the rest of the run.
+func (r *Resolver) replicaSet(ctx context.Context, namespace, name string) *appsv1.ReplicaSet {
+	key := namespace + "/" + name
+	if rs, cached := r.replicaSets[key]; cached {
+		return rs
+	}
+	if r.replicaSets == nil {
+		r.replicaSets = make(map[string]*appsv1.ReplicaSet)
+	}
+	rs, err := r.clientset.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		rs = nil
+	}
+	r.replicaSets[key] = rs
+	return rs
+}
+
 // getWorkloadFromPod extracts workload information from a pod's owner references
-func (r *Resolver) getWorkloadFromPod(pod *corev1.Pod) *types.WorkloadInfo {
+func (r *Resolver) getWorkloadFromPod(ctx context.Context, pod *corev1.Pod) *types.WorkloadInfo {
 	for _, owner := range pod.OwnerReferences {
 		switch owner.Kind {
 		case "ReplicaSet":
 			// For ReplicaSet, we need to check if it's owned by a Deployment
-			if rs, err := r.clientset.AppsV1().ReplicaSets(pod.Namespace).Get(context.Background(), owner.Name, metav1.GetOptions{}); err == nil {
+			if rs := r.replicaSet(ctx, pod.Namespace, owner.Name); rs != nil {
 				for _, rsOwner := range rs.OwnerReferences {
 					if rsOwner.Kind == "Deployment" {
 						return &types.WorkloadInfo{
@@ -320,6 +591,17 @@ func (r *Resolver) getWorkloadFromPod(pod *corev1.Pod) *types.WorkloadInfo {
 						}
 					}
 				}
+				// ReplicaSets managed by a custom controller (e.g. an Argo Rollout) belong to that resource
+				for _, rsOwner := range rs.OwnerReferences {
+					if rsOwner.Controller != nil && *rsOwner.Controller {
+						return &types.WorkloadInfo{
+							Name:      rsOwner.Name,
+							Kind:      rsOwner.Kind,
+							Namespace: pod.Namespace,
+							Labels:    pod.Labels,
+						}
+					}
+				}
 			}
 		case "StatefulSet":
 			return &types.WorkloadInfo{
@@ -342,6 +624,16 @@ func (r *Resolver) getWorkloadFromPod(pod *corev1.Pod) *types.WorkloadInfo {
 				Namespace: pod.Namespace,
 				Labels:    pod.Labels,
 			}
+		default:
+			// Unknown (custom resource) controller: record it as the workload without fetching it
+			if owner.Controller != nil && *owner.Controller {
+				return &types.WorkloadInfo{
+					Name:      owner.Name,
+					Kind:      owner.Kind,
+					Namespace: pod.Namespace,
+					Labels:    pod.Labels,
+				}
+			}
 		}
 	}
 	return nil
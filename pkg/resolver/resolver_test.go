@@ -0,0 +1,424 @@
+package resolver
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nareshku/kubectl-container-status/pkg/collector"
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestResolveCarriesTemplateImages(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "migrate", Image: "api-migrate:1.3"}},
+					Containers:     []corev1.Container{{Name: "app", Image: "api:1.3"}},
+				},
+			},
+		},
+	}
+	r := New(fake.NewSimpleClientset(deployment))
+	expected := map[string]string{"migrate": "api-migrate:1.3", "app": "api:1.3"}
+
+	for _, resourceType := range []string{"", "deployment"} {
+		workloads, err := r.Resolve(context.Background(), &types.Options{Namespace: "default", ResourceName: "api", ResourceType: resourceType})
+		if err != nil {
+			t.Fatalf("resolve %q failed: %v", resourceType, err)
+		}
+		if len(workloads) != 1 || !reflect.DeepEqual(workloads[0].TemplateImages, expected) {
+			t.Errorf("resolve %q: expected template images %v, got %+v", resourceType, expected, workloads)
+		}
+	}
+}
+
+func TestResolveTemplateRevision(t *testing.T) {
+	controller := true
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}
+	owned := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}}
+	}
+	replicaSet := func(name, hash, revision string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			Labels:          map[string]string{"app": "api", appsv1.DefaultDeploymentUniqueLabelKey: hash},
+			Annotations:     map[string]string{deploymentRevisionAnnotation: revision},
+			OwnerReferences: owned("Deployment", "api"),
+		}}
+	}
+	controllerRevision := func(name, hash string, revision int64) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				Labels:          map[string]string{"app": "api", appsv1.DefaultDaemonSetUniqueLabelKey: hash},
+				OwnerReferences: owned("DaemonSet", "agent"),
+			},
+			Revision: revision,
+		}
+	}
+
+	clientset := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", Annotations: map[string]string{deploymentRevisionAnnotation: "3"}},
+			Spec:       appsv1.DeploymentSpec{Selector: selector},
+		},
+		replicaSet("api-5c8b", "5c8b", "2"),
+		replicaSet("api-7d9f", "7d9f", "3"),
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Selector: selector},
+			Status:     appsv1.StatefulSetStatus{CurrentRevision: "db-6f4d", UpdateRevision: "db-8a2c"},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       appsv1.DaemonSetSpec{Selector: selector},
+		},
+		controllerRevision("agent-6b7c", "6b7c", 1),
+		controllerRevision("agent-9e1f", "9e1f", 2),
+	)
+	r := New(clientset)
+
+	tests := []struct {
+		resourceType string
+		name         string
+		expected     string
+	}{
+		{"deployment", "api", "7d9f"},
+		{"statefulset", "db", "db-8a2c"},
+		{"daemonset", "agent", "9e1f"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resourceType, func(t *testing.T) {
+			workloads, err := r.Resolve(context.Background(), &types.Options{Namespace: "default", ResourceName: tt.name, ResourceType: tt.resourceType})
+			if err != nil {
+				t.Fatalf("resolve failed: %v", err)
+			}
+			if len(workloads) != 1 || workloads[0].TemplateRevision != tt.expected {
+				t.Errorf("expected template revision %q, got %+v", tt.expected, workloads)
+			}
+		})
+	}
+}
+
+func TestResolveBySelectorFiltersOwnerKind(t *testing.T) {
+	controller := true
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-7d9f",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &controller}},
+		},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+	managed := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-7d9f-abcde",
+			Namespace:       "default",
+			Labels:          map[string]string{"app": "web"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-7d9f", Controller: &controller}},
+		},
+	}
+	debug := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-debug", Namespace: "default", Labels: map[string]string{"app": "web"}},
+	}
+	r := New(fake.NewSimpleClientset(deployment, replicaSet, managed, debug))
+
+	tests := []struct {
+		ownerKind string
+		expected  string
+	}{
+		{ownerKind: "Deployment", expected: "Deployment/web"},
+		{ownerKind: "pod", expected: "Pod/web-debug"},
+	}
+
+	for _, tt := range tests {
+		workloads, err := r.Resolve(context.Background(), &types.Options{Namespace: "default", Selector: "app=web", OwnerKind: tt.ownerKind})
+		if err != nil {
+			t.Fatalf("owner kind %q: resolve failed: %v", tt.ownerKind, err)
+		}
+		if len(workloads) != 1 || workloads[0].Kind+"/"+workloads[0].Name != tt.expected {
+			t.Errorf("owner kind %q: expected only %s, got %+v", tt.ownerKind, tt.expected, workloads)
+		}
+	}
+
+	if _, err := r.Resolve(context.Background(), &types.Options{Namespace: "default", Selector: "app=web", OwnerKind: "StatefulSet"}); err == nil {
+		t.Error("expected an error when no workload has the requested kind")
+	}
+}
+
+func TestResolveIndexedJob(t *testing.T) {
+	indexed := batchv1.IndexedCompletion
+	nonIndexed := batchv1.NonIndexedCompletion
+
+	tests := []struct {
+		name     string
+		mode     *batchv1.CompletionMode
+		expected bool
+	}{
+		{"indexed", &indexed, true},
+		{"non-indexed", &nonIndexed, false},
+		{"unset", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "shards", Namespace: "default"},
+				Spec: batchv1.JobSpec{
+					CompletionMode: tt.mode,
+					Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"job-name": "shards"}},
+				},
+			}
+			r := New(fake.NewSimpleClientset(job))
+
+			workloads, err := r.Resolve(context.Background(), &types.Options{Namespace: "default", ResourceName: "shards", ResourceType: "job"})
+			if err != nil {
+				t.Fatalf("resolve failed: %v", err)
+			}
+			if len(workloads) != 1 || workloads[0].Job == nil || workloads[0].Job.Indexed != tt.expected {
+				t.Errorf("expected Indexed %v, got %+v", tt.expected, workloads)
+			}
+		})
+	}
+}
+
+// collectedPodNames collects the pods of each workload the way the command does and returns their names
+func collectedPodNames(t *testing.T, clientset *fake.Clientset, workloads []types.WorkloadInfo, options *types.Options) []string {
+	t.Helper()
+	var names []string
+	for _, workload := range workloads {
+		pods, err := collector.New(clientset, nil).CollectPods(context.Background(), workload, options)
+		if err != nil {
+			t.Fatalf("collect %s/%s failed: %v", workload.Kind, workload.Name, err)
+		}
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestResolveByOwnerCollectsOnlyOwnedPods(t *testing.T) {
+	controller := true
+	ownedPod := func(name, owner string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				Labels:          labels,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "TaskRun", Name: owner, Controller: &controller}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		pods     []*corev1.Pod
+		expected []string
+	}{
+		{
+			name: "owned pods share no labels",
+			pods: []*corev1.Pod{
+				ownedPod("build-a", "build", map[string]string{"step": "a"}),
+				ownedPod("build-b", "build", map[string]string{"step": "b"}),
+				{ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+			},
+			expected: []string{"build-a", "build-b"},
+		},
+		{
+			name: "another owner's pods share the labels",
+			pods: []*corev1.Pod{
+				ownedPod("build-a", "build", map[string]string{"pipeline": "ci"}),
+				ownedPod("build-b", "build", map[string]string{"pipeline": "ci"}),
+				ownedPod("test-a", "test", map[string]string{"pipeline": "ci"}),
+			},
+			expected: []string{"build-a", "build-b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objects []runtime.Object
+			for _, pod := range tt.pods {
+				objects = append(objects, pod)
+			}
+			clientset := fake.NewSimpleClientset(objects...)
+			options := &types.Options{Namespace: "default", ResourceName: "build", OwnerKind: "TaskRun"}
+
+			workloads, err := New(clientset).Resolve(context.Background(), options)
+			if err != nil {
+				t.Fatalf("resolve failed: %v", err)
+			}
+			if got := collectedPodNames(t, clientset, workloads, options); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected pods %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestResolveBySelectorCollectsCustomOwnersPods(t *testing.T) {
+	controller := true
+	pod := func(name, owner string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				Labels:          labels,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "TaskRun", Name: owner, Controller: &controller}},
+			},
+		}
+	}
+	clientset := fake.NewSimpleClientset(
+		pod("build-a", "build", map[string]string{"team": "ci", "step": "a"}),
+		pod("build-b", "build", map[string]string{"team": "ci", "step": "b"}),
+		pod("test-a", "test", map[string]string{"team": "ci", "step": "a"}),
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	)
+	options := &types.Options{Namespace: "default", Selector: "team=ci"}
+
+	workloads, err := New(clientset).Resolve(context.Background(), options)
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if len(workloads) != 2 {
+		t.Fatalf("expected one workload per TaskRun, got %+v", workloads)
+	}
+	expected := []string{"build-a", "build-b", "test-a"}
+	if got := collectedPodNames(t, clientset, workloads, options); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected pods %v, got %v", expected, got)
+	}
+}
+
+func TestResolveBySelectorKeepsUserSelector(t *testing.T) {
+	controller := true
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-7d9f",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &controller}},
+		},
+	}
+	pod := func(name, ownerKind, owner string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				Labels:          labels,
+				OwnerReferences: []metav1.OwnerReference{{Kind: ownerKind, Name: owner, Controller: &controller}},
+			},
+		}
+	}
+	clientset := fake.NewSimpleClientset(deployment, replicaSet,
+		pod("web-7d9f-stable", "ReplicaSet", "web-7d9f", map[string]string{"app": "web", "track": "stable"}),
+		pod("web-7d9f-canary", "ReplicaSet", "web-7d9f", map[string]string{"app": "web", "track": "canary"}),
+		pod("cache-0", "StatefulSet", "cache", map[string]string{"app": "cache", "track": "canary"}),
+	)
+	options := &types.Options{Namespace: "default", Selector: "track=canary"}
+
+	workloads, err := New(clientset).Resolve(context.Background(), options)
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if len(workloads) != 2 {
+		t.Fatalf("expected the Deployment and the StatefulSet, got %+v", workloads)
+	}
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "get" && action.GetResource().Resource != "replicasets" {
+			t.Errorf("expected no owner lookups, got a get of %s", action.GetResource().Resource)
+		}
+	}
+	expected := []string{"cache-0", "web-7d9f-canary"}
+	if got := collectedPodNames(t, clientset, workloads, options); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected pods %v, got %v", expected, got)
+	}
+}
+
+func TestGetWorkloadFromPodCachesReplicaSets(t *testing.T) {
+	controller := true
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-7d9f",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &controller}},
+		},
+	}
+	objects := []runtime.Object{replicaSet}
+	for _, name := range []string{"web-7d9f-a", "web-7d9f-b", "web-7d9f-c"} {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				Labels:          map[string]string{"app": "web"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-7d9f", Controller: &controller}},
+			},
+		})
+	}
+	clientset := fake.NewSimpleClientset(objects...)
+
+	owners, matched, err := New(clientset).MatchSelector(context.Background(), &types.Options{Namespace: "default", Selector: "app=web"})
+	if err != nil {
+		t.Fatalf("match failed: %v", err)
+	}
+	if matched != 3 || len(owners) != 1 || owners[0].Kind != "Deployment" {
+		t.Fatalf("expected 3 pods of Deployment web, got %d: %+v", matched, owners)
+	}
+
+	lookups := 0
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "get" && action.GetResource().Resource == "replicasets" {
+			lookups++
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("expected the shared ReplicaSet to be fetched once, got %d lookups", lookups)
+	}
+}
+
+func TestResolveWorkQueueJob(t *testing.T) {
+	parallelism := int32(3)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "queue", Namespace: "default"},
+		Spec: batchv1.JobSpec{
+			Parallelism: &parallelism,
+			Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"job-name": "queue"}},
+		},
+		Status: batchv1.JobStatus{Succeeded: 1},
+	}
+	r := New(fake.NewSimpleClientset(job))
+
+	for _, resourceType := range []string{"", "job"} {
+		workloads, err := r.Resolve(context.Background(), &types.Options{Namespace: "default", ResourceName: "queue", ResourceType: resourceType})
+		if err != nil {
+			t.Fatalf("resolve %q failed: %v", resourceType, err)
+		}
+		if len(workloads) != 1 || workloads[0].Replicas != "1/1" || workloads[0].Job == nil || workloads[0].Job.Parallelism != 3 {
+			t.Errorf("resolve %q: expected 1/1 with parallelism 3, got %+v", resourceType, workloads)
+		}
+	}
+}
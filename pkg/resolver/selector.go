@@ -0,0 +1,138 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/nareshku/kubectl-container-status/pkg/collector"
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// foundTokenPattern pulls the offending token out of a labels.Parse error, e.g. "found 'b', expected: ..."
+var foundTokenPattern = regexp.MustCompile(`found '([^']*)'`)
+
+// ParseSelector parses a label selector, including set-based expressions such as "tier in (web,api)".
+// A malformed selector is reported with the selector echoed back and a caret under the offending token.
+func ParseSelector(selector string) (labels.Selector, error) {
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		at := selectorErrorOffset(selector, err)
+		return nil, fmt.Errorf("invalid selector %q: %w\n  %s\n  %s^", selector, err, selector, strings.Repeat(" ", at))
+	}
+	return parsed, nil
+}
+
+// selectorErrorOffset finds where in the selector parsing failed. Requirements are parsed left to right,
+// so the first comma-separated requirement that fails on its own is the offending one, and the token the
+// parser reported is looked up within it. An empty token means the expression ended too early.
+func selectorErrorOffset(selector string, err error) int {
+	token := ""
+	if match := foundTokenPattern.FindStringSubmatch(err.Error()); match != nil {
+		token = match[1]
+	}
+
+	start := 0
+	for _, requirement := range splitRequirements(selector) {
+		if strings.TrimSpace(requirement) == "" {
+			// An empty requirement, e.g. "a=b,,c", fails on the comma that follows it
+			return min(start+len(requirement), len(selector)-1)
+		}
+		if _, err := labels.Parse(requirement); err == nil {
+			start += len(requirement) + 1
+			continue
+		}
+		if token == "" {
+			return start + len(strings.TrimRight(requirement, " "))
+		}
+		if at := strings.LastIndex(requirement, token); at >= 0 {
+			return start + at
+		}
+		return start
+	}
+	return len(selector)
+}
+
+// splitRequirements splits a selector on the commas between requirements, leaving the commas inside
+// set-based value lists such as "in (a,b)" alone
+func splitRequirements(selector string) []string {
+	var requirements []string
+	depth, start := 0, 0
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				requirements = append(requirements, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(requirements, selector[start:])
+}
+
+// MatchSelector lists the pods matching --selector and groups them by the owner they resolve to, without
+// collecting any pod details. Owners are sorted by kind and name.
+func (r *Resolver) MatchSelector(ctx context.Context, options *types.Options) ([]types.SelectorOwner, int, error) {
+	selector, err := ParseSelector(options.Selector)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Owners may have changed since the last run, as in Resolve
+	r.replicaSets = nil
+
+	namespace := options.Namespace
+	if options.AllNamespaces {
+		namespace = ""
+	}
+
+	pods, err := collector.ListPods(ctx, r.clientset.CoreV1().Pods(namespace), metav1.ListOptions{
+		LabelSelector: selector.String(),
+		FieldSelector: options.FieldSelector,
+	}, options.ChunkSize)
+	if err != nil {
+		return nil, 0, collector.PodListError(err, namespace)
+	}
+
+	owners := make(map[string]*types.SelectorOwner)
+	for _, pod := range pods {
+		owner := types.SelectorOwner{Kind: "Pod", Name: pod.Name, Namespace: pod.Namespace}
+		if workload := r.getWorkloadFromPod(ctx, &pod); workload != nil {
+			owner = types.SelectorOwner{Kind: workload.Kind, Name: workload.Name, Namespace: workload.Namespace}
+		}
+		if options.OwnerKind != "" && !strings.EqualFold(owner.Kind, options.OwnerKind) {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s/%s", owner.Kind, owner.Namespace, owner.Name)
+		if existing, exists := owners[key]; exists {
+			existing.Pods++
+			continue
+		}
+		owner.Pods = 1
+		owners[key] = &owner
+	}
+
+	matched := make([]types.SelectorOwner, 0, len(owners))
+	for _, owner := range owners {
+		matched = append(matched, *owner)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Kind != matched[j].Kind {
+			return matched[i].Kind < matched[j].Kind
+		}
+		if matched[i].Namespace != matched[j].Namespace {
+			return matched[i].Namespace < matched[j].Namespace
+		}
+		return matched[i].Name < matched[j].Name
+	})
+	return matched, len(pods), nil
+}
@@ -0,0 +1,137 @@
+package resolver
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		caret    string // The selector line and the caret line under it; empty for a valid selector
+	}{
+		{selector: "app=web,tier in (frontend,api)"},
+		{selector: "!canary,env notin (dev)"},
+		{selector: "app in a", caret: "  app in a\n         ^"},
+		{selector: "app=web,tier in (a,b", caret: "  app=web,tier in (a,b\n                      ^"},
+		{selector: "app b", caret: "  app b\n      ^"},
+		{selector: "app=web,,tier=api", caret: "  app=web,,tier=api\n          ^"},
+		{selector: "app=web,!=x", caret: "  app=web,!=x\n          ^"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseSelector(tt.selector)
+		if tt.caret == "" {
+			if err != nil {
+				t.Errorf("%q: unexpected error %v", tt.selector, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%q: expected an error", tt.selector)
+			continue
+		}
+		if !strings.HasSuffix(err.Error(), "\n"+tt.caret) {
+			t.Errorf("%q: expected the error to end with\n%s\ngot\n%s", tt.selector, tt.caret, err)
+		}
+	}
+}
+
+func TestMatchSelector(t *testing.T) {
+	controller := true
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-7d9f",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &controller}},
+		},
+	}
+	managed := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				Labels:          map[string]string{"app": "web", "tier": "frontend"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-7d9f", Controller: &controller}},
+			},
+		}
+	}
+	debug := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-debug", Namespace: "default", Labels: map[string]string{"app": "web", "tier": "debug"}},
+	}
+	r := New(fake.NewSimpleClientset(replicaSet, managed("web-7d9f-a"), managed("web-7d9f-b"), debug))
+
+	owners, matched, err := r.MatchSelector(context.Background(), &types.Options{Namespace: "default", Selector: "app=web"})
+	if err != nil {
+		t.Fatalf("MatchSelector() failed: %v", err)
+	}
+	expected := []types.SelectorOwner{
+		{Kind: "Deployment", Name: "web", Namespace: "default", Pods: 2},
+		{Kind: "Pod", Name: "web-debug", Namespace: "default", Pods: 1},
+	}
+	if matched != 3 || !reflect.DeepEqual(owners, expected) {
+		t.Errorf("expected 3 pods owned by %+v, got %d owned by %+v", expected, matched, owners)
+	}
+
+	owners, matched, err = r.MatchSelector(context.Background(), &types.Options{Namespace: "default", Selector: "tier notin (frontend)"})
+	if err != nil {
+		t.Fatalf("MatchSelector() with a set-based selector failed: %v", err)
+	}
+	if matched != 1 || len(owners) != 1 || owners[0].Name != "web-debug" {
+		t.Errorf("expected only web-debug, got %d pods owned by %+v", matched, owners)
+	}
+}
+
+func TestSelectorCollectionMatchesDryRun(t *testing.T) {
+	controller := true
+	pod := func(name, tier string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				Labels:          map[string]string{"app": "shop", "tier": tier},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "shop", Controller: &controller}},
+			},
+		}
+	}
+	clientset := fake.NewSimpleClientset(pod("shop-0", "web"), pod("shop-1", "api"), pod("shop-2", "db"))
+
+	tests := []struct {
+		selector string
+		expected []string
+	}{
+		{selector: "tier in (web,api)", expected: []string{"shop-0", "shop-1"}},
+		{selector: "tier!=db", expected: []string{"shop-0", "shop-1"}},
+		{selector: "tier notin (web)", expected: []string{"shop-1", "shop-2"}},
+		{selector: "app=shop,tier", expected: []string{"shop-0", "shop-1", "shop-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			options := &types.Options{Namespace: "default", Selector: tt.selector}
+			r := New(clientset)
+
+			_, matched, err := r.MatchSelector(context.Background(), options)
+			if err != nil {
+				t.Fatalf("dry run failed: %v", err)
+			}
+			workloads, err := r.Resolve(context.Background(), options)
+			if err != nil {
+				t.Fatalf("resolve failed: %v", err)
+			}
+			got := collectedPodNames(t, clientset, workloads, options)
+			if matched != len(got) || !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("dry run matched %d pods, collected %v, expected %v", matched, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+// endpointSliceController is the managed-by value of the EndpointSlices Kubernetes maintains from a Service's selector
+const endpointSliceController = "endpointslice-controller.k8s.io"
+
+// resolveService resolves a Service to the pods its selector matches, grouped by their owning workloads
+func (r *Resolver) resolveService(ctx context.Context, options *types.Options) ([]types.WorkloadInfo, error) {
+	service, err := r.clientset.CoreV1().Services(options.Namespace).Get(ctx, options.ResourceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+	return r.serviceWorkloads(ctx, service, options)
+}
+
+// serviceWorkloads selects the Service's pods the way the endpoints controller does. When they all belong
+// to one owner the Service itself is the workload; otherwise each owner is its own workload, still limited
+// to the pods the Service routes to. Endpoints that don't come from the selector are noted on every workload.
+func (r *Resolver) serviceWorkloads(ctx context.Context, service *corev1.Service, options *types.Options) ([]types.WorkloadInfo, error) {
+	manual := r.manualEndpoints(ctx, service)
+	if len(service.Spec.Selector) == 0 {
+		if len(manual) == 0 {
+			return nil, fmt.Errorf("service %s has no selector and no endpoints, so no pods back it", service.Name)
+		}
+		return nil, fmt.Errorf("service %s has no selector; its endpoints are managed manually: %s", service.Name, strings.Join(manual, ", "))
+	}
+
+	selectorOptions := *options
+	selectorOptions.Selector = labels.SelectorFromSet(service.Spec.Selector).String()
+	selectorOptions.Namespace = service.Namespace
+	selectorOptions.AllNamespaces = false
+	selectorOptions.ResourceName = ""
+	selectorOptions.ResourceType = ""
+	workloads, err := r.resolveBySelector(ctx, &selectorOptions)
+	if err != nil {
+		return nil, fmt.Errorf("service %s: %w", service.Name, err)
+	}
+
+	if len(workloads) == 1 && workloads[0].Kind == "Selector" {
+		workloads[0].Kind = "Service"
+		workloads[0].Name = service.Name
+		workloads[0].Labels = service.Labels
+		workloads[0].Selector = service.Spec.Selector
+	}
+	if len(manual) > 0 {
+		note := fmt.Sprintf("service %s also routes to endpoints not backed by its selector: %s", service.Name, strings.Join(manual, ", "))
+		for i := range workloads {
+			workloads[i].Warnings = append(workloads[i].Warnings, note)
+		}
+	}
+	return workloads, nil
+}
+
+// manualEndpoints returns the addresses in the Service's EndpointSlices that Kubernetes didn't derive
+// from its selector, e.g. slices created by hand or mirrored from a manually managed Endpoints object.
+// The check is best effort: when the slices can't be listed, nothing is reported.
+func (r *Resolver) manualEndpoints(ctx context.Context, service *corev1.Service) []string {
+	slices, err := r.clientset.DiscoveryV1().EndpointSlices(service.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{discoveryv1.LabelServiceName: service.Name}).String(),
+	})
+	if err != nil {
+		return nil
+	}
+
+	var addresses []string
+	for _, slice := range slices.Items {
+		if slice.Labels[discoveryv1.LabelManagedBy] == endpointSliceController {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			addresses = append(addresses, endpoint.Addresses...)
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,163 @@
+package resolver
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nareshku/kubectl-container-status/pkg/types"
+)
+
+func TestResolveService(t *testing.T) {
+	controller := true
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "api-7d9f",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "api", Controller: &controller}},
+		},
+	}
+	apiPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "api-7d9f-abcde",
+			Namespace:       "default",
+			Labels:          map[string]string{"app": "api", "tier": "web"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "api-7d9f", Controller: &controller}},
+		},
+	}
+	canaryPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "api-canary-0",
+			Namespace:       "default",
+			Labels:          map[string]string{"app": "api", "tier": "web"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "api-canary", Controller: &controller}},
+		},
+	}
+	service := func(name string, selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Selector: selector},
+		}
+	}
+	endpointSlice := func(name, service, managedBy string, addresses ...string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{
+				discoveryv1.LabelServiceName: service,
+				discoveryv1.LabelManagedBy:   managedBy,
+			}},
+			Endpoints: []discoveryv1.Endpoint{{Addresses: addresses}},
+		}
+	}
+
+	r := New(fake.NewSimpleClientset(
+		replicaSet, apiPod, canaryPod,
+		service("api", map[string]string{"app": "api"}),
+		service("api-stable", map[string]string{"app": "api", "tier": "web"}),
+		service("legacy-db", nil),
+		endpointSlice("api-abc", "api", endpointSliceController, "10.0.0.1"),
+		endpointSlice("api-manual", "api", "staff", "192.168.1.10"),
+		endpointSlice("legacy-db-1", "legacy-db", "endpointslicemirroring-controller.k8s.io", "10.20.0.5"),
+	))
+
+	// Pods from two owners are grouped by owner, and the hand-made slice is noted
+	workloads, err := r.Resolve(context.Background(), &types.Options{Namespace: "default", ResourceName: "api", ResourceType: "svc"})
+	if err != nil {
+		t.Fatalf("resolve service/api failed: %v", err)
+	}
+	kinds := make(map[string]bool)
+	for _, workload := range workloads {
+		kinds[workload.Kind+"/"+workload.Name] = true
+		if len(workload.Warnings) != 1 || !strings.Contains(workload.Warnings[0], "endpoints not backed by its selector: 192.168.1.10") {
+			t.Errorf("expected a note about the manual endpoint on %s, got %v", workload.Name, workload.Warnings)
+		}
+	}
+	if len(workloads) != 2 || !kinds["Deployment/api"] || !kinds["StatefulSet/api-canary"] {
+		t.Errorf("expected the Deployment and the StatefulSet, got %+v", workloads)
+	}
+
+	// Auto-detection finds the Service when no workload has its name
+	workloads, err = r.Resolve(context.Background(), &types.Options{Namespace: "default", ResourceName: "api-stable"})
+	if err != nil {
+		t.Fatalf("auto-detecting api-stable failed: %v", err)
+	}
+	if len(workloads) != 2 {
+		t.Errorf("expected both owners behind api-stable, got %+v", workloads)
+	}
+
+	if _, err := r.Resolve(context.Background(), &types.Options{Namespace: "default", ResourceName: "legacy-db", ResourceType: "service"}); err == nil || !strings.Contains(err.Error(), "managed manually: 10.20.0.5") {
+		t.Errorf("expected an error naming the manually managed endpoints, got %v", err)
+	}
+}
+
+func TestResolveServiceWithSingleOwner(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "default", Labels: map[string]string{"app": "debug"}}}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "debug-svc", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "debug"}},
+	}
+	r := New(fake.NewSimpleClientset(pod, service))
+
+	workloads, err := r.Resolve(context.Background(), &types.Options{Namespace: "default", ResourceName: "debug-svc", ResourceType: "service"})
+	if err != nil {
+		t.Fatalf("resolve service/debug-svc failed: %v", err)
+	}
+	if len(workloads) != 1 || workloads[0].Kind != "Service" || workloads[0].Name != "debug-svc" || workloads[0].Selector["app"] != "debug" {
+		t.Errorf("expected the Service itself selecting app=debug, got %+v", workloads)
+	}
+}
+
+func TestResolveServiceCollectsOnlyRoutedPods(t *testing.T) {
+	controller := true
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+		},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "api-7d9f",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "api", Controller: &controller}},
+		},
+	}
+	pod := func(name, ownerKind, owner string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				Labels:          labels,
+				OwnerReferences: []metav1.OwnerReference{{Kind: ownerKind, Name: owner, Controller: &controller}},
+			},
+		}
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-public", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "api", "tier": "public"}},
+	}
+	clientset := fake.NewSimpleClientset(deployment, replicaSet, service,
+		pod("api-7d9f-public", "ReplicaSet", "api-7d9f", map[string]string{"app": "api", "tier": "public"}),
+		pod("api-7d9f-internal", "ReplicaSet", "api-7d9f", map[string]string{"app": "api", "tier": "internal"}),
+		pod("api-canary-0", "StatefulSet", "api-canary", map[string]string{"app": "api", "tier": "public"}),
+	)
+	options := &types.Options{Namespace: "default", ResourceName: "api-public", ResourceType: "service"}
+
+	workloads, err := New(clientset).Resolve(context.Background(), options)
+	if err != nil {
+		t.Fatalf("resolve service/api-public failed: %v", err)
+	}
+	if len(workloads) != 2 {
+		t.Fatalf("expected the Deployment and the StatefulSet, got %+v", workloads)
+	}
+	expected := []string{"api-7d9f-public", "api-canary-0"}
+	if got := collectedPodNames(t, clientset, workloads, options); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected only the pods the service routes to %v, got %v", expected, got)
+	}
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"regexp"
 	"time"
 )
 
@@ -14,6 +15,7 @@ type ContainerInfo struct {
 	LastState         string
 	LastStateReason   string
 	ExitCode          *int32
+	Signal            int32 // Signal reported by the runtime with the exit code, 0 when none
 	StartedAt         *time.Time
 	FinishedAt        *time.Time
 	LastRestartTime   *time.Time
@@ -26,7 +28,42 @@ type ContainerInfo struct {
 	Environment       []EnvVar
 	Ports             []PortInfo
 	TerminationReason string
+	StatusMessage     string   // Detail for the current waiting state (e.g. image pull error)
 	Logs              []string // Container logs (recent lines)
+
+	// Set by the analyzer so every output format can report per-container health
+	Health HealthStatus
+
+	// Effective security context, with pod-level defaults applied
+	Security SecurityInfo
+
+	// Image split into registry, repository, tag and digest
+	ImageRef ImageRef
+
+	// How the previous instance ran and exited, from the last termination state (nil when it never terminated)
+	LastStartedAt  *time.Time
+	LastFinishedAt *time.Time
+	LastExitCode   *int32
+	LastSignal     int32
+}
+
+// ImageRef is a container image reference broken into its parts, normalized the way the
+// container runtime resolves it (bare names come from docker.io/library)
+type ImageRef struct {
+	Registry   string // e.g. docker.io, registry.example.com:5000
+	Repository string // e.g. library/nginx, org/app
+	Tag        string // Empty when the image is pinned by digest only
+	Digest     string // e.g. sha256:...
+}
+
+// SecurityInfo summarizes a container's effective security context
+type SecurityInfo struct {
+	RunAsUser              *int64 // Nil when neither the pod nor the container sets it (the image decides)
+	RunAsNonRoot           *bool
+	Privileged             bool
+	ReadOnlyRootFilesystem bool
+	CapabilitiesAdded      []string
+	CapabilitiesDropped    []string
 }
 
 // ResourceInfo represents resource usage and limits
@@ -37,8 +74,26 @@ type ResourceInfo struct {
 	CPUPercentage float64
 	MemRequest    string
 	MemLimit      string
-	MemUsage      string
+	MemUsage      string // Working set, see ContainerMetrics.MemoryUsage
 	MemPercentage float64
+
+	// Resident set size, only set when the metrics source reports it
+	MemRSS string
+
+	// Usage as a percentage of the request, zero when no request is set
+	CPURequestPercentage float64
+	MemRequestPercentage float64
+
+	// Whether a usage reading was available; without one the usage above is zero, not measured
+	HasCPUUsage bool
+	HasMemUsage bool
+
+	// Usage readings taken with --samples, oldest first; the usage above is the latest of them
+	Samples []MetricSample
+
+	// Exact usage, for --raw-metrics
+	CPUUsageMilli int64
+	MemUsageBytes int64
 }
 
 // ProbeInfo represents probe configuration and status
@@ -51,12 +106,19 @@ type ProbeInfo struct {
 // ProbeDetails represents individual probe details
 type ProbeDetails struct {
 	Configured   bool
-	Type         string // HTTP, TCP, Exec
+	Type         string // HTTP, TCP, Exec, gRPC
 	Path         string
 	Port         string
+	Service      string // gRPC health service name
 	Passing      bool
 	FailureCount int32
 	LastError    string
+	Deadline     time.Duration // Startup probes: how long the container may take to start before it is killed
+
+	// HTTP probe request details, shown with --wide-probes
+	Scheme  string   // HTTP or HTTPS
+	Host    string   // Host to connect to, empty for the pod IP
+	Headers []string // Custom headers as "Name: value", with credentials masked
 }
 
 // VolumeInfo represents volume mount information
@@ -65,6 +127,8 @@ type VolumeInfo struct {
 	MountPath  string
 	VolumeType string
 	Details    string
+	ReadOnly   bool
+	SubPath    string
 }
 
 // PortInfo represents an exposed container port
@@ -106,6 +170,63 @@ type PodInfo struct {
 	Annotations    map[string]string // Pod annotations
 	Conditions     []PodCondition    // Pod conditions (PodScheduled, etc.)
 	Network        NetworkInfo       // Network information
+
+	// Why the pod is in its phase (status.reason and status.message), e.g. Evicted and the resource the node ran low on
+	StatusReason  string
+	StatusMessage string
+
+	// Debug containers added with kubectl debug
+	EphemeralContainers []ContainerInfo
+
+	// Scheduling priority, which decides preemption and eviction order
+	PriorityClassName string
+	Priority          *int32
+
+	// Quality of service class from status.qosClass: Guaranteed, Burstable or BestEffort
+	QOSClass string
+
+	// Scheduler that places the pod, and how long after creation it was scheduled (nil until it is)
+	SchedulerName     string
+	SchedulingLatency *time.Duration
+
+	// Template revision the pod was created from (pod-template-hash or controller-revision-hash label)
+	Revision string
+
+	// Completion index of a pod from an Indexed Job, empty otherwise
+	CompletionIndex string
+
+	// Termination tracking for pods with a deletion timestamp
+	TerminatingFor         time.Duration // Time since deletion was requested (zero if not terminating)
+	TerminationGracePeriod time.Duration // Grace period the pod was given to shut down
+	Finalizers             []string      // Pod finalizers that can block deletion
+
+	// Identity for correlating with audit and API server logs
+	UID             string
+	ResourceVersion string
+
+	// Pod (anti-)affinity and topology spread rules, summarized for pending pods only
+	SchedulingConstraints []string
+
+	// Node placement rules, which decide which nodes the pod may land on
+	NodeSelector map[string]string
+	NodeAffinity []string // Summarized required and preferred node affinity terms
+	Tolerations  []string // Summarized tolerations, without the defaults added to every pod
+
+	// Usage relative to the node's allocatable capacity, only collected with --node-context
+	NodeUsage *NodeUsageInfo
+
+	// Unhealthy conditions of the pod's node, e.g. NotReady or MemoryPressure, only collected with --node-health
+	NodeProblems []string
+}
+
+// NodeUsageInfo is a pod's resource usage as a share of its node's allocatable capacity
+type NodeUsageInfo struct {
+	NodeName       string
+	CPUAllocatable string // Formatted like pod usage, e.g. 3.9 or 940m
+	MemAllocatable string // Formatted like pod usage, e.g. 14.5Gi
+	CPUPercentage  float64
+	MemPercentage  float64
+	HasUsage       bool // False when no pod metrics were available to compare against
 }
 
 // NetworkInfo represents pod network information
@@ -118,11 +239,14 @@ type NetworkInfo struct {
 
 // EventInfo represents kubernetes events
 type EventInfo struct {
-	Time    time.Time
-	Type    string
-	Reason  string
-	Message string
-	PodName string // Track which pod this event belongs to
+	Time      time.Time // Last time the event was observed
+	FirstSeen time.Time // First time the event was observed
+	Count     int32     // Number of occurrences
+	Type      string
+	Reason    string
+	Message   string
+	PodName   string // Track which pod this event belongs to
+	Container string // Container the event is about, empty for pod-level events
 }
 
 // PodMetrics represents pod-level metrics
@@ -130,12 +254,32 @@ type PodMetrics struct {
 	CPUUsage    string
 	MemoryUsage string
 	Containers  map[string]ContainerMetrics
+
+	// Exact pod totals, for --raw-metrics
+	CPUUsageMilli    int64
+	MemoryUsageBytes int64
 }
 
 // ContainerMetrics represents container-level metrics
 type ContainerMetrics struct {
-	CPUUsage    string
+	CPUUsage string
+
+	// Working set memory, which is what metrics-server reports and what kubelet eviction acts on. It leaves
+	// out inactive page cache, so it is neither RSS nor the cgroup's total usage.
 	MemoryUsage string
+
+	// Further memory figures, for metrics sources that report them (metrics-server only has the working set)
+	MemoryRSS string
+
+	// Every reading taken with --samples, oldest first; empty for a single reading
+	Samples []MetricSample
+}
+
+// MetricSample is one container usage reading in a --samples series
+type MetricSample struct {
+	Time        time.Time
+	CPUMilli    int64
+	MemoryBytes int64
 }
 
 // WorkloadInfo represents workload information
@@ -148,23 +292,190 @@ type WorkloadInfo struct {
 	Selector  map[string]string
 	Pods      []PodInfo
 	Health    HealthStatus
+	Job       *JobInfo  // Retry and deadline settings, set for Jobs only
+	PDBs      []PDBInfo // PodDisruptionBudgets covering the workload's pods, collected with --pdb
+	HPAs      []HPAInfo // HorizontalPodAutoscalers scaling the workload, collected with --hpa
+
+	// Restarts across the workload's containers grouped by why they restarted, most frequent first
+	RestartReasons []RestartReasonCount
+
+	// Container name to image in the controller's current pod template (Deployments, StatefulSets, DaemonSets)
+	TemplateImages map[string]string
+
+	// Revision of the controller's current pod template, matched against each pod's Revision: the current
+	// ReplicaSet's pod-template-hash for Deployments, the update revision for StatefulSets and DaemonSets
+	TemplateRevision string
+
+	// Non-fatal problems hit while collecting this workload, e.g. metrics or logs that couldn't be read
+	Warnings []string
+
+	MetricsUnavailable bool // Usage couldn't be read: no metrics API, reading it was forbidden, or a dump without --metrics-from
+	MetricsForbidden   bool // RBAC denied reading pod metrics
+	EventsForbidden    bool // RBAC denied listing events
+
+	// Names of the workload's pods when they were found through owner references rather than a selector;
+	// collection keeps only these pods, since labels alone may match other owners' pods too
+	PodNames []string
+
+	// The --selector a Selector workload stands for, in full set-based form (e.g. "tier in (web,api)");
+	// used instead of Selector, which can only express equality
+	LabelSelector string
+}
+
+// RestartReasonCount is how many container restarts in a workload had the same reason, e.g. OOMKilled
+type RestartReasonCount struct {
+	Reason string
+	Count  int32
+}
+
+// SelectorOwner is an owner that pods matched by --selector resolve to, and how many of them, for --dry-run
+type SelectorOwner struct {
+	Kind      string // Resolved owner kind, e.g. Deployment, or Pod for standalone pods
+	Name      string
+	Namespace string
+	Pods      int
+}
+
+// PDBInfo summarizes a PodDisruptionBudget's current eviction allowance
+type PDBInfo struct {
+	Name               string
+	DisruptionsAllowed int32
+	CurrentHealthy     int32
+	ExpectedPods       int32
+}
+
+// HPAInfo summarizes a HorizontalPodAutoscaler's replica decision and the metrics behind it
+type HPAInfo struct {
+	Name            string
+	CurrentReplicas int32
+	DesiredReplicas int32
+	MinReplicas     int32
+	MaxReplicas     int32
+	Metrics         []HPAMetric
+}
+
+// HPAMetric is one scaling metric's current value against its target, e.g. cpu at 85% of a 70% target
+type HPAMetric struct {
+	Name    string // Resource name (cpu, memory) or custom/external metric name
+	Current string // e.g. "85%" or "120m"; "<unknown>" until the autoscaler has read the metric
+	Target  string // e.g. "70%" or "100m"
+}
+
+// JobInfo holds the Job settings that decide how it retries and when it gives up
+type JobInfo struct {
+	Completions           int32
+	Parallelism           int32
+	Succeeded             int32
+	Failed                int32
+	BackoffLimit          int32
+	ActiveDeadlineSeconds *int64
+	RestartPolicy         string
+	Indexed               bool // completionMode Indexed, where each pod runs one completion index
+}
+
+// UsageThresholds are the usage percentages at which resource bars and values turn yellow and red
+type UsageThresholds struct {
+	Warning  float64
+	Critical float64
 }
 
 // Options represents command-line flags and options
 type Options struct {
-	ResourceName      string
-	ResourceType      string
-	Namespace         string
-	Context           string // Kubernetes context to use
-	AllNamespaces     bool
-	OutputFormat      string // json, yaml, table
-	NoColor           bool
-	Problematic       bool
-	SortBy            string
-	ShowLogs          bool // Show recent container logs
-	ShowResourceUsage bool // Show detailed resource usage (CPU/Memory percentages)
-	SinglePodView     bool // Whether this is a single pod view (vs workload view)
-	Selector          string
+	ResourceName       string
+	ResourceType       string
+	Namespace          string
+	Context            string   // Kubernetes context to use
+	Cluster            string   // Kubeconfig cluster to use, overriding the context's cluster
+	User               string   // Kubeconfig user to use, overriding the context's user
+	Kubeconfig         string   // Explicit kubeconfig path, overriding KUBECONFIG and ~/.kube/config
+	As                 string   // User to impersonate
+	AsGroups           []string // Groups to impersonate
+	AsUID              string   // UID to impersonate
+	AllNamespaces      bool
+	OutputFormat       string // json, yaml, table
+	OutputFile         string // Write the formatted output to this file instead of stdout
+	NoColor            bool
+	Color              string // auto, always or never; auto disables color off a terminal or when NO_COLOR is set
+	Timestamps         bool   // Show absolute timestamps instead of relative ages
+	UTC                bool   // Render absolute timestamps in UTC
+	Timezone           string // Render absolute timestamps in this IANA time zone (e.g. Europe/Berlin)
+	Problematic        bool
+	SortBy             string
+	SortWorkloads      string // Order of the workloads themselves: name, health, restarts (empty keeps resolve order)
+	ShowLogs           bool   // Show recent container logs
+	ShowEvents         bool   // Collect and show pod events
+	NoEvents           bool   // Skip the events lookup entirely; shorthand for ShowEvents=false
+	MaxEvents          int    // Maximum number of events to print per section (0 = unlimited)
+	EventsWarningsOnly bool   // Skip Normal events and keep only warnings and errors
+	EventsSort         string // Event ordering: severity (FailedScheduling, then warnings, then newest) or time
+	ShowEnv            bool   // Collect and show container environment variables
+	ShowResourceUsage  bool   // Show detailed resource usage (CPU/Memory percentages)
+	MetricsFrom        string // Read resource usage from this snapshot file (JSON or CSV) instead of metrics-server
+	AllAnnotations     bool   // Show all pod annotations, including known-noisy ones
+	ResourcesOnly      bool   // Skip events, environment and logs collection for a fast resource view
+	SinglePodView      bool   // Whether this is a single pod view (vs workload view)
+	Selector           string
+	DryRun             bool          // Only report how many pods --selector matches and which owners they resolve to
+	OwnerKind          string        // Owner kind to resolve the resource name against (e.g. Rollout), or to filter selector matches by
+	FieldSelector      string        // Field selector passed through to pod listing (workload and selector views only)
+	ChunkSize          int64         // Page size for pod list requests (0 = fetch everything at once)
+	Compare            string        // Label selector for the comparison set in --compare mode
+	Summary            bool          // Print one roll-up row per workload instead of per-pod tables
+	Top                bool          // Print only a per-container resource usage table with totals, like kubectl top
+	Explain            bool          // Print remediation hints for the issues found after the table output
+	Quiet              bool          // Print one status line per workload and exit non-zero when unhealthy
+	ShowTolerations    bool          // Show pod tolerations in the single-pod view even when the pod is not pending
+	ShowNodeSelector   bool          // Show node selector and node affinity in the single-pod view even when not pending
+	Compact            bool          // Force the narrow workload table, which is also used automatically on narrow terminals
+	ShowIDs            bool          // Show the pod UID and resourceVersion in the single-pod header
+	ShowPDB            bool          // Look up PodDisruptionBudgets covering each workload
+	ShowHPA            bool          // Look up HorizontalPodAutoscalers targeting each workload
+	RawMetrics         bool          // Show exact millicores and bytes instead of rounded cores and Mi/Gi
+	LabelColumns       []string      // Pod label keys to add as workload table columns, like kubectl get -L
+	ShowSecurity       bool          // Show each container's effective security context in the single-pod view
+	WideProbes         bool          // Show HTTP probes' scheme, host and custom headers
+	NodeContext        bool          // Fetch the pod's node and show usage as a share of its allocatable capacity
+	NodeHealth         bool          // Fetch the nodes hosting the pods and flag their unhealthy conditions
+	NodeSummary        bool          // Group workload pods by node and show their health per node
+	EvictionRisk       bool          // Rank workload pods by how likely the kubelet is to evict them under memory pressure
+	WatchProblematic   bool          // Re-collect on an interval and print only pod health transitions
+	WatchInterval      time.Duration // Interval between collections in --watch-problematic and --wait-healthy mode
+	WaitHealthy        bool          // Poll until every workload is healthy, exiting 1 if WaitTimeout passes first
+	WaitTimeout        time.Duration // How long --wait-healthy waits before giving up
+	Bell               bool          // Ring the terminal bell on health transitions
+
+	// Print phase timings to stderr at the end of the run
+	Profile bool
+
+	// Resource bar rendering
+	BarWidth   int             // Segments in resource bars; mini bars use four fifths of it
+	Thresholds UsageThresholds // Usage percentages at which bars and values turn yellow and red
+
+	// Terminal hyperlinks (OSC 8) for pod and node names
+	Hyperlinks bool   // Turned off again when stdout isn't a terminal
+	PodURL     string // URL template with {namespace}, {pod} and {node} placeholders
+	NodeURL    string // URL template with a {node} placeholder
+
+	// Container details in the single-pod view
+	ContainersSummaryOnly bool // Only show details of containers that aren't healthy; healthy ones are counted instead
+	AllContainers         bool // Show details of every container, even in pods with many sidecars
+
+	// Log filter for --logs
+	LogGrep    string         // Keep only log lines matching this regular expression (implies ShowLogs)
+	LogPattern *regexp.Regexp // LogGrep compiled once during flag validation
+
+	// Offline analysis
+	FromFile      []string  // Read pods and events from these kubectl get -o yaml/json dumps instead of a cluster
+	DumpTime      string    // When the dumps were taken (RFC3339), instead of when their files were modified
+	ReferenceTime time.Time // When the shown state was observed, for ages relative to a dump; zero for now
+
+	// Usage sampling for trends
+	Samples        int           // Read metrics this many times per workload; 1 takes a single reading
+	SampleInterval time.Duration // Wait between readings
+
+	// Snapshot and diff mode
+	Snapshot string // Save the collected workloads to this file as JSON
+	Diff     string // Print what changed since the snapshot in this file instead of the usual output
 
 	// Resource-specific flags
 	Deployment  string
@@ -173,7 +484,9 @@ type Options struct {
 	DaemonSet   string
 
 	// Container filter
-	ContainerName string // Filter to show only specific container
+	ContainerName string   // Filter to show only specific container
+	ContainerType string   // Filter to show only init, standard or ephemeral containers ("all" or empty shows every type)
+	Statuses      []string // Show only containers in one of these states, e.g. CrashLoopBackOff (case-insensitive)
 }
 
 // ContainerStatusType represents container status types
@@ -211,6 +524,8 @@ type PodCondition struct {
 	Status  string // True, False, Unknown
 	Reason  string
 	Message string
+
+	LastTransitionTime time.Time // When the condition last changed status
 }
 
 // SortType represents sort options
@@ -223,3 +538,20 @@ const (
 	SortByMemory   SortType = "memory"
 	SortByAge      SortType = "age"
 )
+
+// WorkloadSortType represents the orderings of the top-level workload list
+type WorkloadSortType string
+
+const (
+	WorkloadSortByName     WorkloadSortType = "name"
+	WorkloadSortByHealth   WorkloadSortType = "health"
+	WorkloadSortByRestarts WorkloadSortType = "restarts"
+)
+
+// EventSortType represents the orderings of event lists
+type EventSortType string
+
+const (
+	EventSortBySeverity EventSortType = "severity"
+	EventSortByTime     EventSortType = "time"
+)